@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	configv1alpha1 "k8s.io/component-base/config/v1alpha1"
 )
@@ -66,6 +67,32 @@ type Configuration struct {
 	// QueueVisibility is configuration to expose the information about the top
 	// pending workloads.
 	QueueVisibility *QueueVisibility `json:"queueVisibility,omitempty"`
+
+	// ResourceFlavorDiscovery is configuration to generate ResourceFlavors
+	// from the labels of the Nodes and to publish the capacity of the Nodes
+	// associated with each ResourceFlavor in its status.
+	ResourceFlavorDiscovery *ResourceFlavorDiscovery `json:"resourceFlavorDiscovery,omitempty"`
+
+	// Tracing is configuration to export OpenTelemetry spans of the
+	// scheduling cycles and of the processing of the workloads.
+	// If nil, no spans are exported.
+	Tracing *Tracing `json:"tracing,omitempty"`
+
+	// CapacityCheck is configuration to detect the workloads that can never
+	// fit in their ClusterQueue, not even borrowing all the quota available
+	// in the cohort.
+	// If nil, the workloads are not checked.
+	CapacityCheck *CapacityCheck `json:"capacityCheck,omitempty"`
+
+	// Resources is configuration of how Kueue accounts the resources
+	// requested by the pods of the workloads.
+	Resources *Resources `json:"resources,omitempty"`
+
+	// BatchAdmission is configuration to admit several workloads of the
+	// same ClusterQueue in a scheduling cycle.
+	// If nil, the scheduler evaluates one workload per ClusterQueue in each
+	// cycle.
+	BatchAdmission *BatchAdmission `json:"batchAdmission,omitempty"`
 }
 
 type ControllerManager struct {
@@ -94,6 +121,18 @@ type ControllerManager struct {
 	// +optional
 	PprofBindAddress string `json:"pprofBindAddress,omitempty"`
 
+	// DebugBindAddress is the TCP address that the controller should bind to
+	// for serving the JSON dumps of the scheduler cache and of the queues,
+	// at /debug/kueue/cache and /debug/kueue/queues.
+	// The endpoints are served over HTTPS, with the webhook serving
+	// certificates.
+	// The requests need a bearer token of a user allowed to get the path,
+	// which is checked with TokenReviews and SubjectAccessReviews.
+	// It can be set to "" or "0" to disable the debug endpoints.
+	// The dumps are also logged when the controller receives a SIGUSR2.
+	// +optional
+	DebugBindAddress string `json:"debugBindAddress,omitempty"`
+
 	// Controller contains global configuration options for controllers
 	// registered within this manager.
 	// +optional
@@ -132,6 +171,14 @@ type ControllerMetrics struct {
 	// metrics will be reported.
 	// +optional
 	EnableClusterQueueResources bool `json:"enableClusterQueueResources,omitempty"`
+
+	// EnableLocalQueueMetrics, if true the pending, reserving and admitted
+	// workloads, the resource reservation and usage, the admission wait time
+	// and the evictions will be reported per local queue.
+	// The metrics have one series per local queue, keep it disabled when
+	// there are many local queues.
+	// +optional
+	EnableLocalQueueMetrics bool `json:"enableLocalQueueMetrics,omitempty"`
 }
 
 // ControllerHealth defines the health configs.
@@ -194,6 +241,146 @@ type WaitForPodsReady struct {
 	BlockAdmission *bool `json:"blockAdmission,omitempty"`
 }
 
+type ResourceFlavorDiscovery struct {
+	// Enable when true, indicates that Kueue watches the Nodes and keeps the
+	// aggregate allocatable capacity of the Nodes that match the nodeLabels of
+	// each ResourceFlavor in the ResourceFlavor status. The capacity can be
+	// used by ClusterQueues through nominalQuotaPercentage.
+	// It defaults to false.
+	Enable bool `json:"enable,omitempty"`
+
+	// NodeLabelKeys are the label keys used to group the Nodes.
+	// For every combination of values of these keys found in the Nodes, Kueue
+	// creates a ResourceFlavor whose nodeLabels are that combination.
+	// Nodes that miss any of the keys are not grouped.
+	// If empty, no ResourceFlavor is created.
+	NodeLabelKeys []string `json:"nodeLabelKeys,omitempty"`
+}
+
+type TracingExporter string
+
+const (
+	// TracingExporterOTLP sends the spans to an OpenTelemetry collector
+	// using OTLP over HTTP.
+	TracingExporterOTLP TracingExporter = "otlp"
+
+	// TracingExporterStdout writes the spans to the standard output.
+	TracingExporterStdout TracingExporter = "stdout"
+)
+
+type Tracing struct {
+	// Exporter is the destination of the spans.
+	// Possible values are "otlp" and "stdout".
+	// Defaults to "otlp".
+	Exporter TracingExporter `json:"exporter,omitempty"`
+
+	// Endpoint is the host:port of the OTLP/HTTP receiver of the collector.
+	// Only used by the "otlp" exporter.
+	// Defaults to "localhost:4318".
+	Endpoint *string `json:"endpoint,omitempty"`
+
+	// Insecure when true, makes the "otlp" exporter use plain HTTP instead
+	// of HTTPS.
+	Insecure bool `json:"insecure,omitempty"`
+
+	// SamplingRatePerMillion is the number of traces sampled out of every
+	// million started. Between 0 and 1000000.
+	// Defaults to 1000000.
+	SamplingRatePerMillion *int32 `json:"samplingRatePerMillion,omitempty"`
+}
+
+type CapacityCheckAction string
+
+const (
+	// CapacityCheckReject makes the webhooks of the jobs and of the Workloads
+	// reject the creation of the workloads that can never fit.
+	CapacityCheckReject CapacityCheckAction = "Reject"
+
+	// CapacityCheckWarn makes the webhooks of the jobs and of the Workloads
+	// accept the workloads that can never fit, returning a warning to the
+	// client.
+	CapacityCheckWarn CapacityCheckAction = "Warn"
+
+	// CapacityCheckMarkInadmissible sets the Inadmissible condition in the
+	// workloads that can never fit, while they are pending.
+	CapacityCheckMarkInadmissible CapacityCheckAction = "MarkInadmissible"
+)
+
+type CapacityCheck struct {
+	// Action is what Kueue does with the workloads that can never fit.
+	// Possible values are "Reject", "Warn" and "MarkInadmissible".
+	// Defaults to "Warn".
+	Action CapacityCheckAction `json:"action,omitempty"`
+}
+
+type BatchAdmission struct {
+	// MaxHeadsPerClusterQueue is the maximum number of workloads of a
+	// ClusterQueue that the scheduler evaluates in a cycle. The workloads
+	// are evaluated in queueing order, and the evaluation of a ClusterQueue
+	// stops at the first workload that is not admitted.
+	// Defaults to 10.
+	MaxHeadsPerClusterQueue *int32 `json:"maxHeadsPerClusterQueue,omitempty"`
+}
+
+type Resources struct {
+	// ExcludeResourcePrefixes lists the prefixes of the resource names that
+	// Kueue ignores when it computes the requests of a workload, for example
+	// "example.com/". Quota isn't needed for these resources.
+	ExcludeResourcePrefixes []string `json:"excludeResourcePrefixes,omitempty"`
+
+	// Transformations lists how the requests of the pods for a resource are
+	// converted into the requests of the workload, at most one per input
+	// resource.
+	Transformations []ResourceTransformation `json:"transformations,omitempty"`
+
+	// DeviceClassMappings lists the resources that account for the devices
+	// requested through Dynamic Resource Allocation (DRA). Each
+	// ResourceClaimTemplate referenced by a pod requests one unit of the
+	// resource its ResourceClass is mapped to. The mapped resources are
+	// transformed and excluded like the resources requested by the containers.
+	DeviceClassMappings []DeviceClassMapping `json:"deviceClassMappings,omitempty"`
+}
+
+type DeviceClassMapping struct {
+	// Name is the resource that accounts for the devices in the quota,
+	// for example "example.com/gpu".
+	Name corev1.ResourceName `json:"name"`
+
+	// DeviceClassNames are the names of the ResourceClasses whose devices
+	// are accounted as the resource. A ResourceClass can only be mapped to
+	// one resource.
+	DeviceClassNames []string `json:"deviceClassNames"`
+}
+
+type ResourceTransformationStrategy string
+
+const (
+	// RetainResourceTransformation keeps the input resource in the requests
+	// of the workload, next to the outputs.
+	RetainResourceTransformation ResourceTransformationStrategy = "Retain"
+
+	// ReplaceResourceTransformation removes the input resource from the
+	// requests of the workload, leaving only the outputs.
+	ReplaceResourceTransformation ResourceTransformationStrategy = "Replace"
+)
+
+type ResourceTransformation struct {
+	// Input is the name of the resource requested by the pods.
+	Input corev1.ResourceName `json:"input"`
+
+	// Strategy is whether the input resource is kept in the requests of
+	// the workload. Possible values are "Retain" and "Replace".
+	// Defaults to "Retain".
+	Strategy *ResourceTransformationStrategy `json:"strategy,omitempty"`
+
+	// Outputs are the resources that a unit of the input resource maps to,
+	// with their weights. For example, with an input "example.com/gpu" and
+	// an output "example.com/gpu-memory: 16Gi", a pod requesting 2
+	// "example.com/gpu" requests 32Gi of "example.com/gpu-memory".
+	// The outputs are added to the requests of the same resources, if any.
+	Outputs corev1.ResourceList `json:"outputs,omitempty"`
+}
+
 type InternalCertManagement struct {
 
 	// Enable controls whether to enable internal cert management or not.
@@ -235,6 +422,73 @@ type Integrations struct {
 	Frameworks []string `json:"frameworks,omitempty"`
 	// PodOptions defines kueue controller behaviour for pod objects
 	PodOptions *PodIntegrationOptions `json:"podOptions,omitempty"`
+	// ExternalFrameworks lists the job types, in the "Kind.version.group"
+	// format, for example "FooJob.v1.example.com", that are managed by
+	// controllers outside of Kueue which create their own Workloads.
+	// The Jobs and Pods owned by objects of these types are handled as the
+	// children of a managed parent: they aren't queued separately and the
+	// pod integration doesn't manage them.
+	ExternalFrameworks []string `json:"externalFrameworks,omitempty"`
+	// GenericFrameworks lists custom resources, with a field to suspend them,
+	// that Kueue manages without a dedicated integration. Each entry is
+	// enabled under the framework name "<group>/<lowercase kind>" and
+	// doesn't need to be listed in Frameworks.
+	// Kueue needs RBAC permissions and webhook configurations for these
+	// resources, which aren't part of its installation manifests.
+	GenericFrameworks []GenericFramework `json:"genericFrameworks,omitempty"`
+}
+
+// GenericFramework describes how Kueue reads and updates a custom resource
+// through JSONPaths into its content. The paths use the dot notation with
+// optional list indexes, for example ".spec.replicaSpecs[0].template".
+type GenericFramework struct {
+	// Group of the custom resource.
+	Group string `json:"group"`
+	// Version of the custom resource.
+	Version string `json:"version"`
+	// Kind of the custom resource.
+	Kind string `json:"kind"`
+
+	// SuspendPath is the path of the boolean field that suspends the job,
+	// for example ".spec.suspend".
+	SuspendPath string `json:"suspendPath"`
+
+	// PodSets lists the pod templates of the job, at most 8.
+	PodSets []GenericPodSet `json:"podSets"`
+
+	// ConditionsPath is the path of the list of conditions in the status of
+	// the job. The conditions need the type and status fields of
+	// metav1.Condition.
+	// Defaults to ".status.conditions".
+	ConditionsPath *string `json:"conditionsPath,omitempty"`
+
+	// FinishedConditionTypes lists the condition types that, when their
+	// status is "True", indicate that the job has finished, for example
+	// ["Complete", "Failed"].
+	FinishedConditionTypes []string `json:"finishedConditionTypes"`
+
+	// PodsReadyConditionType is the condition type that, when its status is
+	// "True", indicates that the pods of the job are ready.
+	// When not set, the pods are considered ready as soon as the job runs.
+	PodsReadyConditionType *string `json:"podsReadyConditionType,omitempty"`
+
+	// ActivePath is the path of an integer field with the number of active
+	// pods of the job, for example ".status.active". Kueue waits for it to
+	// drop to 0 before it releases the quota of a suspended job.
+	// When not set, the job is considered active while it isn't suspended.
+	ActivePath *string `json:"activePath,omitempty"`
+}
+
+type GenericPodSet struct {
+	// Name of the podSet in the Workload, it must be a DNS label.
+	Name string `json:"name"`
+	// TemplatePath is the path of the PodTemplateSpec, for example
+	// ".spec.template".
+	TemplatePath string `json:"templatePath"`
+	// ReplicasPath is the path of the integer field with the number of pods
+	// created from the template, for example ".spec.parallelism".
+	// When not set, or when the field is missing, the podSet has 1 pod.
+	ReplicasPath *string `json:"replicasPath,omitempty"`
 }
 
 type PodIntegrationOptions struct {
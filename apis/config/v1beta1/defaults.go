@@ -41,6 +41,10 @@ const (
 	defaultPodsReadyTimeout                             = 5 * time.Minute
 	DefaultQueueVisibilityUpdateIntervalSeconds int32   = 5
 	DefaultClusterQueuesMaxCount                int32   = 10
+	DefaultTracingEndpoint                              = "localhost:4318"
+	DefaultTracingSamplingRatePerMillion        int32   = 1000000
+	DefaultGenericFrameworkConditionsPath               = ".status.conditions"
+	DefaultMaxHeadsPerClusterQueue              int32   = 10
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -112,12 +116,41 @@ func SetDefaults_Configuration(cfg *Configuration) {
 			cfg.WaitForPodsReady.BlockAdmission = &defaultBlockAdmission
 		}
 	}
+	if cfg.Tracing != nil {
+		if cfg.Tracing.Exporter == "" {
+			cfg.Tracing.Exporter = TracingExporterOTLP
+		}
+		if cfg.Tracing.Endpoint == nil {
+			cfg.Tracing.Endpoint = ptr.To(DefaultTracingEndpoint)
+		}
+		if cfg.Tracing.SamplingRatePerMillion == nil {
+			cfg.Tracing.SamplingRatePerMillion = ptr.To(DefaultTracingSamplingRatePerMillion)
+		}
+	}
+	if cfg.CapacityCheck != nil && cfg.CapacityCheck.Action == "" {
+		cfg.CapacityCheck.Action = CapacityCheckWarn
+	}
+	if cfg.BatchAdmission != nil && cfg.BatchAdmission.MaxHeadsPerClusterQueue == nil {
+		cfg.BatchAdmission.MaxHeadsPerClusterQueue = ptr.To(DefaultMaxHeadsPerClusterQueue)
+	}
+	if cfg.Resources != nil {
+		for i := range cfg.Resources.Transformations {
+			if cfg.Resources.Transformations[i].Strategy == nil {
+				cfg.Resources.Transformations[i].Strategy = ptr.To(RetainResourceTransformation)
+			}
+		}
+	}
 	if cfg.Integrations == nil {
 		cfg.Integrations = &Integrations{}
 	}
 	if cfg.Integrations.Frameworks == nil {
 		cfg.Integrations.Frameworks = []string{job.FrameworkName}
 	}
+	for i := range cfg.Integrations.GenericFrameworks {
+		if cfg.Integrations.GenericFrameworks[i].ConditionsPath == nil {
+			cfg.Integrations.GenericFrameworks[i].ConditionsPath = ptr.To(DefaultGenericFrameworkConditionsPath)
+		}
+	}
 	if cfg.QueueVisibility == nil {
 		cfg.QueueVisibility = &QueueVisibility{}
 	}
@@ -337,6 +337,134 @@ func TestSetDefaults_Configuration(t *testing.T) {
 				QueueVisibility:  defaultQueueVisibility,
 			},
 		},
+		"defaulting tracing": {
+			original: &Configuration{
+				Tracing: &Tracing{
+					Insecure: true,
+				},
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+			},
+			want: &Configuration{
+				Tracing: &Tracing{
+					Exporter:               TracingExporterOTLP,
+					Endpoint:               ptr.To(DefaultTracingEndpoint),
+					Insecure:               true,
+					SamplingRatePerMillion: ptr.To(DefaultTracingSamplingRatePerMillion),
+				},
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+			},
+		},
+		"defaulting capacityCheck": {
+			original: &Configuration{
+				CapacityCheck: &CapacityCheck{},
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+			},
+			want: &Configuration{
+				CapacityCheck: &CapacityCheck{
+					Action: CapacityCheckWarn,
+				},
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+			},
+		},
+		"defaulting batchAdmission": {
+			original: &Configuration{
+				BatchAdmission: &BatchAdmission{},
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+			},
+			want: &Configuration{
+				BatchAdmission: &BatchAdmission{
+					MaxHeadsPerClusterQueue: ptr.To(DefaultMaxHeadsPerClusterQueue),
+				},
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+			},
+		},
+		"defaulting resource transformations strategy": {
+			original: &Configuration{
+				Resources: &Resources{
+					Transformations: []ResourceTransformation{
+						{Input: "example.com/gpu"},
+						{Input: "example.com/tpu", Strategy: ptr.To(ReplaceResourceTransformation)},
+					},
+				},
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+			},
+			want: &Configuration{
+				Resources: &Resources{
+					Transformations: []ResourceTransformation{
+						{Input: "example.com/gpu", Strategy: ptr.To(RetainResourceTransformation)},
+						{Input: "example.com/tpu", Strategy: ptr.To(ReplaceResourceTransformation)},
+					},
+				},
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations:     defaultIntegrations,
+				QueueVisibility:  defaultQueueVisibility,
+			},
+		},
+		"defaulting genericFrameworks conditionsPath": {
+			original: &Configuration{
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				Integrations: &Integrations{
+					Frameworks: []string{job.FrameworkName},
+					GenericFrameworks: []GenericFramework{
+						{Group: "example.com", Version: "v1", Kind: "Trainer"},
+						{Group: "example.com", Version: "v1", Kind: "Server", ConditionsPath: ptr.To(".status.state.conditions")},
+					},
+				},
+			},
+			want: &Configuration{
+				Namespace:         ptr.To(DefaultNamespace),
+				ControllerManager: defaultCtrlManagerConfigurationSpec,
+				InternalCertManagement: &InternalCertManagement{
+					Enable: ptr.To(false),
+				},
+				ClientConnection: defaultClientConnection,
+				Integrations: &Integrations{
+					Frameworks: []string{job.FrameworkName},
+					PodOptions: defaultIntegrations.PodOptions,
+					GenericFrameworks: []GenericFramework{
+						{Group: "example.com", Version: "v1", Kind: "Trainer", ConditionsPath: ptr.To(DefaultGenericFrameworkConditionsPath)},
+						{Group: "example.com", Version: "v1", Kind: "Server", ConditionsPath: ptr.To(".status.state.conditions")},
+					},
+				},
+				QueueVisibility: defaultQueueVisibility,
+			},
+		},
 		"set waitForPodsReady.blockAdmission to false when enable is false": {
 			original: &Configuration{
 				WaitForPodsReady: &WaitForPodsReady{
@@ -22,12 +22,48 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/component-base/config/v1alpha1"
 	timex "time"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BatchAdmission) DeepCopyInto(out *BatchAdmission) {
+	*out = *in
+	if in.MaxHeadsPerClusterQueue != nil {
+		in, out := &in.MaxHeadsPerClusterQueue, &out.MaxHeadsPerClusterQueue
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BatchAdmission.
+func (in *BatchAdmission) DeepCopy() *BatchAdmission {
+	if in == nil {
+		return nil
+	}
+	out := new(BatchAdmission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityCheck) DeepCopyInto(out *CapacityCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityCheck.
+func (in *CapacityCheck) DeepCopy() *CapacityCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientConnection) DeepCopyInto(out *ClientConnection) {
 	*out = *in
@@ -103,6 +139,31 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(QueueVisibility)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ResourceFlavorDiscovery != nil {
+		in, out := &in.ResourceFlavorDiscovery, &out.ResourceFlavorDiscovery
+		*out = new(ResourceFlavorDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(Tracing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CapacityCheck != nil {
+		in, out := &in.CapacityCheck, &out.CapacityCheck
+		*out = new(CapacityCheck)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(Resources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BatchAdmission != nil {
+		in, out := &in.BatchAdmission, &out.BatchAdmission
+		*out = new(BatchAdmission)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configuration.
@@ -228,6 +289,88 @@ func (in *ControllerWebhook) DeepCopy() *ControllerWebhook {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeviceClassMapping) DeepCopyInto(out *DeviceClassMapping) {
+	*out = *in
+	if in.DeviceClassNames != nil {
+		in, out := &in.DeviceClassNames, &out.DeviceClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeviceClassMapping.
+func (in *DeviceClassMapping) DeepCopy() *DeviceClassMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(DeviceClassMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericFramework) DeepCopyInto(out *GenericFramework) {
+	*out = *in
+	if in.PodSets != nil {
+		in, out := &in.PodSets, &out.PodSets
+		*out = make([]GenericPodSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ConditionsPath != nil {
+		in, out := &in.ConditionsPath, &out.ConditionsPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.FinishedConditionTypes != nil {
+		in, out := &in.FinishedConditionTypes, &out.FinishedConditionTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodsReadyConditionType != nil {
+		in, out := &in.PodsReadyConditionType, &out.PodsReadyConditionType
+		*out = new(string)
+		**out = **in
+	}
+	if in.ActivePath != nil {
+		in, out := &in.ActivePath, &out.ActivePath
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericFramework.
+func (in *GenericFramework) DeepCopy() *GenericFramework {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericFramework)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenericPodSet) DeepCopyInto(out *GenericPodSet) {
+	*out = *in
+	if in.ReplicasPath != nil {
+		in, out := &in.ReplicasPath, &out.ReplicasPath
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenericPodSet.
+func (in *GenericPodSet) DeepCopy() *GenericPodSet {
+	if in == nil {
+		return nil
+	}
+	out := new(GenericPodSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Integrations) DeepCopyInto(out *Integrations) {
 	*out = *in
@@ -241,6 +384,18 @@ func (in *Integrations) DeepCopyInto(out *Integrations) {
 		*out = new(PodIntegrationOptions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExternalFrameworks != nil {
+		in, out := &in.ExternalFrameworks, &out.ExternalFrameworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GenericFrameworks != nil {
+		in, out := &in.GenericFrameworks, &out.GenericFrameworks
+		*out = make([]GenericFramework, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Integrations.
@@ -328,6 +483,112 @@ func (in *QueueVisibility) DeepCopy() *QueueVisibility {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceFlavorDiscovery) DeepCopyInto(out *ResourceFlavorDiscovery) {
+	*out = *in
+	if in.NodeLabelKeys != nil {
+		in, out := &in.NodeLabelKeys, &out.NodeLabelKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceFlavorDiscovery.
+func (in *ResourceFlavorDiscovery) DeepCopy() *ResourceFlavorDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceFlavorDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceTransformation) DeepCopyInto(out *ResourceTransformation) {
+	*out = *in
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(ResourceTransformationStrategy)
+		**out = **in
+	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceTransformation.
+func (in *ResourceTransformation) DeepCopy() *ResourceTransformation {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceTransformation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Resources) DeepCopyInto(out *Resources) {
+	*out = *in
+	if in.ExcludeResourcePrefixes != nil {
+		in, out := &in.ExcludeResourcePrefixes, &out.ExcludeResourcePrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transformations != nil {
+		in, out := &in.Transformations, &out.Transformations
+		*out = make([]ResourceTransformation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeviceClassMappings != nil {
+		in, out := &in.DeviceClassMappings, &out.DeviceClassMappings
+		*out = make([]DeviceClassMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Resources.
+func (in *Resources) DeepCopy() *Resources {
+	if in == nil {
+		return nil
+	}
+	out := new(Resources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tracing) DeepCopyInto(out *Tracing) {
+	*out = *in
+	if in.Endpoint != nil {
+		in, out := &in.Endpoint, &out.Endpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.SamplingRatePerMillion != nil {
+		in, out := &in.SamplingRatePerMillion, &out.SamplingRatePerMillion
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tracing.
+func (in *Tracing) DeepCopy() *Tracing {
+	if in == nil {
+		return nil
+	}
+	out := new(Tracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WaitForPodsReady) DeepCopyInto(out *WaitForPodsReady) {
 	*out = *in
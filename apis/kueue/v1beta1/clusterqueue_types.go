@@ -93,9 +93,107 @@ type ClusterQueueSpec struct {
 	// lower priority first.
 	Preemption *ClusterQueuePreemption `json:"preemption,omitempty"`
 
-	// admissionChecks lists the AdmissionChecks required by this ClusterQueue
+	// admissionChecks lists the AdmissionChecks required by this ClusterQueue.
+	// Cannot be used along with admissionChecksStrategy.
 	// +optional
 	AdmissionChecks []string `json:"admissionChecks,omitempty"`
+
+	// admissionChecksStrategy lists the AdmissionChecks required by this
+	// ClusterQueue, along with the ResourceFlavors that each of them applies to.
+	// Cannot be used along with admissionChecks.
+	// +optional
+	AdmissionChecksStrategy *AdmissionChecksStrategy `json:"admissionChecksStrategy,omitempty"`
+
+	// waitForPodsReady overrides the waitForPodsReady configuration of Kueue
+	// for the workloads admitted by this ClusterQueue.
+	// When set, the admissions in this ClusterQueue are only blocked by its
+	// own workloads that aren't in the PodsReady condition, and the workloads
+	// of this ClusterQueue don't block the admissions in other ClusterQueues.
+	// When not set, the ClusterQueue follows the Kueue configuration, and the
+	// admissions are blocked by the workloads of all the ClusterQueues that
+	// follow it.
+	// +optional
+	WaitForPodsReady *ClusterQueueWaitForPodsReady `json:"waitForPodsReady,omitempty"`
+
+	// quotaShrinkPolicy defines what happens to the admitted workloads when a
+	// quota schedule, or a change in the spec, reduces the quota of the
+	// ClusterQueue. Usage over the quota that wasn't reduced doesn't lead to
+	// evictions. The possible values are:
+	//
+	// - `Keep` (default): the admitted workloads keep running. No new workloads
+	//   are admitted until the usage fits within the reduced quota.
+	// - `EvictOverQuota`: the admitted workloads are evicted, lower priority
+	//   and more recently admitted first, until the usage fits within the
+	//   reduced quota.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=Keep;EvictOverQuota
+	QuotaShrinkPolicy QuotaShrinkPolicy `json:"quotaShrinkPolicy,omitempty"`
+}
+
+type QuotaShrinkPolicy string
+
+const (
+	// QuotaShrinkKeep means that the admitted workloads keep running when the
+	// quota of the ClusterQueue is reduced.
+	QuotaShrinkKeep QuotaShrinkPolicy = "Keep"
+
+	// QuotaShrinkEvictOverQuota means that the admitted workloads are evicted
+	// until the usage fits within the reduced quota of the ClusterQueue.
+	QuotaShrinkEvictOverQuota QuotaShrinkPolicy = "EvictOverQuota"
+)
+
+// ClusterQueueWaitForPodsReady defines how the ClusterQueue waits for the pods
+// of its admitted workloads to be ready.
+type ClusterQueueWaitForPodsReady struct {
+	// enable indicates whether the PodsReady condition is tracked for the
+	// workloads admitted by the ClusterQueue. When false, the workloads start
+	// as soon as they are admitted, without timeout.
+	Enable bool `json:"enable"`
+
+	// timeout is the time for an admitted workload to reach the PodsReady=True
+	// condition. When the timeout is reached, the workload is evicted and
+	// requeued in the same ClusterQueue.
+	// Defaults to 5 minutes.
+	// +optional
+	// +kubebuilder:default="5m"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// blockAdmission indicates whether the ClusterQueue blocks the admission
+	// of new workloads while any of its admitted workloads isn't in the
+	// PodsReady=True condition.
+	// Defaults to true.
+	// +optional
+	// +kubebuilder:default=true
+	BlockAdmission *bool `json:"blockAdmission,omitempty"`
+}
+
+// AdmissionChecksStrategy defines which AdmissionChecks apply to the workloads,
+// depending on the ResourceFlavors assigned to them.
+type AdmissionChecksStrategy struct {
+	// admissionChecks is the list of AdmissionChecks and the ResourceFlavors
+	// that they apply to.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	AdmissionChecks []AdmissionCheckStrategyRule `json:"admissionChecks,omitempty"`
+}
+
+// AdmissionCheckStrategyRule defines the ResourceFlavors that an AdmissionCheck
+// applies to.
+type AdmissionCheckStrategyRule struct {
+	// name is the name of the AdmissionCheck.
+	Name string `json:"name"`
+
+	// onFlavors is the list of ResourceFlavors that the AdmissionCheck applies
+	// to. The AdmissionCheck is required by a workload when any of its podSets
+	// is assigned one of these flavors.
+	// If empty, the AdmissionCheck applies to all the workloads of the
+	// ClusterQueue.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=16
+	OnFlavors []ResourceFlavorReference `json:"onFlavors,omitempty"`
 }
 
 type QueueingStrategy string
@@ -148,6 +246,76 @@ type FlavorQuotas struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=16
 	Resources []ResourceQuota `json:"resources"`
+
+	// schedules are alternative quotas for this flavor that apply during
+	// recurring time windows. When the windows of several schedules overlap,
+	// the first one in the list applies.
+	// There could be up to 8 schedules.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	Schedules []QuotaSchedule `json:"schedules,omitempty"`
+}
+
+// QuotaSchedule defines the quotas of a flavor during a recurring time window.
+type QuotaSchedule struct {
+	// name of the schedule. It is reported in the status of the ClusterQueue
+	// while the window of the schedule is active.
+	Name string `json:"name"`
+
+	// daysOfWeek are the days on which the window starts.
+	// If empty, the window starts every day.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=7
+	DaysOfWeek []DayOfWeek `json:"daysOfWeek,omitempty"`
+
+	// startTime is the time of the day at which the window starts, in the
+	// 24-hour HH:MM format.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime"`
+
+	// endTime is the time of the day at which the window ends, in the 24-hour
+	// HH:MM format. If it isn't after the startTime, the window ends on the
+	// next day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	EndTime string `json:"endTime"`
+
+	// timeZone is the name of the time zone of the startTime and endTime, as
+	// in the IANA Time Zone database, for example, "Europe/Madrid".
+	// Defaults to UTC.
+	// +optional
+	TimeZone *string `json:"timeZone,omitempty"`
+
+	// resources is the list of quotas that replace the ones of the flavor
+	// while the window is active. The resources that aren't listed keep their
+	// quotas.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Resources []ScheduledResourceQuota `json:"resources"`
+}
+
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type DayOfWeek string
+
+type ScheduledResourceQuota struct {
+	// name of this resource. It must be one of the resources of the flavor.
+	Name corev1.ResourceName `json:"name"`
+
+	// nominalQuota is the quantity of this resource that is available for
+	// Workloads admitted by this ClusterQueue while the window is active.
+	// It replaces the nominalQuota and nominalQuotaPercentage of the flavor.
+	NominalQuota resource.Quantity `json:"nominalQuota"`
+
+	// borrowingLimit is the maximum amount of quota for the [flavor, resource]
+	// combination that this ClusterQueue is allowed to borrow while the window
+	// is active.
+	// If null, the borrowingLimit of the flavor applies.
+	// +optional
+	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
 }
 
 type ResourceQuota struct {
@@ -168,6 +336,17 @@ type ResourceQuota struct {
 	// allocated by a ClusterQueue in the cohort.
 	NominalQuota resource.Quantity `json:"nominalQuota"`
 
+	// nominalQuotaPercentage, if set, makes the nominal quota of this resource
+	// a percentage of the capacity reported in the status of the flavor, which
+	// is kept up to date when the resourceFlavorDiscovery is enabled in the
+	// Kueue configuration. The nominalQuota is then ignored.
+	// If the flavor doesn't report any capacity for the resource, the nominal
+	// quota is zero.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	NominalQuotaPercentage *int32 `json:"nominalQuotaPercentage,omitempty"`
+
 	// borrowingLimit is the maximum amount of quota for the [flavor, resource]
 	// combination that this ClusterQueue is allowed to borrow from the unused
 	// quota of other ClusterQueues in the same cohort.
@@ -230,6 +409,26 @@ type ClusterQueueStatus struct {
 	// status of the pending workloads in the cluster queue.
 	// +optional
 	PendingWorkloadsStatus *ClusterQueuePendingWorkloadsStatus `json:"pendingWorkloadsStatus"`
+
+	// activeQuotaSchedules are the quota schedules whose window is currently
+	// active, by flavor.
+	// +optional
+	// +listType=map
+	// +listMapKey=flavor
+	// +kubebuilder:validation:MaxItems=256
+	ActiveQuotaSchedules []ActiveQuotaSchedule `json:"activeQuotaSchedules,omitempty"`
+}
+
+// ActiveQuotaSchedule identifies the quota schedule that applies to a flavor.
+type ActiveQuotaSchedule struct {
+	// flavor is the name of the flavor.
+	Flavor ResourceFlavorReference `json:"flavor"`
+
+	// name is the name of the schedule.
+	Name string `json:"name"`
+
+	// endTime is the time at which the window of the schedule ends.
+	EndTime metav1.Time `json:"endTime"`
 }
 
 type ClusterQueuePendingWorkloadsStatus struct {
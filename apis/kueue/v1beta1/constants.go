@@ -16,8 +16,16 @@ limitations under the License.
 
 package v1beta1
 
+import corev1 "k8s.io/api/core/v1"
+
 const (
 	ResourceInUseFinalizerName = "kueue.x-k8s.io/resource-in-use"
 
 	DefaultPodSetName = "main"
+
+	// ResourceWorkloads is the resource to limit the number of workloads
+	// admitted in a ClusterQueue. Every workload requests one unit of it in
+	// its first PodSet, like it requests the number of its pods in the
+	// "pods" resource.
+	ResourceWorkloads corev1.ResourceName = "kueue.x-k8s.io/workloads"
 )
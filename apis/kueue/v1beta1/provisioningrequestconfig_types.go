@@ -51,6 +51,34 @@ type ProvisioningRequestConfigSpec struct {
 	// +listType=set
 	// +kubebuilder:validation:MaxItems=100
 	ManagedResources []corev1.ResourceName `json:"managedResources,omitempty"`
+
+	// retryStrategy defines how the ProvisioningRequest is recreated when its
+	// booking expires before the workload is admitted.
+	//
+	// +optional
+	RetryStrategy *ProvisioningRequestRetryStrategy `json:"retryStrategy,omitempty"`
+}
+
+// ProvisioningRequestRetryStrategy defines the backoff of the retries of a
+// ProvisioningRequest.
+type ProvisioningRequestRetryStrategy struct {
+	// backoffLimitCount is the number of times that the ProvisioningRequest
+	// is recreated after its booking expired, before the check is rejected.
+	// Defaults to 3.
+	//
+	// +optional
+	// +kubebuilder:default=3
+	// +kubebuilder:validation:Minimum=0
+	BackoffLimitCount *int32 `json:"backoffLimitCount,omitempty"`
+
+	// backoffBaseSeconds is the time to wait before the first retry. It
+	// doubles with every retry.
+	// Defaults to 60.
+	//
+	// +optional
+	// +kubebuilder:default=60
+	// +kubebuilder:validation:Minimum=1
+	BackoffBaseSeconds *int32 `json:"backoffBaseSeconds,omitempty"`
 }
 
 // Parameter is limited to 255 characters.
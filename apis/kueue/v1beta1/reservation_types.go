@@ -0,0 +1,145 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReservationSpec defines the desired state of Reservation
+type ReservationSpec struct {
+	// clusterQueue is the name of the ClusterQueue whose quota is reserved.
+	// The reserved quota can only be used by the workloads admitted by this
+	// ClusterQueue that have the kueue.x-k8s.io/reservation label set to the
+	// name of the Reservation.
+	ClusterQueue ClusterQueueReference `json:"clusterQueue"`
+
+	// flavors is the list of resources reserved for each flavor.
+	// The flavors and resources must be part of the quota of the ClusterQueue.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Flavors []ReservedFlavor `json:"flavors"`
+
+	// startTime is the time at which the reserved quota becomes available to
+	// the workloads of the Reservation.
+	// At this time, the workloads of other ClusterQueues in the cohort that are
+	// borrowing the reserved quota are preempted.
+	StartTime metav1.Time `json:"startTime"`
+
+	// duration is how long the reserved quota is available to the workloads of
+	// the Reservation, counting from the startTime.
+	// Once it elapses, the quota is released, and the workloads of the
+	// Reservation that are running keep using the regular quota of the
+	// ClusterQueue.
+	Duration metav1.Duration `json:"duration"`
+
+	// holdPeriod is how long before the startTime the reserved quota is held,
+	// that is, it can no longer be borrowed by other ClusterQueues in the cohort
+	// or used by other workloads in the ClusterQueue.
+	// The workloads that were admitted using the quota before the hold period
+	// keep running until the startTime.
+	// Defaults to 1h.
+	// +optional
+	// +kubebuilder:default="1h"
+	HoldPeriod *metav1.Duration `json:"holdPeriod,omitempty"`
+}
+
+type ReservedFlavor struct {
+	// name of the flavor.
+	Name ResourceFlavorReference `json:"name"`
+
+	// resources is the quantity reserved for each resource.
+	Resources corev1.ResourceList `json:"resources"`
+}
+
+// ReservationPhase is the phase of a Reservation in its time window.
+type ReservationPhase string
+
+const (
+	// ReservationPending means that the hold period of the Reservation hasn't
+	// started yet.
+	ReservationPending ReservationPhase = "Pending"
+
+	// ReservationHolding means that the reserved quota is held, but the
+	// Reservation hasn't started yet.
+	ReservationHolding ReservationPhase = "Holding"
+
+	// ReservationActive means that the reserved quota is available to the
+	// workloads of the Reservation.
+	ReservationActive ReservationPhase = "Active"
+
+	// ReservationExpired means that the time window of the Reservation is over
+	// and the quota is no longer reserved.
+	ReservationExpired ReservationPhase = "Expired"
+)
+
+// ReservationStatus defines the observed state of Reservation
+type ReservationStatus struct {
+	// phase is the phase of the Reservation in its time window.
+	// +optional
+	// +kubebuilder:validation:Enum=Pending;Holding;Active;Expired
+	Phase ReservationPhase `json:"phase,omitempty"`
+
+	// flavorsUsage are the reserved resources in use by the workloads of the
+	// Reservation that hold quota in the ClusterQueue.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	FlavorsUsage []ReservedFlavor `json:"flavorsUsage,omitempty"`
+
+	// reservingWorkloads is the number of workloads of the Reservation that
+	// hold quota in the ClusterQueue.
+	// +optional
+	ReservingWorkloads int32 `json:"reservingWorkloads,omitempty"`
+}
+
+//+genclient
+//+genclient:nonNamespaced
+//+kubebuilder:object:root=true
+//+kubebuilder:storageversion
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="ClusterQueue",JSONPath=".spec.clusterQueue",type=string,description="ClusterQueue whose quota is reserved"
+//+kubebuilder:printcolumn:name="Start",JSONPath=".spec.startTime",type=date,description="Time at which the reservation starts"
+//+kubebuilder:printcolumn:name="Phase",JSONPath=".status.phase",type=string,description="Phase of the reservation"
+//+kubebuilder:printcolumn:name="Reserving Workloads",JSONPath=".status.reservingWorkloads",type=integer,description="Number of workloads of the reservation that hold quota"
+
+// Reservation is the Schema for the reservations API
+type Reservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReservationSpec   `json:"spec,omitempty"`
+	Status ReservationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ReservationList contains a list of Reservation
+type ReservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Reservation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Reservation{}, &ReservationList{})
+}
@@ -26,13 +26,15 @@ import (
 //+kubebuilder:object:root=true
 //+kubebuilder:storageversion
 //+kubebuilder:resource:scope=Cluster,shortName={flavor,flavors}
+//+kubebuilder:subresource:status
 
 // ResourceFlavor is the Schema for the resourceflavors API.
 type ResourceFlavor struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ResourceFlavorSpec `json:"spec,omitempty"`
+	Spec   ResourceFlavorSpec   `json:"spec,omitempty"`
+	Status ResourceFlavorStatus `json:"status,omitempty"`
 }
 
 // ResourceFlavorSpec defines the desired state of the ResourceFlavor
@@ -79,6 +81,80 @@ type ResourceFlavorSpec struct {
 	// +listType=atomic
 	// +kubebuilder:validation:MaxItems=8
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// podTemplateOverrides are changes that will be applied to the pod
+	// templates of the podSets admitted in the quota associated with this
+	// resource flavor, in addition to the nodeLabels and tolerations.
+	// The changes are reverted if the workload is evicted.
+	//
+	// +optional
+	PodTemplateOverrides *PodTemplateOverrides `json:"podTemplateOverrides,omitempty"`
+}
+
+// ResourceFlavorStatus defines the observed state of the ResourceFlavor
+type ResourceFlavorStatus struct {
+	// nodeCount is the number of schedulable Nodes that have the nodeLabels
+	// of the ResourceFlavor.
+	// +optional
+	NodeCount int32 `json:"nodeCount,omitempty"`
+
+	// capacity is the aggregate allocatable capacity of the schedulable Nodes
+	// that have the nodeLabels of the ResourceFlavor.
+	// It is only reported when the resourceFlavorDiscovery is enabled in the
+	// Kueue configuration.
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+}
+
+// PodTemplateOverrides is the restricted set of pod template fields that a
+// ResourceFlavor can inject into the pods that use it.
+// Admission fails if a value conflicts with the one in the pod template.
+type PodTemplateOverrides struct {
+	// annotations are added to the pod template.
+	//
+	// annotations can be up to 8 elements.
+	// +optional
+	// +mapType=atomic
+	// +kubebuilder:validation:MaxProperties=8
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// env are environment variables added to all the containers of the pod
+	// template, for example KUEUE_FLAVOR.
+	// Variables already defined in a container with the same value are kept.
+	//
+	// env can be up to 16 elements.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// runtimeClassName is the RuntimeClass used to run the pods, for example
+	// one providing GPU support.
+	// +optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// schedulerName is the scheduler that dispatches the pods. It can only be
+	// injected in pod templates that use the default scheduler.
+	// +optional
+	SchedulerName string `json:"schedulerName,omitempty"`
+
+	// podAffinity terms are added to the pod affinity of the pod template.
+	// +optional
+	PodAffinity *corev1.PodAffinity `json:"podAffinity,omitempty"`
+
+	// podAntiAffinity terms are added to the pod anti-affinity of the pod
+	// template.
+	// +optional
+	PodAntiAffinity *corev1.PodAntiAffinity `json:"podAntiAffinity,omitempty"`
+
+	// topologySpreadConstraints are added to the pod template.
+	//
+	// topologySpreadConstraints can be up to 4 elements.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=4
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -19,6 +19,7 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // WorkloadSpec defines the desired state of Workload
@@ -59,6 +60,59 @@ type WorkloadSpec struct {
 	// +kubebuilder:default=""
 	// +kubebuilder:validation:Enum=kueue.x-k8s.io/workloadpriorityclass;scheduling.k8s.io/priorityclass;""
 	PriorityClassSource string `json:"priorityClassSource,omitempty"`
+
+	// preemptionPolicy is the policy for preempting workloads with lower
+	// priority to admit this workload. One of Never, PreemptLowerPriority.
+	// The value is populated from the WorkloadPriorityClass.
+	// Defaults to PreemptLowerPriority if unset.
+	// +optional
+	// +kubebuilder:validation:Enum=Never;PreemptLowerPriority
+	PreemptionPolicy *corev1.PreemptionPolicy `json:"preemptionPolicy,omitempty"`
+
+	// protected indicates that the workload can't be preempted by workloads
+	// with lower priority, even when its ClusterQueue is borrowing.
+	// The value is populated from the WorkloadPriorityClass.
+	// +optional
+	Protected bool `json:"protected,omitempty"`
+
+	// podSetFlavorGroups lists groups of PodSets that must be assigned
+	// consistent flavors, for example, to keep the launcher and the workers of
+	// an MPI job in the same zone.
+	// Each PodSet can only be part of one group.
+	// There can be up to 4 groups.
+	// podSetFlavorGroups cannot be changed while .status.admission is not null.
+	//
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=4
+	PodSetFlavorGroups []PodSetFlavorGroup `json:"podSetFlavorGroups,omitempty"`
+}
+
+// PodSetFlavorGroup is a set of PodSets that must be assigned consistent
+// flavors during admission.
+type PodSetFlavorGroup struct {
+	// podSets is the list of names of the PodSets in the group.
+	// Each name should match one of the names in .spec.podSets.
+	// The list must contain between 2 and 8 names.
+	//
+	// +listType=set
+	// +kubebuilder:validation:MinItems=2
+	// +kubebuilder:validation:MaxItems=8
+	PodSets []string `json:"podSets"`
+
+	// nodeLabel is a key of the nodeLabels of the ResourceFlavors, such as
+	// topology.kubernetes.io/zone.
+	//
+	// If empty, the PodSets in the group must be assigned the same flavor in
+	// every resource group that more than one of them requests resources from.
+	//
+	// If set, the PodSets in the group can be assigned different flavors, as
+	// long as all the assigned flavors have the same value for this node label.
+	// Flavors that don't have this node label can't be assigned to the PodSets
+	// in the group.
+	//
+	// +optional
+	NodeLabel string `json:"nodeLabel,omitempty"`
 }
 
 type Admission struct {
@@ -171,6 +225,78 @@ type WorkloadStatus struct {
 	// +patchStrategy=merge
 	// +patchMergeKey=name
 	AdmissionChecks []AdmissionCheckState `json:"admissionChecks,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// admissionHistory records the latest quota reservations of the workload,
+	// from the oldest to the most recent, including how each of them ended.
+	// Only the latest 10 are kept.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=10
+	AdmissionHistory []AdmissionRecord `json:"admissionHistory,omitempty"`
+}
+
+type AdmissionRecord struct {
+	// clusterQueue is the name of the ClusterQueue that reserved quota for
+	// the workload.
+	ClusterQueue ClusterQueueReference `json:"clusterQueue"`
+
+	// podSetFlavors are the flavors assigned to each podSet.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	PodSetFlavors []PodSetFlavors `json:"podSetFlavors,omitempty"`
+
+	// quotaReservationTime is the time when the quota was reserved.
+	QuotaReservationTime metav1.Time `json:"quotaReservationTime"`
+
+	// admissionTime is the time when the workload was admitted, that is, when
+	// all its admission checks were ready.
+	// It's not set if the workload was evicted before being admitted.
+	// +optional
+	AdmissionTime *metav1.Time `json:"admissionTime,omitempty"`
+
+	// evictionTime is the time when the workload was evicted, or when its
+	// quota reservation was released for another reason.
+	// It's not set while the quota is still reserved.
+	// +optional
+	EvictionTime *metav1.Time `json:"evictionTime,omitempty"`
+
+	// evictionReason is the reason of the Evicted condition set when the
+	// workload was evicted, for example Preempted or PodsReadyTimeout.
+	// Otherwise, it's the reason why the quota reservation was released, for
+	// example Inadmissible or AdmissionChecksRejected.
+	// +optional
+	EvictionReason string `json:"evictionReason,omitempty"`
+
+	// preemptor identifies the workload whose admission required the eviction
+	// of this one. Only set when evictionReason is Preempted.
+	// +optional
+	Preemptor *PreemptorReference `json:"preemptor,omitempty"`
+}
+
+type PodSetFlavors struct {
+	// name is the name of the podSet.
+	Name string `json:"name"`
+
+	// flavors are the flavors assigned to the podSet for each resource.
+	Flavors map[corev1.ResourceName]ResourceFlavorReference `json:"flavors,omitempty"`
+}
+
+type PreemptorReference struct {
+	// name is the name of the preempting workload.
+	Name string `json:"name"`
+
+	// namespace is the namespace of the preempting workload.
+	Namespace string `json:"namespace"`
+
+	// uid is the UID of the preempting workload.
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+
+	// clusterQueue is the name of the ClusterQueue in which the preempting
+	// workload was being admitted.
+	ClusterQueue ClusterQueueReference `json:"clusterQueue"`
 }
 
 type AdmissionCheckState struct {
@@ -253,6 +379,12 @@ const (
 
 	// WorkloadEvicted means that the Workload was evicted by a ClusterQueue
 	WorkloadEvicted = "Evicted"
+
+	// WorkloadInadmissible means that the Workload can never fit in its
+	// ClusterQueue, not even borrowing all the quota of the cohort.
+	// It's only set when the capacity check of the manager is configured to
+	// mark the workloads.
+	WorkloadInadmissible = "Inadmissible"
 )
 
 const (
@@ -267,6 +399,10 @@ const (
 	// WorkloadEvictedByAdmissionCheck indicates that the workload was evicted
 	// beacuse at least one admission check transitioned to False.
 	WorkloadEvictedByAdmissionCheck = "AdmissionCheck"
+
+	// WorkloadEvictedByQuotaShrink indicates that the workload was evicted
+	// because a quota schedule reduced the quota of its ClusterQueue.
+	WorkloadEvictedByQuotaShrink = "QuotaShrink"
 )
 
 // +genclient
@@ -276,6 +412,8 @@ const (
 // +kubebuilder:printcolumn:name="Queue",JSONPath=".spec.queueName",type=string,description="Name of the queue this workload was submitted to"
 // +kubebuilder:printcolumn:name="Admitted by",JSONPath=".status.admission.clusterQueue",type=string,description="Name of the ClusterQueue that admitted this workload"
 // +kubebuilder:printcolumn:name="Age",JSONPath=".metadata.creationTimestamp",type=date,description="Time this workload was created"
+// +kubebuilder:printcolumn:name="Admission history",JSONPath=".status.admissionHistory[*].clusterQueue",type=string,priority=1,description="ClusterQueues that reserved quota for this workload, from the oldest"
+// +kubebuilder:printcolumn:name="Evictions",JSONPath=".status.admissionHistory[*].evictionReason",type=string,priority=1,description="Reasons of the evictions of this workload, from the oldest"
 // +kubebuilder:resource:shortName={wl}
 
 // Workload is the Schema for the workloads API
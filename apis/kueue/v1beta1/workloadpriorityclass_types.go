@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -34,13 +35,32 @@ type WorkloadPriorityClass struct {
 
 	// value represents the integer value of this workloadPriorityClass. This is the actual priority that workloads
 	// receive when jobs have the name of this class in their workloadPriorityClass label.
-	// Changing the value of workloadPriorityClass doesn't affect the priority of workloads that were already created.
+	// Changing the value of workloadPriorityClass updates the priority of the unfinished workloads that use it.
 	Value int32 `json:"value"`
 
 	// description is an arbitrary string that usually provides guidelines on
 	// when this workloadPriorityClass should be used.
 	// +optional
 	Description string `json:"description,omitempty"`
+
+	// preemptionPolicy is the policy for preempting workloads with lower
+	// priority to admit the workloads of this workloadPriorityClass.
+	// One of Never, PreemptLowerPriority. When Never, the workloads are placed
+	// ahead of the workloads with lower priority in the queue, but they don't
+	// preempt any workload.
+	// Defaults to PreemptLowerPriority if unset.
+	// Changing the preemptionPolicy of workloadPriorityClass updates the unfinished workloads that use it.
+	// +optional
+	// +kubebuilder:validation:Enum=Never;PreemptLowerPriority
+	PreemptionPolicy *corev1.PreemptionPolicy `json:"preemptionPolicy,omitempty"`
+
+	// protected indicates that the workloads of this workloadPriorityClass
+	// can't be preempted by workloads with lower priority, even when their
+	// ClusterQueue is borrowing and the preempting ClusterQueue reclaims its
+	// quota in the cohort with reclaimWithinCohort set to Any.
+	// Changing protected updates the unfinished workloads that use the workloadPriorityClass.
+	// +optional
+	Protected bool `json:"protected,omitempty"`
 }
 
 //+kubebuilder:object:root=true
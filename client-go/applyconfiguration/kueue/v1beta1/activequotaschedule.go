@@ -0,0 +1,61 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// ActiveQuotaScheduleApplyConfiguration represents an declarative configuration of the ActiveQuotaSchedule type for use
+// with apply.
+type ActiveQuotaScheduleApplyConfiguration struct {
+	Flavor  *v1beta1.ResourceFlavorReference `json:"flavor,omitempty"`
+	Name    *string                          `json:"name,omitempty"`
+	EndTime *v1.Time                         `json:"endTime,omitempty"`
+}
+
+// ActiveQuotaScheduleApplyConfiguration constructs an declarative configuration of the ActiveQuotaSchedule type for use with
+// apply.
+func ActiveQuotaSchedule() *ActiveQuotaScheduleApplyConfiguration {
+	return &ActiveQuotaScheduleApplyConfiguration{}
+}
+
+// WithFlavor sets the Flavor field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Flavor field is set to the value of the last call.
+func (b *ActiveQuotaScheduleApplyConfiguration) WithFlavor(value v1beta1.ResourceFlavorReference) *ActiveQuotaScheduleApplyConfiguration {
+	b.Flavor = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ActiveQuotaScheduleApplyConfiguration) WithName(value string) *ActiveQuotaScheduleApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithEndTime sets the EndTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EndTime field is set to the value of the last call.
+func (b *ActiveQuotaScheduleApplyConfiguration) WithEndTime(value v1.Time) *ActiveQuotaScheduleApplyConfiguration {
+	b.EndTime = &value
+	return b
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// AdmissionCheckStrategyRuleApplyConfiguration represents an declarative configuration of the AdmissionCheckStrategyRule type for use
+// with apply.
+type AdmissionCheckStrategyRuleApplyConfiguration struct {
+	Name      *string                           `json:"name,omitempty"`
+	OnFlavors []v1beta1.ResourceFlavorReference `json:"onFlavors,omitempty"`
+}
+
+// AdmissionCheckStrategyRuleApplyConfiguration constructs an declarative configuration of the AdmissionCheckStrategyRule type for use with
+// apply.
+func AdmissionCheckStrategyRule() *AdmissionCheckStrategyRuleApplyConfiguration {
+	return &AdmissionCheckStrategyRuleApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *AdmissionCheckStrategyRuleApplyConfiguration) WithName(value string) *AdmissionCheckStrategyRuleApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithOnFlavors adds the given value to the OnFlavors field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OnFlavors field.
+func (b *AdmissionCheckStrategyRuleApplyConfiguration) WithOnFlavors(values ...v1beta1.ResourceFlavorReference) *AdmissionCheckStrategyRuleApplyConfiguration {
+	for i := range values {
+		b.OnFlavors = append(b.OnFlavors, values[i])
+	}
+	return b
+}
@@ -25,13 +25,16 @@ import (
 // ClusterQueueSpecApplyConfiguration represents an declarative configuration of the ClusterQueueSpec type for use
 // with apply.
 type ClusterQueueSpecApplyConfiguration struct {
-	ResourceGroups    []ResourceGroupApplyConfiguration         `json:"resourceGroups,omitempty"`
-	Cohort            *string                                   `json:"cohort,omitempty"`
-	QueueingStrategy  *kueuev1beta1.QueueingStrategy            `json:"queueingStrategy,omitempty"`
-	NamespaceSelector *v1.LabelSelector                         `json:"namespaceSelector,omitempty"`
-	FlavorFungibility *FlavorFungibilityApplyConfiguration      `json:"flavorFungibility,omitempty"`
-	Preemption        *ClusterQueuePreemptionApplyConfiguration `json:"preemption,omitempty"`
-	AdmissionChecks   []string                                  `json:"admissionChecks,omitempty"`
+	ResourceGroups          []ResourceGroupApplyConfiguration               `json:"resourceGroups,omitempty"`
+	Cohort                  *string                                         `json:"cohort,omitempty"`
+	QueueingStrategy        *kueuev1beta1.QueueingStrategy                  `json:"queueingStrategy,omitempty"`
+	NamespaceSelector       *v1.LabelSelector                               `json:"namespaceSelector,omitempty"`
+	FlavorFungibility       *FlavorFungibilityApplyConfiguration            `json:"flavorFungibility,omitempty"`
+	Preemption              *ClusterQueuePreemptionApplyConfiguration       `json:"preemption,omitempty"`
+	AdmissionChecks         []string                                        `json:"admissionChecks,omitempty"`
+	AdmissionChecksStrategy *AdmissionChecksStrategyApplyConfiguration      `json:"admissionChecksStrategy,omitempty"`
+	WaitForPodsReady        *ClusterQueueWaitForPodsReadyApplyConfiguration `json:"waitForPodsReady,omitempty"`
+	QuotaShrinkPolicy       *kueuev1beta1.QuotaShrinkPolicy                 `json:"quotaShrinkPolicy,omitempty"`
 }
 
 // ClusterQueueSpecApplyConfiguration constructs an declarative configuration of the ClusterQueueSpec type for use with
@@ -102,3 +105,27 @@ func (b *ClusterQueueSpecApplyConfiguration) WithAdmissionChecks(values ...strin
 	}
 	return b
 }
+
+// WithAdmissionChecksStrategy sets the AdmissionChecksStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AdmissionChecksStrategy field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithAdmissionChecksStrategy(value *AdmissionChecksStrategyApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.AdmissionChecksStrategy = value
+	return b
+}
+
+// WithWaitForPodsReady sets the WaitForPodsReady field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WaitForPodsReady field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithWaitForPodsReady(value *ClusterQueueWaitForPodsReadyApplyConfiguration) *ClusterQueueSpecApplyConfiguration {
+	b.WaitForPodsReady = value
+	return b
+}
+
+// WithQuotaShrinkPolicy sets the QuotaShrinkPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the QuotaShrinkPolicy field is set to the value of the last call.
+func (b *ClusterQueueSpecApplyConfiguration) WithQuotaShrinkPolicy(value kueuev1beta1.QuotaShrinkPolicy) *ClusterQueueSpecApplyConfiguration {
+	b.QuotaShrinkPolicy = &value
+	return b
+}
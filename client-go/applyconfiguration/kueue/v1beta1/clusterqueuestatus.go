@@ -31,6 +31,7 @@ type ClusterQueueStatusApplyConfiguration struct {
 	AdmittedWorkloads      *int32                                                `json:"admittedWorkloads,omitempty"`
 	Conditions             []v1.Condition                                        `json:"conditions,omitempty"`
 	PendingWorkloadsStatus *ClusterQueuePendingWorkloadsStatusApplyConfiguration `json:"pendingWorkloadsStatus,omitempty"`
+	ActiveQuotaSchedules   []ActiveQuotaScheduleApplyConfiguration               `json:"activeQuotaSchedules,omitempty"`
 }
 
 // ClusterQueueStatusApplyConfiguration constructs an declarative configuration of the ClusterQueueStatus type for use with
@@ -106,3 +107,16 @@ func (b *ClusterQueueStatusApplyConfiguration) WithPendingWorkloadsStatus(value
 	b.PendingWorkloadsStatus = value
 	return b
 }
+
+// WithActiveQuotaSchedules adds the given value to the ActiveQuotaSchedules field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ActiveQuotaSchedules field.
+func (b *ClusterQueueStatusApplyConfiguration) WithActiveQuotaSchedules(values ...*ActiveQuotaScheduleApplyConfiguration) *ClusterQueueStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithActiveQuotaSchedules")
+		}
+		b.ActiveQuotaSchedules = append(b.ActiveQuotaSchedules, *values[i])
+	}
+	return b
+}
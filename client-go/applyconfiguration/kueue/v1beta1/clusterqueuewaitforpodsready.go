@@ -0,0 +1,60 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterQueueWaitForPodsReadyApplyConfiguration represents an declarative configuration of the ClusterQueueWaitForPodsReady type for use
+// with apply.
+type ClusterQueueWaitForPodsReadyApplyConfiguration struct {
+	Enable         *bool        `json:"enable,omitempty"`
+	Timeout        *v1.Duration `json:"timeout,omitempty"`
+	BlockAdmission *bool        `json:"blockAdmission,omitempty"`
+}
+
+// ClusterQueueWaitForPodsReadyApplyConfiguration constructs an declarative configuration of the ClusterQueueWaitForPodsReady type for use with
+// apply.
+func ClusterQueueWaitForPodsReady() *ClusterQueueWaitForPodsReadyApplyConfiguration {
+	return &ClusterQueueWaitForPodsReadyApplyConfiguration{}
+}
+
+// WithEnable sets the Enable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Enable field is set to the value of the last call.
+func (b *ClusterQueueWaitForPodsReadyApplyConfiguration) WithEnable(value bool) *ClusterQueueWaitForPodsReadyApplyConfiguration {
+	b.Enable = &value
+	return b
+}
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *ClusterQueueWaitForPodsReadyApplyConfiguration) WithTimeout(value v1.Duration) *ClusterQueueWaitForPodsReadyApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
+// WithBlockAdmission sets the BlockAdmission field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BlockAdmission field is set to the value of the last call.
+func (b *ClusterQueueWaitForPodsReadyApplyConfiguration) WithBlockAdmission(value bool) *ClusterQueueWaitForPodsReadyApplyConfiguration {
+	b.BlockAdmission = &value
+	return b
+}
@@ -26,6 +26,7 @@ import (
 type FlavorQuotasApplyConfiguration struct {
 	Name      *v1beta1.ResourceFlavorReference  `json:"name,omitempty"`
 	Resources []ResourceQuotaApplyConfiguration `json:"resources,omitempty"`
+	Schedules []QuotaScheduleApplyConfiguration `json:"schedules,omitempty"`
 }
 
 // FlavorQuotasApplyConfiguration constructs an declarative configuration of the FlavorQuotas type for use with
@@ -54,3 +55,16 @@ func (b *FlavorQuotasApplyConfiguration) WithResources(values ...*ResourceQuotaA
 	}
 	return b
 }
+
+// WithSchedules adds the given value to the Schedules field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Schedules field.
+func (b *FlavorQuotasApplyConfiguration) WithSchedules(values ...*QuotaScheduleApplyConfiguration) *FlavorQuotasApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSchedules")
+		}
+		b.Schedules = append(b.Schedules, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// PodSetFlavorGroupApplyConfiguration represents an declarative configuration of the PodSetFlavorGroup type for use
+// with apply.
+type PodSetFlavorGroupApplyConfiguration struct {
+	PodSets   []string `json:"podSets,omitempty"`
+	NodeLabel *string  `json:"nodeLabel,omitempty"`
+}
+
+// PodSetFlavorGroupApplyConfiguration constructs an declarative configuration of the PodSetFlavorGroup type for use with
+// apply.
+func PodSetFlavorGroup() *PodSetFlavorGroupApplyConfiguration {
+	return &PodSetFlavorGroupApplyConfiguration{}
+}
+
+// WithPodSets adds the given value to the PodSets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PodSets field.
+func (b *PodSetFlavorGroupApplyConfiguration) WithPodSets(values ...string) *PodSetFlavorGroupApplyConfiguration {
+	for i := range values {
+		b.PodSets = append(b.PodSets, values[i])
+	}
+	return b
+}
+
+// WithNodeLabel sets the NodeLabel field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeLabel field is set to the value of the last call.
+func (b *PodSetFlavorGroupApplyConfiguration) WithNodeLabel(value string) *PodSetFlavorGroupApplyConfiguration {
+	b.NodeLabel = &value
+	return b
+}
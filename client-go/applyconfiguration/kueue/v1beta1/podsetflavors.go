@@ -0,0 +1,58 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// PodSetFlavorsApplyConfiguration represents an declarative configuration of the PodSetFlavors type for use
+// with apply.
+type PodSetFlavorsApplyConfiguration struct {
+	Name    *string                                             `json:"name,omitempty"`
+	Flavors map[v1.ResourceName]v1beta1.ResourceFlavorReference `json:"flavors,omitempty"`
+}
+
+// PodSetFlavorsApplyConfiguration constructs an declarative configuration of the PodSetFlavors type for use with
+// apply.
+func PodSetFlavors() *PodSetFlavorsApplyConfiguration {
+	return &PodSetFlavorsApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PodSetFlavorsApplyConfiguration) WithName(value string) *PodSetFlavorsApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithFlavors puts the entries into the Flavors field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Flavors field,
+// overwriting an existing map entries in Flavors field with the same key.
+func (b *PodSetFlavorsApplyConfiguration) WithFlavors(entries map[v1.ResourceName]v1beta1.ResourceFlavorReference) *PodSetFlavorsApplyConfiguration {
+	if b.Flavors == nil && len(entries) > 0 {
+		b.Flavors = make(map[v1.ResourceName]v1beta1.ResourceFlavorReference, len(entries))
+	}
+	for k, v := range entries {
+		b.Flavors[k] = v
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	types "k8s.io/apimachinery/pkg/types"
+	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// PreemptorReferenceApplyConfiguration represents an declarative configuration of the PreemptorReference type for use
+// with apply.
+type PreemptorReferenceApplyConfiguration struct {
+	Name         *string                        `json:"name,omitempty"`
+	Namespace    *string                        `json:"namespace,omitempty"`
+	UID          *types.UID                     `json:"uid,omitempty"`
+	ClusterQueue *v1beta1.ClusterQueueReference `json:"clusterQueue,omitempty"`
+}
+
+// PreemptorReferenceApplyConfiguration constructs an declarative configuration of the PreemptorReference type for use with
+// apply.
+func PreemptorReference() *PreemptorReferenceApplyConfiguration {
+	return &PreemptorReferenceApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PreemptorReferenceApplyConfiguration) WithName(value string) *PreemptorReferenceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *PreemptorReferenceApplyConfiguration) WithNamespace(value string) *PreemptorReferenceApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithUID sets the UID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UID field is set to the value of the last call.
+func (b *PreemptorReferenceApplyConfiguration) WithUID(value types.UID) *PreemptorReferenceApplyConfiguration {
+	b.UID = &value
+	return b
+}
+
+// WithClusterQueue sets the ClusterQueue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterQueue field is set to the value of the last call.
+func (b *PreemptorReferenceApplyConfiguration) WithClusterQueue(value v1beta1.ClusterQueueReference) *PreemptorReferenceApplyConfiguration {
+	b.ClusterQueue = &value
+	return b
+}
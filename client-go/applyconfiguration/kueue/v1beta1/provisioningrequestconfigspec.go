@@ -25,9 +25,10 @@ import (
 // ProvisioningRequestConfigSpecApplyConfiguration represents an declarative configuration of the ProvisioningRequestConfigSpec type for use
 // with apply.
 type ProvisioningRequestConfigSpecApplyConfiguration struct {
-	ProvisioningClassName *string                      `json:"provisioningClassName,omitempty"`
-	Parameters            map[string]v1beta1.Parameter `json:"parameters,omitempty"`
-	ManagedResources      []v1.ResourceName            `json:"managedResources,omitempty"`
+	ProvisioningClassName *string                                             `json:"provisioningClassName,omitempty"`
+	Parameters            map[string]v1beta1.Parameter                        `json:"parameters,omitempty"`
+	ManagedResources      []v1.ResourceName                                   `json:"managedResources,omitempty"`
+	RetryStrategy         *ProvisioningRequestRetryStrategyApplyConfiguration `json:"retryStrategy,omitempty"`
 }
 
 // ProvisioningRequestConfigSpecApplyConfiguration constructs an declarative configuration of the ProvisioningRequestConfigSpec type for use with
@@ -67,3 +68,11 @@ func (b *ProvisioningRequestConfigSpecApplyConfiguration) WithManagedResources(v
 	}
 	return b
 }
+
+// WithRetryStrategy sets the RetryStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetryStrategy field is set to the value of the last call.
+func (b *ProvisioningRequestConfigSpecApplyConfiguration) WithRetryStrategy(value *ProvisioningRequestRetryStrategyApplyConfiguration) *ProvisioningRequestConfigSpecApplyConfiguration {
+	b.RetryStrategy = value
+	return b
+}
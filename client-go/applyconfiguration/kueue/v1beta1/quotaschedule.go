@@ -0,0 +1,94 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// QuotaScheduleApplyConfiguration represents an declarative configuration of the QuotaSchedule type for use
+// with apply.
+type QuotaScheduleApplyConfiguration struct {
+	Name       *string                                    `json:"name,omitempty"`
+	DaysOfWeek []v1beta1.DayOfWeek                        `json:"daysOfWeek,omitempty"`
+	StartTime  *string                                    `json:"startTime,omitempty"`
+	EndTime    *string                                    `json:"endTime,omitempty"`
+	TimeZone   *string                                    `json:"timeZone,omitempty"`
+	Resources  []ScheduledResourceQuotaApplyConfiguration `json:"resources,omitempty"`
+}
+
+// QuotaScheduleApplyConfiguration constructs an declarative configuration of the QuotaSchedule type for use with
+// apply.
+func QuotaSchedule() *QuotaScheduleApplyConfiguration {
+	return &QuotaScheduleApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *QuotaScheduleApplyConfiguration) WithName(value string) *QuotaScheduleApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithDaysOfWeek adds the given value to the DaysOfWeek field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DaysOfWeek field.
+func (b *QuotaScheduleApplyConfiguration) WithDaysOfWeek(values ...v1beta1.DayOfWeek) *QuotaScheduleApplyConfiguration {
+	for i := range values {
+		b.DaysOfWeek = append(b.DaysOfWeek, values[i])
+	}
+	return b
+}
+
+// WithStartTime sets the StartTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartTime field is set to the value of the last call.
+func (b *QuotaScheduleApplyConfiguration) WithStartTime(value string) *QuotaScheduleApplyConfiguration {
+	b.StartTime = &value
+	return b
+}
+
+// WithEndTime sets the EndTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EndTime field is set to the value of the last call.
+func (b *QuotaScheduleApplyConfiguration) WithEndTime(value string) *QuotaScheduleApplyConfiguration {
+	b.EndTime = &value
+	return b
+}
+
+// WithTimeZone sets the TimeZone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeZone field is set to the value of the last call.
+func (b *QuotaScheduleApplyConfiguration) WithTimeZone(value string) *QuotaScheduleApplyConfiguration {
+	b.TimeZone = &value
+	return b
+}
+
+// WithResources adds the given value to the Resources field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Resources field.
+func (b *QuotaScheduleApplyConfiguration) WithResources(values ...*ScheduledResourceQuotaApplyConfiguration) *QuotaScheduleApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithResources")
+		}
+		b.Resources = append(b.Resources, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// ReservationStatusApplyConfiguration represents an declarative configuration of the ReservationStatus type for use
+// with apply.
+type ReservationStatusApplyConfiguration struct {
+	Phase              *v1beta1.ReservationPhase          `json:"phase,omitempty"`
+	FlavorsUsage       []ReservedFlavorApplyConfiguration `json:"flavorsUsage,omitempty"`
+	ReservingWorkloads *int32                             `json:"reservingWorkloads,omitempty"`
+}
+
+// ReservationStatusApplyConfiguration constructs an declarative configuration of the ReservationStatus type for use with
+// apply.
+func ReservationStatus() *ReservationStatusApplyConfiguration {
+	return &ReservationStatusApplyConfiguration{}
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *ReservationStatusApplyConfiguration) WithPhase(value v1beta1.ReservationPhase) *ReservationStatusApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithFlavorsUsage adds the given value to the FlavorsUsage field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FlavorsUsage field.
+func (b *ReservationStatusApplyConfiguration) WithFlavorsUsage(values ...*ReservedFlavorApplyConfiguration) *ReservationStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFlavorsUsage")
+		}
+		b.FlavorsUsage = append(b.FlavorsUsage, *values[i])
+	}
+	return b
+}
+
+// WithReservingWorkloads sets the ReservingWorkloads field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReservingWorkloads field is set to the value of the last call.
+func (b *ReservationStatusApplyConfiguration) WithReservingWorkloads(value int32) *ReservationStatusApplyConfiguration {
+	b.ReservingWorkloads = &value
+	return b
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	v1beta1 "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// ReservedFlavorApplyConfiguration represents an declarative configuration of the ReservedFlavor type for use
+// with apply.
+type ReservedFlavorApplyConfiguration struct {
+	Name      *v1beta1.ResourceFlavorReference `json:"name,omitempty"`
+	Resources *v1.ResourceList                 `json:"resources,omitempty"`
+}
+
+// ReservedFlavorApplyConfiguration constructs an declarative configuration of the ReservedFlavor type for use with
+// apply.
+func ReservedFlavor() *ReservedFlavorApplyConfiguration {
+	return &ReservedFlavorApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ReservedFlavorApplyConfiguration) WithName(value v1beta1.ResourceFlavorReference) *ReservedFlavorApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithResources sets the Resources field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Resources field is set to the value of the last call.
+func (b *ReservedFlavorApplyConfiguration) WithResources(value v1.ResourceList) *ReservedFlavorApplyConfiguration {
+	b.Resources = &value
+	return b
+}
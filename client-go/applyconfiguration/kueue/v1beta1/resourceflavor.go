@@ -28,7 +28,8 @@ import (
 type ResourceFlavorApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Spec                             *ResourceFlavorSpecApplyConfiguration `json:"spec,omitempty"`
+	Spec                             *ResourceFlavorSpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                           *ResourceFlavorStatusApplyConfiguration `json:"status,omitempty"`
 }
 
 // ResourceFlavor constructs an declarative configuration of the ResourceFlavor type for use with
@@ -206,3 +207,11 @@ func (b *ResourceFlavorApplyConfiguration) WithSpec(value *ResourceFlavorSpecApp
 	b.Spec = value
 	return b
 }
+
+// WithStatus sets the Status field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Status field is set to the value of the last call.
+func (b *ResourceFlavorApplyConfiguration) WithStatus(value *ResourceFlavorStatusApplyConfiguration) *ResourceFlavorApplyConfiguration {
+	b.Status = value
+	return b
+}
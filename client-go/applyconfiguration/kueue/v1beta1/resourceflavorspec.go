@@ -24,9 +24,10 @@ import (
 // ResourceFlavorSpecApplyConfiguration represents an declarative configuration of the ResourceFlavorSpec type for use
 // with apply.
 type ResourceFlavorSpecApplyConfiguration struct {
-	NodeLabels  map[string]string `json:"nodeLabels,omitempty"`
-	NodeTaints  []v1.Taint        `json:"nodeTaints,omitempty"`
-	Tolerations []v1.Toleration   `json:"tolerations,omitempty"`
+	NodeLabels           map[string]string                       `json:"nodeLabels,omitempty"`
+	NodeTaints           []v1.Taint                              `json:"nodeTaints,omitempty"`
+	Tolerations          []v1.Toleration                         `json:"tolerations,omitempty"`
+	PodTemplateOverrides *PodTemplateOverridesApplyConfiguration `json:"podTemplateOverrides,omitempty"`
 }
 
 // ResourceFlavorSpecApplyConfiguration constructs an declarative configuration of the ResourceFlavorSpec type for use with
@@ -68,3 +69,11 @@ func (b *ResourceFlavorSpecApplyConfiguration) WithTolerations(values ...v1.Tole
 	}
 	return b
 }
+
+// WithPodTemplateOverrides sets the PodTemplateOverrides field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodTemplateOverrides field is set to the value of the last call.
+func (b *ResourceFlavorSpecApplyConfiguration) WithPodTemplateOverrides(value *PodTemplateOverridesApplyConfiguration) *ResourceFlavorSpecApplyConfiguration {
+	b.PodTemplateOverrides = value
+	return b
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// ResourceFlavorStatusApplyConfiguration represents an declarative configuration of the ResourceFlavorStatus type for use
+// with apply.
+type ResourceFlavorStatusApplyConfiguration struct {
+	NodeCount *int32           `json:"nodeCount,omitempty"`
+	Capacity  *v1.ResourceList `json:"capacity,omitempty"`
+}
+
+// ResourceFlavorStatusApplyConfiguration constructs an declarative configuration of the ResourceFlavorStatus type for use with
+// apply.
+func ResourceFlavorStatus() *ResourceFlavorStatusApplyConfiguration {
+	return &ResourceFlavorStatusApplyConfiguration{}
+}
+
+// WithNodeCount sets the NodeCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NodeCount field is set to the value of the last call.
+func (b *ResourceFlavorStatusApplyConfiguration) WithNodeCount(value int32) *ResourceFlavorStatusApplyConfiguration {
+	b.NodeCount = &value
+	return b
+}
+
+// WithCapacity sets the Capacity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Capacity field is set to the value of the last call.
+func (b *ResourceFlavorStatusApplyConfiguration) WithCapacity(value v1.ResourceList) *ResourceFlavorStatusApplyConfiguration {
+	b.Capacity = &value
+	return b
+}
@@ -25,9 +25,10 @@ import (
 // ResourceQuotaApplyConfiguration represents an declarative configuration of the ResourceQuota type for use
 // with apply.
 type ResourceQuotaApplyConfiguration struct {
-	Name           *v1.ResourceName   `json:"name,omitempty"`
-	NominalQuota   *resource.Quantity `json:"nominalQuota,omitempty"`
-	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
+	Name                   *v1.ResourceName   `json:"name,omitempty"`
+	NominalQuota           *resource.Quantity `json:"nominalQuota,omitempty"`
+	NominalQuotaPercentage *int32             `json:"nominalQuotaPercentage,omitempty"`
+	BorrowingLimit         *resource.Quantity `json:"borrowingLimit,omitempty"`
 }
 
 // ResourceQuotaApplyConfiguration constructs an declarative configuration of the ResourceQuota type for use with
@@ -52,6 +53,14 @@ func (b *ResourceQuotaApplyConfiguration) WithNominalQuota(value resource.Quanti
 	return b
 }
 
+// WithNominalQuotaPercentage sets the NominalQuotaPercentage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NominalQuotaPercentage field is set to the value of the last call.
+func (b *ResourceQuotaApplyConfiguration) WithNominalQuotaPercentage(value int32) *ResourceQuotaApplyConfiguration {
+	b.NominalQuotaPercentage = &value
+	return b
+}
+
 // WithBorrowingLimit sets the BorrowingLimit field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the BorrowingLimit field is set to the value of the last call.
@@ -18,6 +18,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
@@ -28,8 +29,10 @@ import (
 type WorkloadPriorityClassApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Value                            *int32  `json:"value,omitempty"`
-	Description                      *string `json:"description,omitempty"`
+	Value                            *int32                   `json:"value,omitempty"`
+	Description                      *string                  `json:"description,omitempty"`
+	PreemptionPolicy                 *corev1.PreemptionPolicy `json:"preemptionPolicy,omitempty"`
+	Protected                        *bool                    `json:"protected,omitempty"`
 }
 
 // WorkloadPriorityClass constructs an declarative configuration of the WorkloadPriorityClass type for use with
@@ -215,3 +218,19 @@ func (b *WorkloadPriorityClassApplyConfiguration) WithDescription(value string)
 	b.Description = &value
 	return b
 }
+
+// WithPreemptionPolicy sets the PreemptionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreemptionPolicy field is set to the value of the last call.
+func (b *WorkloadPriorityClassApplyConfiguration) WithPreemptionPolicy(value corev1.PreemptionPolicy) *WorkloadPriorityClassApplyConfiguration {
+	b.PreemptionPolicy = &value
+	return b
+}
+
+// WithProtected sets the Protected field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Protected field is set to the value of the last call.
+func (b *WorkloadPriorityClassApplyConfiguration) WithProtected(value bool) *WorkloadPriorityClassApplyConfiguration {
+	b.Protected = &value
+	return b
+}
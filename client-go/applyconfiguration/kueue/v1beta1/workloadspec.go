@@ -17,14 +17,21 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
 // WorkloadSpecApplyConfiguration represents an declarative configuration of the WorkloadSpec type for use
 // with apply.
 type WorkloadSpecApplyConfiguration struct {
-	PodSets             []PodSetApplyConfiguration `json:"podSets,omitempty"`
-	QueueName           *string                    `json:"queueName,omitempty"`
-	PriorityClassName   *string                    `json:"priorityClassName,omitempty"`
-	Priority            *int32                     `json:"priority,omitempty"`
-	PriorityClassSource *string                    `json:"priorityClassSource,omitempty"`
+	PodSets             []PodSetApplyConfiguration            `json:"podSets,omitempty"`
+	QueueName           *string                               `json:"queueName,omitempty"`
+	PriorityClassName   *string                               `json:"priorityClassName,omitempty"`
+	Priority            *int32                                `json:"priority,omitempty"`
+	PriorityClassSource *string                               `json:"priorityClassSource,omitempty"`
+	PreemptionPolicy    *v1.PreemptionPolicy                  `json:"preemptionPolicy,omitempty"`
+	Protected           *bool                                 `json:"protected,omitempty"`
+	PodSetFlavorGroups  []PodSetFlavorGroupApplyConfiguration `json:"podSetFlavorGroups,omitempty"`
 }
 
 // WorkloadSpecApplyConfiguration constructs an declarative configuration of the WorkloadSpec type for use with
@@ -77,3 +84,32 @@ func (b *WorkloadSpecApplyConfiguration) WithPriorityClassSource(value string) *
 	b.PriorityClassSource = &value
 	return b
 }
+
+// WithPreemptionPolicy sets the PreemptionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreemptionPolicy field is set to the value of the last call.
+func (b *WorkloadSpecApplyConfiguration) WithPreemptionPolicy(value v1.PreemptionPolicy) *WorkloadSpecApplyConfiguration {
+	b.PreemptionPolicy = &value
+	return b
+}
+
+// WithProtected sets the Protected field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Protected field is set to the value of the last call.
+func (b *WorkloadSpecApplyConfiguration) WithProtected(value bool) *WorkloadSpecApplyConfiguration {
+	b.Protected = &value
+	return b
+}
+
+// WithPodSetFlavorGroups adds the given value to the PodSetFlavorGroups field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PodSetFlavorGroups field.
+func (b *WorkloadSpecApplyConfiguration) WithPodSetFlavorGroups(values ...*PodSetFlavorGroupApplyConfiguration) *WorkloadSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPodSetFlavorGroups")
+		}
+		b.PodSetFlavorGroups = append(b.PodSetFlavorGroups, *values[i])
+	}
+	return b
+}
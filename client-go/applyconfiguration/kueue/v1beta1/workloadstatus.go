@@ -24,10 +24,11 @@ import (
 // WorkloadStatusApplyConfiguration represents an declarative configuration of the WorkloadStatus type for use
 // with apply.
 type WorkloadStatusApplyConfiguration struct {
-	Admission       *AdmissionApplyConfiguration            `json:"admission,omitempty"`
-	Conditions      []v1.Condition                          `json:"conditions,omitempty"`
-	ReclaimablePods []ReclaimablePodApplyConfiguration      `json:"reclaimablePods,omitempty"`
-	AdmissionChecks []AdmissionCheckStateApplyConfiguration `json:"admissionChecks,omitempty"`
+	Admission        *AdmissionApplyConfiguration            `json:"admission,omitempty"`
+	Conditions       []v1.Condition                          `json:"conditions,omitempty"`
+	ReclaimablePods  []ReclaimablePodApplyConfiguration      `json:"reclaimablePods,omitempty"`
+	AdmissionChecks  []AdmissionCheckStateApplyConfiguration `json:"admissionChecks,omitempty"`
+	AdmissionHistory []AdmissionRecordApplyConfiguration     `json:"admissionHistory,omitempty"`
 }
 
 // WorkloadStatusApplyConfiguration constructs an declarative configuration of the WorkloadStatus type for use with
@@ -79,3 +80,16 @@ func (b *WorkloadStatusApplyConfiguration) WithAdmissionChecks(values ...*Admiss
 	}
 	return b
 }
+
+// WithAdmissionHistory adds the given value to the AdmissionHistory field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdmissionHistory field.
+func (b *WorkloadStatusApplyConfiguration) WithAdmissionHistory(values ...*AdmissionRecordApplyConfiguration) *WorkloadStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdmissionHistory")
+		}
+		b.AdmissionHistory = append(b.AdmissionHistory, *values[i])
+	}
+	return b
+}
@@ -28,6 +28,8 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=kueue.x-k8s.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithKind("ActiveQuotaSchedule"):
+		return &kueuev1beta1.ActiveQuotaScheduleApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Admission"):
 		return &kueuev1beta1.AdmissionApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheck"):
@@ -36,10 +38,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.AdmissionCheckParametersReferenceApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckSpec"):
 		return &kueuev1beta1.AdmissionCheckSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AdmissionChecksStrategy"):
+		return &kueuev1beta1.AdmissionChecksStrategyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckState"):
 		return &kueuev1beta1.AdmissionCheckStateApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckStatus"):
 		return &kueuev1beta1.AdmissionCheckStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AdmissionCheckStrategyRule"):
+		return &kueuev1beta1.AdmissionCheckStrategyRuleApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AdmissionRecord"):
+		return &kueuev1beta1.AdmissionRecordApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueue"):
 		return &kueuev1beta1.ClusterQueueApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueuePendingWorkload"):
@@ -52,6 +60,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.ClusterQueueSpecApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueueStatus"):
 		return &kueuev1beta1.ClusterQueueStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterQueueWaitForPodsReady"):
+		return &kueuev1beta1.ClusterQueueWaitForPodsReadyApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlavorFungibility"):
 		return &kueuev1beta1.FlavorFungibilityApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("FlavorQuotas"):
@@ -72,24 +82,48 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &kueuev1beta1.PodSetApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetAssignment"):
 		return &kueuev1beta1.PodSetAssignmentApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PodSetFlavorGroup"):
+		return &kueuev1beta1.PodSetFlavorGroupApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PodSetFlavors"):
+		return &kueuev1beta1.PodSetFlavorsApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("PodSetUpdate"):
 		return &kueuev1beta1.PodSetUpdateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PodTemplateOverrides"):
+		return &kueuev1beta1.PodTemplateOverridesApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PreemptorReference"):
+		return &kueuev1beta1.PreemptorReferenceApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfig"):
 		return &kueuev1beta1.ProvisioningRequestConfigApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestConfigSpec"):
 		return &kueuev1beta1.ProvisioningRequestConfigSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ProvisioningRequestRetryStrategy"):
+		return &kueuev1beta1.ProvisioningRequestRetryStrategyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("QuotaSchedule"):
+		return &kueuev1beta1.QuotaScheduleApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ReclaimablePod"):
 		return &kueuev1beta1.ReclaimablePodApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("Reservation"):
+		return &kueuev1beta1.ReservationApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ReservationSpec"):
+		return &kueuev1beta1.ReservationSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ReservationStatus"):
+		return &kueuev1beta1.ReservationStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ReservedFlavor"):
+		return &kueuev1beta1.ReservedFlavorApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavor"):
 		return &kueuev1beta1.ResourceFlavorApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavorSpec"):
 		return &kueuev1beta1.ResourceFlavorSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ResourceFlavorStatus"):
+		return &kueuev1beta1.ResourceFlavorStatusApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceGroup"):
 		return &kueuev1beta1.ResourceGroupApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceQuota"):
 		return &kueuev1beta1.ResourceQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("ResourceUsage"):
 		return &kueuev1beta1.ResourceUsageApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ScheduledResourceQuota"):
+		return &kueuev1beta1.ScheduledResourceQuotaApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("Workload"):
 		return &kueuev1beta1.WorkloadApplyConfiguration{}
 	case v1beta1.SchemeGroupVersion.WithKind("WorkloadPriorityClass"):
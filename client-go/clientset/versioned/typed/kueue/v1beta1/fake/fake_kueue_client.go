@@ -43,6 +43,10 @@ func (c *FakeKueueV1beta1) ProvisioningRequestConfigs() v1beta1.ProvisioningRequ
 	return &FakeProvisioningRequestConfigs{c}
 }
 
+func (c *FakeKueueV1beta1) Reservations() v1beta1.ReservationInterface {
+	return &FakeReservations{c}
+}
+
 func (c *FakeKueueV1beta1) ResourceFlavors() v1beta1.ResourceFlavorInterface {
 	return &FakeResourceFlavors{c}
 }
@@ -97,6 +97,17 @@ func (c *FakeResourceFlavors) Update(ctx context.Context, resourceFlavor *v1beta
 	return obj.(*v1beta1.ResourceFlavor), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeResourceFlavors) UpdateStatus(ctx context.Context, resourceFlavor *v1beta1.ResourceFlavor, opts v1.UpdateOptions) (*v1beta1.ResourceFlavor, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(resourceflavorsResource, "status", resourceFlavor), &v1beta1.ResourceFlavor{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ResourceFlavor), err
+}
+
 // Delete takes name of the resourceFlavor and deletes it. Returns an error if one occurs.
 func (c *FakeResourceFlavors) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -142,3 +153,25 @@ func (c *FakeResourceFlavors) Apply(ctx context.Context, resourceFlavor *kueuev1
 	}
 	return obj.(*v1beta1.ResourceFlavor), err
 }
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeResourceFlavors) ApplyStatus(ctx context.Context, resourceFlavor *kueuev1beta1.ResourceFlavorApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ResourceFlavor, err error) {
+	if resourceFlavor == nil {
+		return nil, fmt.Errorf("resourceFlavor provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(resourceFlavor)
+	if err != nil {
+		return nil, err
+	}
+	name := resourceFlavor.Name
+	if name == nil {
+		return nil, fmt.Errorf("resourceFlavor.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(resourceflavorsResource, *name, types.ApplyPatchType, data, "status"), &v1beta1.ResourceFlavor{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.ResourceFlavor), err
+}
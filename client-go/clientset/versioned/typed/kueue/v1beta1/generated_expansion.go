@@ -25,6 +25,8 @@ type LocalQueueExpansion interface{}
 
 type ProvisioningRequestConfigExpansion interface{}
 
+type ReservationExpansion interface{}
+
 type ResourceFlavorExpansion interface{}
 
 type WorkloadExpansion interface{}
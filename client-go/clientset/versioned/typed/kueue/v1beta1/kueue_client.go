@@ -31,6 +31,7 @@ type KueueV1beta1Interface interface {
 	ClusterQueuesGetter
 	LocalQueuesGetter
 	ProvisioningRequestConfigsGetter
+	ReservationsGetter
 	ResourceFlavorsGetter
 	WorkloadsGetter
 	WorkloadPriorityClassesGetter
@@ -57,6 +58,10 @@ func (c *KueueV1beta1Client) ProvisioningRequestConfigs() ProvisioningRequestCon
 	return newProvisioningRequestConfigs(c)
 }
 
+func (c *KueueV1beta1Client) Reservations() ReservationInterface {
+	return newReservations(c)
+}
+
 func (c *KueueV1beta1Client) ResourceFlavors() ResourceFlavorInterface {
 	return newResourceFlavors(c)
 }
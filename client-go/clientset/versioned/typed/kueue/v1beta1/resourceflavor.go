@@ -42,6 +42,7 @@ type ResourceFlavorsGetter interface {
 type ResourceFlavorInterface interface {
 	Create(ctx context.Context, resourceFlavor *v1beta1.ResourceFlavor, opts v1.CreateOptions) (*v1beta1.ResourceFlavor, error)
 	Update(ctx context.Context, resourceFlavor *v1beta1.ResourceFlavor, opts v1.UpdateOptions) (*v1beta1.ResourceFlavor, error)
+	UpdateStatus(ctx context.Context, resourceFlavor *v1beta1.ResourceFlavor, opts v1.UpdateOptions) (*v1beta1.ResourceFlavor, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1beta1.ResourceFlavor, error)
@@ -49,6 +50,7 @@ type ResourceFlavorInterface interface {
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1beta1.ResourceFlavor, err error)
 	Apply(ctx context.Context, resourceFlavor *kueuev1beta1.ResourceFlavorApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ResourceFlavor, err error)
+	ApplyStatus(ctx context.Context, resourceFlavor *kueuev1beta1.ResourceFlavorApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ResourceFlavor, err error)
 	ResourceFlavorExpansion
 }
 
@@ -131,6 +133,21 @@ func (c *resourceFlavors) Update(ctx context.Context, resourceFlavor *v1beta1.Re
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *resourceFlavors) UpdateStatus(ctx context.Context, resourceFlavor *v1beta1.ResourceFlavor, opts v1.UpdateOptions) (result *v1beta1.ResourceFlavor, err error) {
+	result = &v1beta1.ResourceFlavor{}
+	err = c.client.Put().
+		Resource("resourceflavors").
+		Name(resourceFlavor.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(resourceFlavor).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the resourceFlavor and deletes it. Returns an error if one occurs.
 func (c *resourceFlavors) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -194,3 +211,32 @@ func (c *resourceFlavors) Apply(ctx context.Context, resourceFlavor *kueuev1beta
 		Into(result)
 	return
 }
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *resourceFlavors) ApplyStatus(ctx context.Context, resourceFlavor *kueuev1beta1.ResourceFlavorApplyConfiguration, opts v1.ApplyOptions) (result *v1beta1.ResourceFlavor, err error) {
+	if resourceFlavor == nil {
+		return nil, fmt.Errorf("resourceFlavor provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(resourceFlavor)
+	if err != nil {
+		return nil, err
+	}
+
+	name := resourceFlavor.Name
+	if name == nil {
+		return nil, fmt.Errorf("resourceFlavor.Name must be provided to Apply")
+	}
+
+	result = &v1beta1.ResourceFlavor{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Resource("resourceflavors").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
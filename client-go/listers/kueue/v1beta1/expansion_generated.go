@@ -37,6 +37,10 @@ type LocalQueueNamespaceListerExpansion interface{}
 // ProvisioningRequestConfigLister.
 type ProvisioningRequestConfigListerExpansion interface{}
 
+// ReservationListerExpansion allows custom methods to be added to
+// ReservationLister.
+type ReservationListerExpansion interface{}
+
 // ResourceFlavorListerExpansion allows custom methods to be added to
 // ResourceFlavorLister.
 type ResourceFlavorListerExpansion interface{}
@@ -22,13 +22,17 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	zaplog "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -39,7 +43,9 @@ import (
 	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -53,22 +59,29 @@ import (
 	"sigs.k8s.io/kueue/pkg/controller/core"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/controller/jobs/generic"
 	"sigs.k8s.io/kueue/pkg/controller/jobs/noop"
+	"sigs.k8s.io/kueue/pkg/debugger"
+	"sigs.k8s.io/kueue/pkg/dra"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/scheduler"
+	"sigs.k8s.io/kueue/pkg/tracing"
 	"sigs.k8s.io/kueue/pkg/util/cert"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
 	"sigs.k8s.io/kueue/pkg/util/useragent"
 	"sigs.k8s.io/kueue/pkg/version"
 	"sigs.k8s.io/kueue/pkg/webhooks"
+	"sigs.k8s.io/kueue/pkg/workload"
 
 	// Ensure linking of the job controllers.
 	_ "sigs.k8s.io/kueue/pkg/controller/jobs"
 	// +kubebuilder:scaffold:imports
 )
 
+const tracingShutdownTimeout = 5 * time.Second
+
 var (
 	scheme            = runtime.NewScheme()
 	setupLog          = ctrl.Log.WithName("setup")
@@ -125,7 +138,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	metrics.Register()
+	shutdownTracing := setupTracing(&cfg)
 
 	kubeConfig := ctrl.GetConfigOrDie()
 	if kubeConfig.UserAgent == "" {
@@ -139,6 +152,7 @@ func main() {
 		setupLog.Error(err, "Unable to start manager")
 		os.Exit(1)
 	}
+	metrics.Register(mgr.Elected())
 
 	certsReady := make(chan struct{})
 
@@ -151,10 +165,19 @@ func main() {
 		close(certsReady)
 	}
 
-	cCache := cache.New(mgr.GetClient(), cache.WithPodsReadyTracking(blockForPodsReady(&cfg)))
-	queues := queue.NewManager(mgr.GetClient(), cCache)
-
 	ctx := ctrl.SetupSignalHandler()
+	var templates *dra.Templates
+	if cfg.Resources != nil && len(cfg.Resources.DeviceClassMappings) > 0 {
+		if templates, err = dra.WatchTemplates(ctx, mgr.GetCache()); err != nil {
+			setupLog.Error(err, "Unable to watch the ResourceClaimTemplates")
+			os.Exit(1)
+		}
+	}
+
+	wlInfoOpts := workloadInfoOptions(&cfg, templates)
+	cCache := cache.New(mgr.GetClient(), cache.WithPodsReadyTracking(blockForPodsReady(&cfg)), cache.WithWorkloadInfoOptions(wlInfoOpts...))
+	queues := queue.NewManager(mgr.GetClient(), cCache, queueOptions(&cfg, wlInfoOpts)...)
+
 	if err := setupIndexes(ctx, mgr, &cfg); err != nil {
 		setupLog.Error(err, "Unable to setup indexes")
 		os.Exit(1)
@@ -163,6 +186,7 @@ func main() {
 	serverVersionFetcher := setupServerVersionFetcher(mgr, kubeConfig)
 
 	setupProbeEndpoints(mgr)
+	setupDebugger(mgr, cCache, queues, certsReady, &cfg)
 	// Cert won't be ready until manager starts, so start a goroutine here which
 	// will block until the cert is ready before setting up the controllers.
 	// Controllers who register after manager starts will start directly.
@@ -171,17 +195,70 @@ func main() {
 	go func() {
 		queues.CleanUpOnContext(ctx)
 	}()
-	go func() {
-		cCache.CleanUpOnContext(ctx)
-	}()
 
-	setupScheduler(mgr, cCache, queues)
+	setupScheduler(mgr, cCache, queues, &cfg)
 
 	setupLog.Info("Starting manager")
 	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "Could not run manager")
 		os.Exit(1)
 	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		setupLog.Error(err, "Could not flush the tracing spans")
+	}
+}
+
+// setupDebugger logs the state of the cache and the queues on SIGUSR2 and,
+// if configured, serves it on the debug endpoints, with the webhook serving
+// certificates.
+func setupDebugger(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, certsReady <-chan struct{}, cfg *configapi.Configuration) {
+	if err := mgr.Add(debugger.NewDumper(cCache, queues)); err != nil {
+		setupLog.Error(err, "Unable to add the debug dumper")
+		os.Exit(1)
+	}
+	addr := cfg.ControllerManager.DebugBindAddress
+	if addr == "" || addr == "0" {
+		return
+	}
+	certDir := cfg.Webhook.CertDir
+	if certDir == "" {
+		certDir = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+	}
+	handler := debugger.NewHandler(mgr.GetClient(), cCache, queues)
+	if err := mgr.Add(debugger.NewServer(addr, certDir, certsReady, handler)); err != nil {
+		setupLog.Error(err, "Unable to add the debug server")
+		os.Exit(1)
+	}
+}
+
+// setupTracing registers the exporter of the spans, if configured, and
+// returns the function that flushes them on exit.
+func setupTracing(cfg *configapi.Configuration) func(context.Context) error {
+	noop := func(context.Context) error { return nil }
+	if cfg.Tracing == nil {
+		return noop
+	}
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Tracing.Exporter {
+	case configapi.TracingExporterStdout:
+		exporter, err = tracing.NewStdoutExporter()
+	default:
+		exporter, err = tracing.NewOTLPExporter(context.Background(), *cfg.Tracing.Endpoint, cfg.Tracing.Insecure)
+	}
+	if err != nil {
+		setupLog.Error(err, "Unable to create the tracing exporter")
+		os.Exit(1)
+	}
+	shutdown, err := tracing.Setup(exporter, *cfg.Tracing.SamplingRatePerMillion)
+	if err != nil {
+		setupLog.Error(err, "Unable to set up tracing")
+		os.Exit(1)
+	}
+	setupLog.Info("Tracing enabled", "exporter", cfg.Tracing.Exporter)
+	return shutdown
 }
 
 func setupIndexes(ctx context.Context, mgr ctrl.Manager, cfg *configapi.Configuration) error {
@@ -234,7 +311,14 @@ func setupControllers(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manag
 
 	manageJobsWithoutQueueName := cfg.ManageJobsWithoutQueueName
 
-	if failedWebhook, err := webhooks.Setup(mgr); err != nil {
+	var webhookOpts []webhooks.Option
+	if cfg.CapacityCheck != nil {
+		webhookOpts = append(webhookOpts, webhooks.WithCapacityCheck(cCache, cfg.CapacityCheck.Action))
+	}
+	if cfg.Resources != nil {
+		webhookOpts = append(webhookOpts, webhooks.WithResources(cfg.Resources))
+	}
+	if failedWebhook, err := webhooks.Setup(mgr, webhookOpts...); err != nil {
 		setupLog.Error(err, "Unable to create webhook", "webhook", failedWebhook)
 		os.Exit(1)
 	}
@@ -244,6 +328,9 @@ func setupControllers(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manag
 		jobframework.WithWaitForPodsReady(waitForPodsReady(cfg)),
 		jobframework.WithKubeServerVersion(serverVersionFetcher),
 	}
+	if cfg.CapacityCheck != nil && cfg.CapacityCheck.Action != configapi.CapacityCheckMarkInadmissible {
+		opts = append(opts, jobframework.WithCapacityCheck(cCache, cfg.CapacityCheck.Action == configapi.CapacityCheckReject))
+	}
 	err := jobframework.ForEachIntegration(func(name string, cb jobframework.IntegrationCallbacks) error {
 		log := setupLog.WithValues("jobFrameworkName", name)
 
@@ -314,12 +401,13 @@ func setupProbeEndpoints(mgr ctrl.Manager) {
 	}
 }
 
-func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager) {
+func setupScheduler(mgr ctrl.Manager, cCache *cache.Cache, queues *queue.Manager, cfg *configapi.Configuration) {
 	sched := scheduler.New(
 		queues,
 		cCache,
 		mgr.GetClient(),
 		mgr.GetEventRecorderFor(constants.AdmissionName),
+		scheduler.WithLocalQueueMetrics(cfg.Metrics.EnableLocalQueueMetrics),
 	)
 	if err := mgr.Add(sched); err != nil {
 		setupLog.Error(err, "Unable to add scheduler to manager")
@@ -357,6 +445,38 @@ func waitForPodsReady(cfg *configapi.Configuration) bool {
 	return cfg.WaitForPodsReady != nil && cfg.WaitForPodsReady.Enable
 }
 
+func queueOptions(cfg *configapi.Configuration, wlInfoOpts []workload.InfoOption) []queue.Option {
+	opts := []queue.Option{queue.WithWorkloadInfoOptions(wlInfoOpts...)}
+	if cfg.BatchAdmission != nil {
+		opts = append(opts, queue.WithMaxHeadsPerClusterQueue(int(*cfg.BatchAdmission.MaxHeadsPerClusterQueue)))
+	}
+	return opts
+}
+
+// workloadInfoOptions converts the resource exclusions, transformations and
+// device class mappings of the configuration into the options to compute the
+// requests of the workloads.
+func workloadInfoOptions(cfg *configapi.Configuration, templates *dra.Templates) []workload.InfoOption {
+	if cfg.Resources == nil {
+		return nil
+	}
+	transformations := make(map[corev1.ResourceName]workload.ResourceTransformation, len(cfg.Resources.Transformations))
+	for _, t := range cfg.Resources.Transformations {
+		transformations[t.Input] = workload.ResourceTransformation{
+			Outputs: t.Outputs,
+			Replace: ptr.Deref(t.Strategy, configapi.RetainResourceTransformation) == configapi.ReplaceResourceTransformation,
+		}
+	}
+	opts := []workload.InfoOption{
+		workload.WithExcludedResourcePrefixes(cfg.Resources.ExcludeResourcePrefixes),
+		workload.WithResourceTransformations(transformations),
+	}
+	if templates != nil {
+		opts = append(opts, workload.WithResourceClaimTemplateResolver(templates.Resolver(dra.ResourceNamesByClass(cfg.Resources.DeviceClassMappings))))
+	}
+	return opts
+}
+
 func apply(configFile string) (ctrl.Options, configapi.Configuration, error) {
 	options, cfg, err := config.Load(scheme, configFile)
 	if err != nil {
@@ -364,6 +484,23 @@ func apply(configFile string) (ctrl.Options, configapi.Configuration, error) {
 	}
 
 	if cfg.Integrations != nil {
+		if err := generic.Register(cfg.Integrations.GenericFrameworks); err != nil {
+			return options, cfg, err
+		}
+		for _, kindArg := range cfg.Integrations.ExternalFrameworks {
+			if err := jobframework.RegisterExternalJobType(kindArg); err != nil {
+				return options, cfg, err
+			}
+		}
+		if len(cfg.Integrations.GenericFrameworks) > 0 {
+			// The generic frameworks are read as unstructured objects, which
+			// the client doesn't serve from the cache by default.
+			if options.Client.Cache == nil {
+				options.Client.Cache = &client.CacheOptions{}
+			}
+			options.Client.Cache.Unstructured = true
+		}
+
 		var errorlist field.ErrorList
 		availableFrameworks := jobframework.GetIntegrationsList()
 		path := field.NewPath("integrations", "frameworks")
@@ -393,5 +530,10 @@ func isFrameworkEnabled(cfg *configapi.Configuration, name string) bool {
 			return true
 		}
 	}
+	for i := range cfg.Integrations.GenericFrameworks {
+		if generic.FrameworkName(&cfg.Integrations.GenericFrameworks[i]) == name {
+			return true
+		}
+	}
 	return false
 }
@@ -61,6 +61,29 @@ integrations:
 		t.Fatal(err)
 	}
 
+	conflictingGenericFrameworkConfig := filepath.Join(tmpDir, "conflictingGenericFramework.yaml")
+	if err := os.WriteFile(conflictingGenericFrameworkConfig, []byte(`
+apiVersion: config.kueue.x-k8s.io/v1beta1
+kind: Configuration
+integrations:
+  frameworks:
+  - batch/job
+  genericFrameworks:
+  - group: batch
+    version: v1
+    kind: Job
+    suspendPath: .spec.suspend
+    podSets:
+    - name: main
+      templatePath: .spec.template
+      replicasPath: .spec.parallelism
+    finishedConditionTypes:
+    - Complete
+    - Failed
+`), os.FileMode(0600)); err != nil {
+		t.Fatal(err)
+	}
+
 	enableDefaultInternalCertManagement := &config.InternalCertManagement{
 		Enable:             ptr.To(true),
 		WebhookServiceName: ptr.To(config.DefaultWebhookServiceName),
@@ -124,6 +147,11 @@ integrations:
 			configFile: badIntegrationsConfig,
 			wantError:  fmt.Errorf("integrations.frameworks: Unsupported value: \"unregistered/jobframework\": supported values: \"batch/job\", \"jobset.x-k8s.io/jobset\", \"kubeflow.org/mpijob\", \"kubeflow.org/mxjob\", \"kubeflow.org/paddlejob\", \"kubeflow.org/pytorchjob\", \"kubeflow.org/tfjob\", \"kubeflow.org/xgboostjob\", \"pod\", \"ray.io/rayjob\""),
 		},
+		{
+			name:       "generic framework conflicting with an integration",
+			configFile: conflictingGenericFrameworkConfig,
+			wantError:  fmt.Errorf("duplicate framework name \"batch/job\""),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -20,8 +20,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -29,7 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
-	ctrl "sigs.k8s.io/controller-runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -51,7 +53,8 @@ const (
 )
 
 type options struct {
-	podsReadyTracking bool
+	podsReadyTracking   bool
+	workloadInfoOptions []workload.InfoOption
 }
 
 // Option configures the reconciler.
@@ -60,26 +63,39 @@ type Option func(*options)
 // WithPodsReadyTracking indicates the cache controller tracks the PodsReady
 // condition for admitted workloads, and allows to block admission of new
 // workloads until all admitted workloads are in the PodsReady condition.
+// ClusterQueues can override it with their waitForPodsReady.
 func WithPodsReadyTracking(f bool) Option {
 	return func(o *options) {
 		o.podsReadyTracking = f
 	}
 }
 
+// WithWorkloadInfoOptions sets the options to compute the requests of the
+// workloads, such as the resource transformations and exclusions.
+func WithWorkloadInfoOptions(opts ...workload.InfoOption) Option {
+	return func(o *options) {
+		o.workloadInfoOptions = opts
+	}
+}
+
 var defaultOptions = options{}
 
 // Cache keeps track of the Workloads that got admitted through ClusterQueues.
 type Cache struct {
 	sync.RWMutex
-	podsReadyCond sync.Cond
 
-	client            client.Client
-	clusterQueues     map[string]*ClusterQueue
-	cohorts           map[string]*Cohort
-	assumedWorkloads  map[string]string
-	resourceFlavors   map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
-	podsReadyTracking bool
-	admissionChecks   map[string]AdmissionCheck
+	client              client.Client
+	clusterQueues       map[string]*ClusterQueue
+	cohorts             map[string]*Cohort
+	assumedWorkloads    map[string]string
+	resourceFlavors     map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor
+	podsReadyTracking   bool
+	admissionChecks     map[string]AdmissionCheck
+	reservations        map[string]*kueue.Reservation
+	workloadInfoOptions []workload.InfoOption
+	// snapshotMu guards the copies of the ClusterQueues shared by the
+	// snapshots, which are rebuilt while holding the read lock.
+	snapshotMu sync.Mutex
 }
 
 func New(client client.Client, opts ...Option) *Cache {
@@ -88,25 +104,27 @@ func New(client client.Client, opts ...Option) *Cache {
 		opt(&options)
 	}
 	c := &Cache{
-		client:            client,
-		clusterQueues:     make(map[string]*ClusterQueue),
-		cohorts:           make(map[string]*Cohort),
-		assumedWorkloads:  make(map[string]string),
-		resourceFlavors:   make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor),
-		admissionChecks:   make(map[string]AdmissionCheck),
-		podsReadyTracking: options.podsReadyTracking,
-	}
-	c.podsReadyCond.L = &c.RWMutex
+		client:              client,
+		clusterQueues:       make(map[string]*ClusterQueue),
+		cohorts:             make(map[string]*Cohort),
+		assumedWorkloads:    make(map[string]string),
+		resourceFlavors:     make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor),
+		admissionChecks:     make(map[string]AdmissionCheck),
+		reservations:        make(map[string]*kueue.Reservation),
+		podsReadyTracking:   options.podsReadyTracking,
+		workloadInfoOptions: options.workloadInfoOptions,
+	}
 	return c
 }
 
 func (c *Cache) newClusterQueue(cq *kueue.ClusterQueue) (*ClusterQueue, error) {
 	cqImpl := &ClusterQueue{
-		Name:              cq.Name,
-		Workloads:         make(map[string]*workload.Info),
-		WorkloadsNotReady: sets.New[string](),
-		localQueues:       make(map[string]*queue),
-		podsReadyTracking: c.podsReadyTracking,
+		Name:                cq.Name,
+		Workloads:           make(map[string]*workload.Info),
+		WorkloadsNotReady:   sets.New[string](),
+		localQueues:         make(map[string]*queue),
+		podsReadyTracking:   c.podsReadyTracking,
+		workloadInfoOptions: c.workloadInfoOptions,
 	}
 	if err := cqImpl.update(cq, c.resourceFlavors, c.admissionChecks); err != nil {
 		return nil, err
@@ -115,61 +133,95 @@ func (c *Cache) newClusterQueue(cq *kueue.ClusterQueue) (*ClusterQueue, error) {
 	return cqImpl, nil
 }
 
-// WaitForPodsReady waits for all admitted workloads to be in the PodsReady condition
-// if podsReadyTracking is enabled, otherwise returns immediately.
-func (c *Cache) WaitForPodsReady(ctx context.Context) {
-	if !c.podsReadyTracking {
-		return
+// PodsReadyForAllAdmittedWorkloads returns whether the workloads of the
+// ClusterQueue can be admitted, because it doesn't block admissions or all the
+// admitted workloads in its scope are in the PodsReady condition. See
+// PodsReadyScope for the scope of a ClusterQueue.
+func (c *Cache) PodsReadyForAllAdmittedWorkloads(log logr.Logger, cqName string) bool {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.clusterQueues[cqName]
+	if cq == nil || !cq.blocksForPodsReady() {
+		return true
 	}
-
-	c.Lock()
-	defer c.Unlock()
-
-	log := ctrl.LoggerFrom(ctx)
-	for {
-		if c.podsReadyForAllAdmittedWorkloads(log) {
-			return
-		}
-		log.V(3).Info("Blocking admission as not all workloads are in the PodsReady condition")
-		select {
-		case <-ctx.Done():
-			log.V(5).Info("Context cancelled when waiting for pods to be ready; returning")
-			return
-		default:
-			// wait releases the lock and acquires again when awaken
-			c.podsReadyCond.Wait()
+	for _, scopeCQ := range c.podsReadyScope(cq) {
+		if len(scopeCQ.WorkloadsNotReady) > 0 {
+			log.V(3).Info("There is a ClusterQueue with not ready workloads", "clusterQueue", klog.KRef("", scopeCQ.Name))
+			return false
 		}
 	}
+	log.V(5).Info("All workloads are in the PodsReady condition")
+	return true
 }
 
-func (c *Cache) PodsReadyForAllAdmittedWorkloads(log logr.Logger) bool {
-	if !c.podsReadyTracking {
-		return true
+// PodsReadyScope returns the names of the ClusterQueues whose admissions are
+// blocked by the workloads of the given ClusterQueue that aren't in the
+// PodsReady condition. A ClusterQueue overriding the waitForPodsReady
+// configuration only blocks itself, while all the ClusterQueues following the
+// configuration of Kueue block each other.
+// It's empty if the ClusterQueue doesn't block admissions.
+func (c *Cache) PodsReadyScope(cqName string) sets.Set[string] {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.clusterQueues[cqName]
+	if cq == nil || !cq.blocksForPodsReady() {
+		return nil
 	}
-	c.Lock()
-	defer c.Unlock()
-	return c.podsReadyForAllAdmittedWorkloads(log)
+	names := sets.New[string]()
+	for _, scopeCQ := range c.podsReadyScope(cq) {
+		names.Insert(scopeCQ.Name)
+	}
+	return names
 }
 
-func (c *Cache) podsReadyForAllAdmittedWorkloads(log logr.Logger) bool {
-	for _, cq := range c.clusterQueues {
-		if len(cq.WorkloadsNotReady) > 0 {
-			log.V(3).Info("There is a ClusterQueue with not ready workloads", "clusterQueue", klog.KRef("", cq.Name))
-			return false
+func (c *Cache) podsReadyScope(cq *ClusterQueue) []*ClusterQueue {
+	if cq.waitForPodsReady != nil {
+		return []*ClusterQueue{cq}
+	}
+	var scope []*ClusterQueue
+	for _, other := range c.clusterQueues {
+		if other.waitForPodsReady == nil {
+			scope = append(scope, other)
 		}
 	}
-	log.V(5).Info("All workloads are in the PodsReady condition")
-	return true
+	return scope
 }
 
-// CleanUpOnContext tracks the context. When closed, it wakes routines waiting
-// on the podsReady condition. It should be called before doing any calls to
-// cache.WaitForPodsReady.
-func (c *Cache) CleanUpOnContext(ctx context.Context) {
-	<-ctx.Done()
+// PodsReadyTimeout returns the PodsReady timeout set by the ClusterQueue, and
+// whether the ClusterQueue overrides the waitForPodsReady configuration. The
+// timeout is nil when the ClusterQueue doesn't wait for the pods to be ready.
+func (c *Cache) PodsReadyTimeout(cqName string) (*time.Duration, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.clusterQueues[cqName]
+	if cq == nil || cq.waitForPodsReady == nil {
+		return nil, false
+	}
+	if !cq.waitForPodsReady.Enable || cq.waitForPodsReady.Timeout == nil {
+		return nil, true
+	}
+	return ptr.To(cq.waitForPodsReady.Timeout.Duration), true
+}
+
+// UpdateQuotaSchedules sets the quota schedules that apply to the flavors of
+// the ClusterQueue, by flavor. It returns the names of the ClusterQueues whose
+// inadmissible workloads might fit with the new quotas, which is empty when
+// the quotas didn't change.
+func (c *Cache) UpdateQuotaSchedules(cq *kueue.ClusterQueue, schedules map[kueue.ResourceFlavorReference]string) sets.Set[string] {
 	c.Lock()
 	defer c.Unlock()
-	c.podsReadyCond.Broadcast()
+	cqImpl := c.clusterQueues[cq.Name]
+	if cqImpl == nil || maps.Equal(cqImpl.quotaSchedules, schedules) {
+		return nil
+	}
+	before := cqImpl.quotaLimits()
+	cqImpl.quotaSchedules = schedules
+	cqImpl.updateResourceGroups(cq.Spec.ResourceGroups)
+	cqImpl.UpdateWithFlavors(c.resourceFlavors)
+	cqImpl.recordQuotaShrink(before)
+	cqs := sets.New[string]()
+	c.appendClusterQueueAndCohort(cqs, cq.Name)
+	return cqs
 }
 
 func (c *Cache) updateClusterQueues() sets.Set[string] {
@@ -177,6 +229,7 @@ func (c *Cache) updateClusterQueues() sets.Set[string] {
 
 	for _, cq := range c.clusterQueues {
 		prevStatus := cq.Status
+		prevGeneration := cq.AllocatableResourceGeneration
 		// We call update on all ClusterQueues irrespective of which CQ actually use this flavor
 		// because it is not expensive to do so, and is not worth tracking which ClusterQueues use
 		// which flavors.
@@ -186,6 +239,10 @@ func (c *Cache) updateClusterQueues() sets.Set[string] {
 		if prevStatus == pending && curStatus == active {
 			cqs.Insert(cq.Name)
 		}
+		// The quotas computed from the capacity of the flavors might have changed.
+		if curStatus == active && cq.AllocatableResourceGeneration != prevGeneration {
+			cqs.Insert(cq.Name)
+		}
 	}
 	return cqs
 }
@@ -225,6 +282,29 @@ func (c *Cache) ClusterQueueActive(name string) bool {
 	return c.clusterQueueInStatus(name, active)
 }
 
+// PendingQuotaShrink returns whether the quota of the ClusterQueue was lowered
+// since the last call to QuotaShrinkHandled, and the number of shrinks to
+// pass to it.
+func (c *Cache) PendingQuotaShrink(name string) (int64, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	cq := c.clusterQueues[name]
+	if cq == nil {
+		return 0, false
+	}
+	return cq.quotaShrinks, cq.quotaShrinks > cq.handledQuotaShrinks
+}
+
+// QuotaShrinkHandled records that the shrinks of the quota of the ClusterQueue
+// returned by PendingQuotaShrink were handled.
+func (c *Cache) QuotaShrinkHandled(name string, shrinks int64) {
+	c.Lock()
+	defer c.Unlock()
+	if cq := c.clusterQueues[name]; cq != nil {
+		cq.handledQuotaShrinks = max(cq.handledQuotaShrinks, shrinks)
+	}
+}
+
 func (c *Cache) ClusterQueueTerminating(name string) bool {
 	return c.clusterQueueInStatus(name, terminating)
 }
@@ -259,6 +339,7 @@ func (c *Cache) TerminateClusterQueue(name string) {
 	defer c.Unlock()
 	if cq, exists := c.clusterQueues[name]; exists {
 		cq.Status = terminating
+		cq.version++
 		metrics.ReportClusterQueueStatus(cq.Name, cq.Status)
 	}
 }
@@ -333,9 +414,11 @@ func (c *Cache) UpdateClusterQueue(cq *kueue.ClusterQueue) error {
 	if !ok {
 		return errCqNotFound
 	}
+	before := cqImpl.quotaLimits()
 	if err := cqImpl.update(cq, c.resourceFlavors, c.admissionChecks); err != nil {
 		return err
 	}
+	cqImpl.recordQuotaShrink(before)
 	for _, qImpl := range cqImpl.localQueues {
 		if qImpl == nil {
 			return errQNotFound
@@ -428,9 +511,6 @@ func (c *Cache) addOrUpdateWorkload(w *kueue.Workload) bool {
 		clusterQueue.deleteWorkload(w)
 	}
 
-	if c.podsReadyTracking {
-		c.podsReadyCond.Broadcast()
-	}
 	return clusterQueue.addWorkload(w) == nil
 }
 
@@ -453,9 +533,6 @@ func (c *Cache) UpdateWorkload(oldWl, newWl *kueue.Workload) error {
 	if !ok {
 		return fmt.Errorf("new ClusterQueue doesn't exist")
 	}
-	if c.podsReadyTracking {
-		c.podsReadyCond.Broadcast()
-	}
 	return cq.addWorkload(newWl)
 }
 
@@ -471,9 +548,6 @@ func (c *Cache) DeleteWorkload(w *kueue.Workload) error {
 	c.cleanupAssumedState(w)
 
 	cq.deleteWorkload(w)
-	if c.podsReadyTracking {
-		c.podsReadyCond.Broadcast()
-	}
 	return nil
 }
 
@@ -493,6 +567,15 @@ func (c *Cache) IsAssumedOrAdmittedWorkload(w workload.Info) bool {
 	return false
 }
 
+// AssumedWorkloads returns the keys of the workloads that are assumed, but
+// not yet confirmed by the API server, with the ClusterQueue they are
+// assumed to.
+func (c *Cache) AssumedWorkloads() map[string]string {
+	c.RLock()
+	defer c.RUnlock()
+	return maps.Clone(c.assumedWorkloads)
+}
+
 func (c *Cache) AssumeWorkload(w *kueue.Workload) error {
 	c.Lock()
 	defer c.Unlock()
@@ -537,9 +620,6 @@ func (c *Cache) ForgetWorkload(w *kueue.Workload) error {
 		return errCqNotFound
 	}
 	cq.deleteWorkload(w)
-	if c.podsReadyTracking {
-		c.podsReadyCond.Broadcast()
-	}
 	return nil
 }
 
@@ -726,7 +806,7 @@ func (c *Cache) ClusterQueuesUsingAdmissionCheck(ac string) []string {
 	var cqs []string
 
 	for _, cq := range c.clusterQueues {
-		if cq.AdmissionChecks.Has(ac) {
+		if _, found := cq.AdmissionChecks[ac]; found {
 			cqs = append(cqs, cq.Name)
 		}
 	}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -867,7 +868,10 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
 					FlavorFungibility:             defaultFlavorFungibility,
-					AdmissionChecks:               sets.New("check1", "check2"),
+					AdmissionChecks: map[string]sets.Set[kueue.ResourceFlavorReference]{
+						"check1": sets.New[kueue.ResourceFlavorReference](),
+						"check2": sets.New[kueue.ResourceFlavorReference](),
+					},
 				},
 			},
 			wantCohorts: map[string]sets.Set[string]{},
@@ -895,7 +899,10 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
 					FlavorFungibility:             defaultFlavorFungibility,
-					AdmissionChecks:               sets.New("check1", "check2"),
+					AdmissionChecks: map[string]sets.Set[kueue.ResourceFlavorReference]{
+						"check1": sets.New[kueue.ResourceFlavorReference](),
+						"check2": sets.New[kueue.ResourceFlavorReference](),
+					},
 				},
 			},
 			wantCohorts: map[string]sets.Set[string]{},
@@ -924,7 +931,10 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
 					FlavorFungibility:             defaultFlavorFungibility,
-					AdmissionChecks:               sets.New("check1", "check2"),
+					AdmissionChecks: map[string]sets.Set[kueue.ResourceFlavorReference]{
+						"check1": sets.New[kueue.ResourceFlavorReference](),
+						"check2": sets.New[kueue.ResourceFlavorReference](),
+					},
 				},
 			},
 			wantCohorts: map[string]sets.Set[string]{},
@@ -953,7 +963,10 @@ func TestCacheClusterQueueOperations(t *testing.T) {
 					Preemption:                    defaultPreemption,
 					AllocatableResourceGeneration: 1,
 					FlavorFungibility:             defaultFlavorFungibility,
-					AdmissionChecks:               sets.New("check1", "check2"),
+					AdmissionChecks: map[string]sets.Set[kueue.ResourceFlavorReference]{
+						"check1": sets.New[kueue.ResourceFlavorReference](),
+						"check2": sets.New[kueue.ResourceFlavorReference](),
+					},
 				},
 			},
 			wantCohorts: map[string]sets.Set[string]{},
@@ -2618,38 +2631,6 @@ func TestMatchingClusterQueues(t *testing.T) {
 	}
 }
 
-// TestWaitForPodsReadyCancelled ensures that the WaitForPodsReady call does not block when the context is closed.
-func TestWaitForPodsReadyCancelled(t *testing.T) {
-	cache := New(utiltesting.NewFakeClient(), WithPodsReadyTracking(true))
-	ctx, cancel := context.WithCancel(context.Background())
-	log := ctrl.LoggerFrom(ctx)
-
-	go cache.CleanUpOnContext(ctx)
-
-	cq := kueue.ClusterQueue{
-		ObjectMeta: metav1.ObjectMeta{Name: "one"},
-	}
-	if err := cache.AddClusterQueue(ctx, &cq); err != nil {
-		t.Fatalf("Failed adding clusterQueue: %v", err)
-	}
-
-	wl := utiltesting.MakeWorkload("a", "").ReserveQuota(&kueue.Admission{
-		ClusterQueue: "one",
-	}).Obj()
-	if err := cache.AssumeWorkload(wl); err != nil {
-		t.Fatalf("Failed assuming the workload to block the further admission: %v", err)
-	}
-
-	if cache.PodsReadyForAllAdmittedWorkloads(log) {
-		t.Fatalf("Unexpected that all admitted workloads are in PodsReady condition")
-	}
-
-	// cancel the context so that the WaitForPodsReady is returns
-	go cancel()
-
-	cache.WaitForPodsReady(ctx)
-}
-
 // TestCachePodsReadyForAllAdmittedWorkloads verifies the condition used to determine whether to wait
 func TestCachePodsReadyForAllAdmittedWorkloads(t *testing.T) {
 	clusterQueues := []kueue.ClusterQueue{
@@ -2894,18 +2875,166 @@ func TestCachePodsReadyForAllAdmittedWorkloads(t *testing.T) {
 			if err := tc.operation(cache); err != nil {
 				t.Errorf("Unexpected error during operation: %q", err)
 			}
-			gotReady := cache.PodsReadyForAllAdmittedWorkloads(log)
+			gotReady := cache.PodsReadyForAllAdmittedWorkloads(log, "one")
 			if diff := cmp.Diff(tc.wantReady, gotReady); diff != "" {
 				t.Errorf("Unexpected response about workloads without pods ready (-want,+got):\n%s", diff)
 			}
-			// verify that the WaitForPodsReady is non-blocking when podsReadyForAllAdmittedWorkloads returns true
-			if gotReady {
-				cache.WaitForPodsReady(ctx)
+		})
+	}
+}
+
+// TestCachePodsReadyScope verifies that the ClusterQueues overriding the
+// waitForPodsReady configuration are only blocked by their own workloads.
+func TestCachePodsReadyScope(t *testing.T) {
+	notReady := func(name, cq string) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "").ReserveQuota(&kueue.Admission{
+			ClusterQueue: kueue.ClusterQueueReference(cq),
+		}).Obj()
+	}
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("global-a").Obj(),
+		utiltesting.MakeClusterQueue("global-b").Obj(),
+		utiltesting.MakeClusterQueue("own").WaitForPodsReady(true, time.Minute, true).Obj(),
+		utiltesting.MakeClusterQueue("own-nonblocking").WaitForPodsReady(true, time.Minute, false).Obj(),
+		utiltesting.MakeClusterQueue("disabled").WaitForPodsReady(false, time.Minute, true).Obj(),
+	}
+	allCQs := []string{"global-a", "global-b", "own", "own-nonblocking", "disabled"}
+
+	tests := map[string]struct {
+		podsReadyTracking bool
+		workloads         []*kueue.Workload
+		wantReady         map[string]bool
+		wantScope         map[string]sets.Set[string]
+	}{
+		"no workloads": {
+			podsReadyTracking: true,
+			wantReady:         map[string]bool{"global-a": true, "global-b": true, "own": true, "own-nonblocking": true, "disabled": true},
+			wantScope: map[string]sets.Set[string]{
+				"global-a": sets.New("global-a", "global-b"),
+				"global-b": sets.New("global-a", "global-b"),
+				"own":      sets.New("own"),
+			},
+		},
+		"not ready workload in a ClusterQueue following the configuration": {
+			podsReadyTracking: true,
+			workloads:         []*kueue.Workload{notReady("a", "global-a")},
+			wantReady:         map[string]bool{"global-a": false, "global-b": false, "own": true, "own-nonblocking": true, "disabled": true},
+			wantScope: map[string]sets.Set[string]{
+				"global-a": sets.New("global-a", "global-b"),
+				"global-b": sets.New("global-a", "global-b"),
+				"own":      sets.New("own"),
+			},
+		},
+		"not ready workloads in the ClusterQueues overriding the configuration": {
+			podsReadyTracking: true,
+			workloads: []*kueue.Workload{
+				notReady("a", "own"),
+				notReady("b", "own-nonblocking"),
+				notReady("c", "disabled"),
+			},
+			wantReady: map[string]bool{"global-a": true, "global-b": true, "own": false, "own-nonblocking": true, "disabled": true},
+			wantScope: map[string]sets.Set[string]{
+				"global-a": sets.New("global-a", "global-b"),
+				"global-b": sets.New("global-a", "global-b"),
+				"own":      sets.New("own"),
+			},
+		},
+		"blocking disabled in the configuration": {
+			workloads: []*kueue.Workload{notReady("a", "global-a"), notReady("b", "own")},
+			wantReady: map[string]bool{"global-a": true, "global-b": true, "own": false, "own-nonblocking": true, "disabled": true},
+			wantScope: map[string]sets.Set[string]{
+				"own": sets.New("own"),
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient(), WithPodsReadyTracking(tc.podsReadyTracking))
+			ctx := context.Background()
+			log := ctrl.LoggerFrom(ctx)
+			for _, cq := range clusterQueues {
+				if err := cache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Failed adding clusterQueue: %v", err)
+				}
+			}
+			for _, wl := range tc.workloads {
+				cache.AddOrUpdateWorkload(wl)
+			}
+			gotReady := make(map[string]bool, len(allCQs))
+			gotScope := make(map[string]sets.Set[string], len(allCQs))
+			for _, cqName := range allCQs {
+				gotReady[cqName] = cache.PodsReadyForAllAdmittedWorkloads(log, cqName)
+				if scope := cache.PodsReadyScope(cqName); len(scope) > 0 {
+					gotScope[cqName] = scope
+				}
+			}
+			if diff := cmp.Diff(tc.wantReady, gotReady); diff != "" {
+				t.Errorf("Unexpected PodsReadyForAllAdmittedWorkloads (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantScope, gotScope); diff != "" {
+				t.Errorf("Unexpected PodsReadyScope (-want,+got):\n%s", diff)
 			}
 		})
 	}
 }
 
+func TestCachePodsReadyTimeout(t *testing.T) {
+	ctx := context.Background()
+	cache := New(utiltesting.NewFakeClient(), WithPodsReadyTracking(true))
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("global").Obj(),
+		utiltesting.MakeClusterQueue("own").WaitForPodsReady(true, time.Minute, true).Obj(),
+		utiltesting.MakeClusterQueue("disabled").WaitForPodsReady(false, time.Minute, true).Obj(),
+	}
+	for _, cq := range clusterQueues {
+		if err := cache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding clusterQueue: %v", err)
+		}
+	}
+	type result struct {
+		Timeout    *time.Duration
+		Overridden bool
+	}
+	want := map[string]result{
+		"global":   {},
+		"own":      {Timeout: ptr.To(time.Minute), Overridden: true},
+		"disabled": {Overridden: true},
+		"missing":  {},
+	}
+	got := make(map[string]result, len(want))
+	for cqName := range want {
+		timeout, overridden := cache.PodsReadyTimeout(cqName)
+		got[cqName] = result{Timeout: timeout, Overridden: overridden}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected PodsReadyTimeout (-want,+got):\n%s", diff)
+	}
+}
+
+// TestCachePodsReadyUpdateClusterQueue verifies that the workloads not in the
+// PodsReady condition are tracked again when the ClusterQueue starts blocking
+// admissions.
+func TestCachePodsReadyUpdateClusterQueue(t *testing.T) {
+	ctx := context.Background()
+	log := ctrl.LoggerFrom(ctx)
+	cache := New(utiltesting.NewFakeClient())
+	cq := utiltesting.MakeClusterQueue("cq").Obj()
+	if err := cache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed adding clusterQueue: %v", err)
+	}
+	cache.AddOrUpdateWorkload(utiltesting.MakeWorkload("a", "").ReserveQuota(&kueue.Admission{ClusterQueue: "cq"}).Obj())
+	if !cache.PodsReadyForAllAdmittedWorkloads(log, "cq") {
+		t.Errorf("Unexpected blocked admission before enabling waitForPodsReady")
+	}
+	cq = utiltesting.MakeClusterQueue("cq").WaitForPodsReady(true, time.Minute, true).Obj()
+	if err := cache.UpdateClusterQueue(cq); err != nil {
+		t.Fatalf("Failed updating clusterQueue: %v", err)
+	}
+	if cache.PodsReadyForAllAdmittedWorkloads(log, "cq") {
+		t.Errorf("Unexpected admission not blocked after enabling waitForPodsReady")
+	}
+}
+
 // TestIsAssumedOrAdmittedCheckWorkload verifies if workload is in Assumed map from cache or if it is Admitted in one ClusterQueue
 func TestIsAssumedOrAdmittedCheckWorkload(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,178 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// MaxCapacityViolations returns the reasons why the workload can never fit
+// in the ClusterQueue of its LocalQueue, not even borrowing all the unused
+// quota of the cohort. The requests of each podSet are compared with the
+// maximum quota of each flavor, and the total requests of the workload with
+// the sum of the maximum quotas of the flavors.
+// The podSets that can be partially admitted are checked with their minCount.
+// It returns nothing when the workload might fit, or when its LocalQueue or
+// ClusterQueue are unknown, as other conditions report them.
+func (c *Cache) MaxCapacityViolations(w *kueue.Workload) []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.clusterQueueForLocalQueue(workload.QueueKey(w))
+	if cq == nil {
+		return nil
+	}
+	return cq.maxCapacityViolations(w)
+}
+
+func (c *Cache) clusterQueueForLocalQueue(qKey string) *ClusterQueue {
+	for _, cq := range c.clusterQueues {
+		if _, ok := cq.localQueues[qKey]; ok {
+			return cq
+		}
+	}
+	return nil
+}
+
+func (c *ClusterQueue) maxCapacityViolations(w *kueue.Workload) []string {
+	var reasons []string
+	totals := make(workload.Requests)
+	for i, psr := range workload.NewInfo(w, c.workloadInfoOptions...).TotalRequests {
+		if minCount := w.Spec.PodSets[i].MinCount; minCount != nil && *minCount < psr.Count {
+			psr = *psr.ScaledTo(*minCount)
+		}
+		c.AddCountedResources(i, &psr)
+		requestsByRG := make(map[*ResourceGroup]workload.Requests)
+		for _, rName := range sortedResources(psr.Requests) {
+			v := psr.Requests[rName]
+			if v == 0 {
+				continue
+			}
+			totals[rName] += v
+			rg := c.RGByResource[rName]
+			if rg == nil {
+				reasons = append(reasons, fmt.Sprintf("resource %s of podSet %s is not covered by any resource group of ClusterQueue %s", rName, psr.Name, c.Name))
+				continue
+			}
+			if requestsByRG[rg] == nil {
+				requestsByRG[rg] = make(workload.Requests)
+			}
+			requestsByRG[rg][rName] = v
+		}
+		for j := range c.ResourceGroups {
+			rg := &c.ResourceGroups[j]
+			if requests, ok := requestsByRG[rg]; ok && !c.anyFlavorFits(rg, requests) {
+				reasons = append(reasons, fmt.Sprintf("podSet %s requests %s, which exceed the maximum quota of every flavor in ClusterQueue %s", psr.Name, formatRequests(requests), c.Name))
+			}
+		}
+	}
+	if len(w.Spec.PodSets) > 1 {
+		for _, rName := range sortedResources(totals) {
+			rg := c.RGByResource[rName]
+			if rg == nil {
+				continue
+			}
+			var capacity int64
+			for _, fq := range rg.Flavors {
+				capacity += c.maxQuota(fq.Name, rName)
+			}
+			if totals[rName] > capacity {
+				reasons = append(reasons, fmt.Sprintf("the workload requests %s, which exceed the maximum quota of ClusterQueue %s", formatRequests(workload.Requests{rName: totals[rName]}), c.Name))
+			}
+		}
+	}
+	return reasons
+}
+
+func (c *ClusterQueue) anyFlavorFits(rg *ResourceGroup, requests workload.Requests) bool {
+	for _, fq := range rg.Flavors {
+		fits := true
+		for rName, v := range requests {
+			if v > c.maxQuota(fq.Name, rName) {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			return true
+		}
+	}
+	return false
+}
+
+// maxQuota returns the most of the resource that the ClusterQueue can use from
+// the flavor: its nominal quota plus the nominal quota of the other members of
+// the cohort, up to the borrowing limit.
+func (c *ClusterQueue) maxQuota(fName kueue.ResourceFlavorReference, rName corev1.ResourceName) int64 {
+	quota := c.quotaFor(fName, rName)
+	if quota == nil {
+		return 0
+	}
+	if c.Cohort == nil {
+		return quota.Nominal
+	}
+	var cohortNominal int64
+	for member := range c.Cohort.Members {
+		if q := member.quotaFor(fName, rName); q != nil {
+			cohortNominal += q.Nominal
+		}
+	}
+	if quota.BorrowingLimit != nil {
+		return min(cohortNominal, quota.Nominal+*quota.BorrowingLimit)
+	}
+	return cohortNominal
+}
+
+func (c *ClusterQueue) quotaFor(fName kueue.ResourceFlavorReference, rName corev1.ResourceName) *ResourceQuota {
+	rg := c.RGByResource[rName]
+	if rg == nil {
+		return nil
+	}
+	for _, fq := range rg.Flavors {
+		if fq.Name == fName {
+			return fq.Resources[rName]
+		}
+	}
+	return nil
+}
+
+func sortedResources(r workload.Requests) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(r))
+	for rName := range r {
+		names = append(names, rName)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+func formatRequests(r workload.Requests) string {
+	var s string
+	for i, rName := range sortedResources(r) {
+		if i > 0 {
+			s += ", "
+		}
+		q := workload.ResourceQuantity(rName, r[rName])
+		s += fmt.Sprintf("%s=%s", rName, q.String())
+	}
+	return s
+}
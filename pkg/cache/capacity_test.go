@@ -0,0 +1,205 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestMaxCapacityViolations(t *testing.T) {
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("standalone").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "10").Obj(),
+				*utiltesting.MakeFlavorQuotas("spot").Resource(corev1.ResourceCPU, "6").Obj(),
+			).
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("gpu").Resource("example.com/gpu", "4").Obj(),
+			).
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourcePods, "10").Obj(),
+			).
+			Obj(),
+		utiltesting.MakeClusterQueue("borrower").
+			Cohort("cohort").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4").Obj(),
+			).
+			Obj(),
+		utiltesting.MakeClusterQueue("limited").
+			Cohort("cohort").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "4", "2").Obj(),
+			).
+			Obj(),
+		utiltesting.MakeClusterQueue("lender").
+			Cohort("cohort").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("on-demand").Resource(corev1.ResourceCPU, "8").Obj(),
+			).
+			Obj(),
+		utiltesting.MakeClusterQueue("no-workloads").
+			ResourceGroup(
+				*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Resource(kueue.ResourceWorkloads, "0").
+					Obj(),
+			).
+			Obj(),
+	}
+	localQueues := []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("standalone", "ns").ClusterQueue("standalone").Obj(),
+		utiltesting.MakeLocalQueue("borrower", "ns").ClusterQueue("borrower").Obj(),
+		utiltesting.MakeLocalQueue("limited", "ns").ClusterQueue("limited").Obj(),
+		utiltesting.MakeLocalQueue("no-workloads", "ns").ClusterQueue("no-workloads").Obj(),
+	}
+	cases := map[string]struct {
+		wl        *kueue.Workload
+		cacheOpts []Option
+		want      []string
+	}{
+		"fits in a flavor": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("standalone").Request(corev1.ResourceCPU, "8").Obj(),
+		},
+		"unknown local queue": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("unknown").Request(corev1.ResourceCPU, "100").Obj(),
+		},
+		"exceeds every flavor": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("standalone").Request(corev1.ResourceCPU, "12").Obj(),
+			want: []string{
+				"podSet main requests cpu=12, which exceed the maximum quota of every flavor in ClusterQueue standalone",
+			},
+		},
+		"resource not covered": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("standalone").
+				Request(corev1.ResourceCPU, "1").
+				Request(corev1.ResourceMemory, "1Gi").
+				Obj(),
+			want: []string{
+				"resource memory of podSet main is not covered by any resource group of ClusterQueue standalone",
+			},
+		},
+		"podSets fit separately but not together": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("standalone").
+				PodSets(
+					*utiltesting.MakePodSet("driver", 1).Request(corev1.ResourceCPU, "8").Obj(),
+					*utiltesting.MakePodSet("workers", 2).Request(corev1.ResourceCPU, "5").Obj(),
+				).
+				Obj(),
+			want: []string{
+				"the workload requests cpu=18, which exceed the maximum quota of ClusterQueue standalone",
+			},
+		},
+		"exceeds the pods quota": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("standalone").
+				PodSets(*utiltesting.MakePodSet("main", 12).Request(corev1.ResourceCPU, "500m").Obj()).
+				Obj(),
+			want: []string{
+				"podSet main requests pods=12, which exceed the maximum quota of every flavor in ClusterQueue standalone",
+			},
+		},
+		"checked with the minimum count": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("standalone").
+				PodSets(
+					*utiltesting.MakePodSet("main", 8).
+						SetMinimumCount(2).
+						Request("example.com/gpu", "2").
+						Obj(),
+				).
+				Obj(),
+		},
+		"fits borrowing from the cohort": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("borrower").Request(corev1.ResourceCPU, "16").Obj(),
+		},
+		"exceeds the cohort": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("borrower").Request(corev1.ResourceCPU, "17").Obj(),
+			want: []string{
+				"podSet main requests cpu=17, which exceed the maximum quota of every flavor in ClusterQueue borrower",
+			},
+		},
+		"exceeds the borrowing limit": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("limited").Request(corev1.ResourceCPU, "7").Obj(),
+			want: []string{
+				"podSet main requests cpu=7, which exceed the maximum quota of every flavor in ClusterQueue limited",
+			},
+		},
+		"exceeds the workloads quota": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("no-workloads").
+				PodSets(
+					*utiltesting.MakePodSet("driver", 1).Request(corev1.ResourceCPU, "1").Obj(),
+					*utiltesting.MakePodSet("workers", 2).Request(corev1.ResourceCPU, "1").Obj(),
+				).
+				Obj(),
+			want: []string{
+				"podSet driver requests cpu=1, kueue.x-k8s.io/workloads=1, which exceed the maximum quota of every flavor in ClusterQueue no-workloads",
+				"the workload requests kueue.x-k8s.io/workloads=1, which exceed the maximum quota of ClusterQueue no-workloads",
+			},
+		},
+		"excluded resource not covered": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("standalone").
+				Request(corev1.ResourceCPU, "1").
+				Request("networking.example.com/vpc", "1").
+				Obj(),
+			cacheOpts: []Option{
+				WithWorkloadInfoOptions(workload.WithExcludedResourcePrefixes([]string{"networking.example.com/"})),
+			},
+		},
+		"exceeds the quota after the resource transformation": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("standalone").
+				Request("example.com/mig", "3").
+				Obj(),
+			cacheOpts: []Option{
+				WithWorkloadInfoOptions(workload.WithResourceTransformations(map[corev1.ResourceName]workload.ResourceTransformation{
+					"example.com/mig": {
+						Outputs: corev1.ResourceList{"example.com/gpu": resource.MustParse("2")},
+						Replace: true,
+					},
+				})),
+			},
+			want: []string{
+				"podSet main requests example.com/gpu=6, which exceed the maximum quota of every flavor in ClusterQueue standalone",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient(), tc.cacheOpts...)
+			for _, cq := range clusterQueues {
+				if err := cache.AddClusterQueue(context.Background(), cq); err != nil {
+					t.Fatalf("Adding ClusterQueue %s: %v", cq.Name, err)
+				}
+			}
+			for _, lq := range localQueues {
+				if err := cache.AddLocalQueue(lq); err != nil {
+					t.Fatalf("Adding LocalQueue %s: %v", lq.Name, err)
+				}
+			}
+			if diff := cmp.Diff(tc.want, cache.MaxCapacityViolations(tc.wl)); diff != "" {
+				t.Errorf("Unexpected violations (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
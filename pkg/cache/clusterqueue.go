@@ -13,6 +13,7 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -34,20 +35,48 @@ type ClusterQueue struct {
 	NamespaceSelector labels.Selector
 	Preemption        kueue.ClusterQueuePreemption
 	FlavorFungibility kueue.FlavorFungibility
-	AdmissionChecks   sets.Set[string]
-	Status            metrics.ClusterQueueStatus
+	// AdmissionChecks are the checks required by the ClusterQueue, with the
+	// flavors that they apply to. An empty set means all the flavors.
+	AdmissionChecks map[string]sets.Set[kueue.ResourceFlavorReference]
+	Status          metrics.ClusterQueueStatus
 	// AllocatableResourceGeneration will be increased when some admitted workloads are
 	// deleted, or the resource groups are changed.
 	AllocatableResourceGeneration int64
+	// Reservations is the quota held for the Reservations of the ClusterQueue
+	// that are holding or active. It is only populated in a snapshot.
+	Reservations map[string]*ReservedQuota
 
 	// The following fields are not populated in a snapshot.
 
 	// Key is localQueue's key (namespace/name).
-	localQueues                         map[string]*queue
+	localQueues map[string]*queue
+	// podsReadyTracking is the blocking of admissions configured in Kueue,
+	// waitForPodsReady overrides it for the ClusterQueue.
 	podsReadyTracking                   bool
+	waitForPodsReady                    *kueue.ClusterQueueWaitForPodsReady
 	hasMissingFlavors                   bool
 	hasMissingOrInactiveAdmissionChecks bool
 	admittedWorkloadsCount              int
+	// quotaSchedules are the names of the quota schedules that apply, by
+	// flavor.
+	quotaSchedules map[kueue.ResourceFlavorReference]string
+	// quotaShrinks is increased every time a quota or a borrowing limit is
+	// lowered, and handledQuotaShrinks is the value up to which the shrinks
+	// were handled by the ClusterQueue controller.
+	quotaShrinks        int64
+	handledQuotaShrinks int64
+	// workloadInfoOptions are the options to compute the requests of the
+	// workloads.
+	workloadInfoOptions []workload.InfoOption
+	// version is increased on every change that is visible in a snapshot.
+	version int64
+	// snapshotBase is the copy of the ClusterQueue that the snapshots share
+	// while its version is current.
+	snapshotBase *ClusterQueue
+
+	// sharedState is only set in a snapshot, when Usage and Workloads are
+	// shared with the snapshotBase of the ClusterQueue in the cache.
+	sharedState bool
 }
 
 // Cohort is a set of ClusterQueues that can borrow resources from each other.
@@ -81,6 +110,9 @@ type FlavorQuotas struct {
 type ResourceQuota struct {
 	Nominal        int64
 	BorrowingLimit *int64
+	// NominalPercentage, if not nil, is the percentage of the capacity of the
+	// flavor that Nominal is computed from.
+	NominalPercentage *int32
 }
 
 type FlavorResourceQuantities map[kueue.ResourceFlavorReference]map[corev1.ResourceName]int64
@@ -149,6 +181,7 @@ var defaultPreemption = kueue.ClusterQueuePreemption{
 var defaultFlavorFungibility = kueue.FlavorFungibility{WhenCanBorrow: kueue.Borrow, WhenCanPreempt: kueue.TryNextFlavor}
 
 func (c *ClusterQueue) update(in *kueue.ClusterQueue, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, admissionChecks map[string]AdmissionCheck) error {
+	c.version++
 	c.updateResourceGroups(in.Spec.ResourceGroups)
 	nsSelector, err := metav1.LabelSelectorAsSelector(in.Spec.NamespaceSelector)
 	if err != nil {
@@ -156,7 +189,7 @@ func (c *ClusterQueue) update(in *kueue.ClusterQueue, resourceFlavors map[kueue.
 	}
 	c.NamespaceSelector = nsSelector
 
-	c.AdmissionChecks = sets.New(in.Spec.AdmissionChecks...)
+	c.AdmissionChecks = admissioncheck.FlavorsByCheck(in)
 
 	c.Usage = filterQuantities(c.Usage, in.Spec.ResourceGroups)
 	c.AdmittedUsage = filterQuantities(c.AdmittedUsage, in.Spec.ResourceGroups)
@@ -181,9 +214,35 @@ func (c *ClusterQueue) update(in *kueue.ClusterQueue, resourceFlavors map[kueue.
 		c.FlavorFungibility = defaultFlavorFungibility
 	}
 
+	c.waitForPodsReady = in.Spec.WaitForPodsReady.DeepCopy()
+	c.updateWorkloadsNotReady()
+
 	return nil
 }
 
+// blocksForPodsReady returns whether the ClusterQueue blocks admissions until
+// its admitted workloads are in the PodsReady condition.
+func (c *ClusterQueue) blocksForPodsReady() bool {
+	if c.waitForPodsReady != nil {
+		return c.waitForPodsReady.Enable && ptr.Deref(c.waitForPodsReady.BlockAdmission, true)
+	}
+	return c.podsReadyTracking
+}
+
+// updateWorkloadsNotReady recomputes the workloads not in the PodsReady
+// condition, as the blocking of admissions can change with the ClusterQueue.
+func (c *ClusterQueue) updateWorkloadsNotReady() {
+	c.WorkloadsNotReady = sets.New[string]()
+	if !c.blocksForPodsReady() {
+		return
+	}
+	for k, wi := range c.Workloads {
+		if !apimeta.IsStatusConditionTrue(wi.Obj.Status.Conditions, kueue.WorkloadPodsReady) {
+			c.WorkloadsNotReady.Insert(k)
+		}
+	}
+}
+
 func filterQuantities(orig FlavorResourceQuantities, resourceGroups []kueue.ResourceGroup) FlavorResourceQuantities {
 	ret := make(FlavorResourceQuantities)
 	for _, rg := range resourceGroups {
@@ -199,6 +258,44 @@ func filterQuantities(orig FlavorResourceQuantities, resourceGroups []kueue.Reso
 	return ret
 }
 
+// quotaLimits returns the nominal quota and the borrowing limit of each
+// resource of each flavor.
+func (c *ClusterQueue) quotaLimits() map[kueue.ResourceFlavorReference]map[corev1.ResourceName]ResourceQuota {
+	limits := make(map[kueue.ResourceFlavorReference]map[corev1.ResourceName]ResourceQuota)
+	for _, rg := range c.ResourceGroups {
+		for _, fQuotas := range rg.Flavors {
+			resources := make(map[corev1.ResourceName]ResourceQuota, len(fQuotas.Resources))
+			for rName, rQuota := range fQuotas.Resources {
+				resources[rName] = *rQuota
+			}
+			limits[fQuotas.Name] = resources
+		}
+	}
+	return limits
+}
+
+// recordQuotaShrink increases quotaShrinks if a quota or a borrowing limit is
+// lower than in the given limits, or was removed.
+func (c *ClusterQueue) recordQuotaShrink(before map[kueue.ResourceFlavorReference]map[corev1.ResourceName]ResourceQuota) {
+	after := c.quotaLimits()
+	for fName, resources := range before {
+		for rName, old := range resources {
+			cur, found := after[fName][rName]
+			if !found || cur.Nominal < old.Nominal || borrowingLimitLowered(old.BorrowingLimit, cur.BorrowingLimit) {
+				c.quotaShrinks++
+				return
+			}
+		}
+	}
+}
+
+func borrowingLimitLowered(old, cur *int64) bool {
+	if cur == nil {
+		return false
+	}
+	return old == nil || *cur < *old
+}
+
 func (c *ClusterQueue) updateResourceGroups(in []kueue.ResourceGroup) {
 	c.ResourceGroups = make([]ResourceGroup, len(in))
 	for i, rgIn := range in {
@@ -213,6 +310,7 @@ func (c *ClusterQueue) updateResourceGroups(in []kueue.ResourceGroup) {
 				Name:      fIn.Name,
 				Resources: make(map[corev1.ResourceName]*ResourceQuota, len(fIn.Resources)),
 			}
+			scheduled := c.scheduledQuotas(fIn)
 			for _, rIn := range fIn.Resources {
 				rQuota := ResourceQuota{
 					Nominal: workload.ResourceValue(rIn.Name, rIn.NominalQuota),
@@ -220,15 +318,47 @@ func (c *ClusterQueue) updateResourceGroups(in []kueue.ResourceGroup) {
 				if rIn.BorrowingLimit != nil {
 					rQuota.BorrowingLimit = ptr.To(workload.ResourceValue(rIn.Name, *rIn.BorrowingLimit))
 				}
+				if rIn.NominalQuotaPercentage != nil {
+					rQuota.NominalPercentage = ptr.To(*rIn.NominalQuotaPercentage)
+				}
+				if sIn, found := scheduled[rIn.Name]; found {
+					rQuota.Nominal = workload.ResourceValue(rIn.Name, sIn.NominalQuota)
+					rQuota.NominalPercentage = nil
+					if sIn.BorrowingLimit != nil {
+						rQuota.BorrowingLimit = ptr.To(workload.ResourceValue(rIn.Name, *sIn.BorrowingLimit))
+					}
+				}
 				fQuotas.Resources[rIn.Name] = &rQuota
 			}
 			rg.Flavors = append(rg.Flavors, fQuotas)
 		}
 	}
 	c.AllocatableResourceGeneration++
+	c.version++
 	c.UpdateRGByResource()
 }
 
+// scheduledQuotas returns the quotas of the schedule that applies to the
+// flavor, by resource.
+func (c *ClusterQueue) scheduledQuotas(fIn *kueue.FlavorQuotas) map[corev1.ResourceName]*kueue.ScheduledResourceQuota {
+	name, found := c.quotaSchedules[fIn.Name]
+	if !found {
+		return nil
+	}
+	for i := range fIn.Schedules {
+		schedule := &fIn.Schedules[i]
+		if schedule.Name != name {
+			continue
+		}
+		quotas := make(map[corev1.ResourceName]*kueue.ScheduledResourceQuota, len(schedule.Resources))
+		for j := range schedule.Resources {
+			quotas[schedule.Resources[j].Name] = &schedule.Resources[j]
+		}
+		return quotas
+	}
+	return nil
+}
+
 func (c *ClusterQueue) UpdateRGByResource() {
 	c.RGByResource = make(map[corev1.ResourceName]*ResourceGroup)
 	for i := range c.ResourceGroups {
@@ -249,6 +379,7 @@ func (c *ClusterQueue) updateQueueStatus() {
 	}
 	if status != c.Status {
 		c.Status = status
+		c.version++
 		metrics.ReportClusterQueueStatus(c.Name, c.Status)
 	}
 }
@@ -275,9 +406,62 @@ func (c *ClusterQueue) inactiveReason() (string, string) {
 // Exported only for testing.
 func (c *ClusterQueue) UpdateWithFlavors(flavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor) {
 	c.hasMissingFlavors = c.updateLabelKeys(flavors)
+	c.updateNominalFromCapacity(flavors)
 	c.updateQueueStatus()
 }
 
+// updateNominalFromCapacity sets the nominal quotas that are a percentage of
+// the capacity reported in the status of the flavors.
+func (c *ClusterQueue) updateNominalFromCapacity(flavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor) {
+	changed := make(map[*ResourceQuota]int64)
+	for _, rg := range c.ResourceGroups {
+		for _, fQuotas := range rg.Flavors {
+			var capacity corev1.ResourceList
+			if flv, exist := flavors[fQuotas.Name]; exist {
+				capacity = flv.Status.Capacity
+			}
+			for rName, rQuota := range fQuotas.Resources {
+				if rQuota.NominalPercentage == nil {
+					continue
+				}
+				nominal := workload.ResourceValue(rName, capacity[rName]) * int64(*rQuota.NominalPercentage) / 100
+				if nominal != rQuota.Nominal {
+					changed[rQuota] = nominal
+				}
+			}
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	// The resource groups are shared with the snapshots, so they are copied
+	// instead of updated in place.
+	resourceGroups := make([]ResourceGroup, len(c.ResourceGroups))
+	for i, rg := range c.ResourceGroups {
+		resourceGroups[i] = rg
+		resourceGroups[i].Flavors = make([]FlavorQuotas, len(rg.Flavors))
+		for j, fQuotas := range rg.Flavors {
+			resources := make(map[corev1.ResourceName]*ResourceQuota, len(fQuotas.Resources))
+			for rName, rQuota := range fQuotas.Resources {
+				if nominal, found := changed[rQuota]; found {
+					rQuota = &ResourceQuota{
+						Nominal:           nominal,
+						BorrowingLimit:    rQuota.BorrowingLimit,
+						NominalPercentage: rQuota.NominalPercentage,
+					}
+				}
+				resources[rName] = rQuota
+			}
+			resourceGroups[i].Flavors[j] = FlavorQuotas{Name: fQuotas.Name, Resources: resources}
+		}
+	}
+	c.ResourceGroups = resourceGroups
+	c.UpdateRGByResource()
+	c.AllocatableResourceGeneration++
+	c.version++
+}
+
 func (c *ClusterQueue) updateLabelKeys(flavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor) bool {
 	var flavorNotFound bool
 	for i := range c.ResourceGroups {
@@ -321,15 +505,28 @@ func (c *ClusterQueue) updateWithAdmissionChecks(checks map[string]AdmissionChec
 	}
 }
 
+// AddCountedResources adds to the requests of a PodSet the resources that
+// Kueue counts instead of reading them from the pods, if the ClusterQueue has
+// quota for them: the number of pods and, for the first PodSet, the workload.
+func (c *ClusterQueue) AddCountedResources(podSetIdx int, psr *workload.PodSetResources) {
+	if _, found := c.RGByResource[corev1.ResourcePods]; found {
+		psr.Requests[corev1.ResourcePods] = int64(psr.Count)
+	}
+	if _, found := c.RGByResource[kueue.ResourceWorkloads]; found && podSetIdx == 0 {
+		psr.Requests[kueue.ResourceWorkloads] = 1
+	}
+}
+
 func (c *ClusterQueue) addWorkload(w *kueue.Workload) error {
 	k := workload.Key(w)
 	if _, exist := c.Workloads[k]; exist {
 		return fmt.Errorf("workload already exists in ClusterQueue")
 	}
-	wi := workload.NewInfo(w)
+	wi := workload.NewInfo(w, c.workloadInfoOptions...)
 	c.Workloads[k] = wi
 	c.updateWorkloadUsage(wi, 1)
-	if c.podsReadyTracking && !apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadPodsReady) {
+	c.version++
+	if c.blocksForPodsReady() && !apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadPodsReady) {
 		c.WorkloadsNotReady.Insert(k)
 	}
 	c.reportActiveWorkloads()
@@ -343,12 +540,11 @@ func (c *ClusterQueue) deleteWorkload(w *kueue.Workload) {
 		return
 	}
 	c.updateWorkloadUsage(wi, -1)
-	if c.podsReadyTracking && !apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadPodsReady) {
-		c.WorkloadsNotReady.Delete(k)
-	}
+	c.WorkloadsNotReady.Delete(k)
 	// we only increase the AllocatableResourceGeneration cause the add of workload won't make more
 	// workloads fit in ClusterQueue.
 	c.AllocatableResourceGeneration++
+	c.version++
 
 	delete(c.Workloads, k)
 	c.reportActiveWorkloads()
@@ -1,9 +1,13 @@
 package cache
 
 import (
+	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/metrics"
@@ -69,6 +73,161 @@ func TestClusterQueueUpdateWithFlavors(t *testing.T) {
 	}
 }
 
+func TestClusterQueueNominalFromCapacity(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("x86").
+			ResourcePercentage(corev1.ResourceCPU, 50).
+			Resource(corev1.ResourceMemory, "10Gi").
+			Obj()).
+		Obj()
+
+	testcases := map[string]struct {
+		flavor         *kueue.ResourceFlavor
+		wantNominal    map[corev1.ResourceName]int64
+		wantGeneration int64
+	}{
+		"flavor without capacity": {
+			flavor: utiltesting.MakeResourceFlavor("x86").Obj(),
+			wantNominal: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    0,
+				corev1.ResourceMemory: 10 * 1024 * 1024 * 1024,
+			},
+			wantGeneration: 1,
+		},
+		"flavor with capacity": {
+			flavor: utiltesting.MakeResourceFlavor("x86").
+				Capacity(corev1.ResourceCPU, "9").
+				Capacity(corev1.ResourceMemory, "64Gi").
+				Obj(),
+			wantNominal: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    4500,
+				corev1.ResourceMemory: 10 * 1024 * 1024 * 1024,
+			},
+			wantGeneration: 2,
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			cache := New(utiltesting.NewFakeClient())
+			cqImpl, err := cache.newClusterQueue(cq)
+			if err != nil {
+				t.Fatalf("failed to new clusterQueue %v", err)
+			}
+			snapshotRGs := cqImpl.ResourceGroups
+
+			cqImpl.UpdateWithFlavors(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{"x86": tc.flavor})
+
+			gotNominal := make(map[corev1.ResourceName]int64)
+			for rName, rQuota := range cqImpl.ResourceGroups[0].Flavors[0].Resources {
+				gotNominal[rName] = rQuota.Nominal
+			}
+			if diff := cmp.Diff(tc.wantNominal, gotNominal); diff != "" {
+				t.Errorf("Unexpected nominal quotas (-want,+got):\n%s", diff)
+			}
+			if cqImpl.AllocatableResourceGeneration != tc.wantGeneration {
+				t.Errorf("Unexpected allocatable resource generation, want: %d, got: %d", tc.wantGeneration, cqImpl.AllocatableResourceGeneration)
+			}
+			if got := snapshotRGs[0].Flavors[0].Resources[corev1.ResourceCPU].Nominal; got != 0 {
+				t.Errorf("The previous resource groups were modified, got cpu nominal quota %d", got)
+			}
+		})
+	}
+}
+
+func TestCacheUpdateQuotaSchedules(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		Cohort("cohort").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("x86").
+			ResourcePercentage(corev1.ResourceCPU, 50).
+			Resource(corev1.ResourceMemory, "10Gi", "5Gi").
+			Schedule(*utiltesting.MakeQuotaSchedule("night", "20:00", "08:00").
+				Resource(corev1.ResourceCPU, "2").
+				Resource(corev1.ResourceMemory, "1Gi", "0").
+				Obj()).
+			Obj()).
+		Obj()
+	other := utiltesting.MakeClusterQueue("other").
+		Cohort("cohort").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("x86").Resource(corev1.ResourceCPU, "1").Obj()).
+		Obj()
+
+	testcases := map[string]struct {
+		schedules          map[kueue.ResourceFlavorReference]string
+		wantCQs            sets.Set[string]
+		wantNominal        map[corev1.ResourceName]int64
+		wantBorrowingLimit map[corev1.ResourceName]int64
+	}{
+		"no schedule": {
+			wantNominal: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    4500,
+				corev1.ResourceMemory: 10 * 1024 * 1024 * 1024,
+			},
+			wantBorrowingLimit: map[corev1.ResourceName]int64{
+				corev1.ResourceMemory: 5 * 1024 * 1024 * 1024,
+			},
+		},
+		"active schedule": {
+			schedules: map[kueue.ResourceFlavorReference]string{"x86": "night"},
+			wantCQs:   sets.New("cq", "other"),
+			wantNominal: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    2000,
+				corev1.ResourceMemory: 1024 * 1024 * 1024,
+			},
+			wantBorrowingLimit: map[corev1.ResourceName]int64{
+				corev1.ResourceMemory: 0,
+			},
+		},
+		"schedule not found": {
+			schedules: map[kueue.ResourceFlavorReference]string{"x86": "day"},
+			wantCQs:   sets.New("cq", "other"),
+			wantNominal: map[corev1.ResourceName]int64{
+				corev1.ResourceCPU:    4500,
+				corev1.ResourceMemory: 10 * 1024 * 1024 * 1024,
+			},
+			wantBorrowingLimit: map[corev1.ResourceName]int64{
+				corev1.ResourceMemory: 5 * 1024 * 1024 * 1024,
+			},
+		},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cache := New(utiltesting.NewFakeClient())
+			cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("x86").Capacity(corev1.ResourceCPU, "9").Obj())
+			for _, q := range []*kueue.ClusterQueue{cq, other} {
+				if err := cache.AddClusterQueue(ctx, q); err != nil {
+					t.Fatalf("Failed adding ClusterQueue: %v", err)
+				}
+			}
+
+			gotCQs := cache.UpdateQuotaSchedules(cq, tc.schedules)
+			if diff := cmp.Diff(tc.wantCQs, gotCQs, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected ClusterQueues to requeue (-want,+got):\n%s", diff)
+			}
+			if gotCQs := cache.UpdateQuotaSchedules(cq, tc.schedules); len(gotCQs) != 0 {
+				t.Errorf("Unexpected ClusterQueues to requeue when the schedules didn't change: %v", sets.List(gotCQs))
+			}
+
+			gotNominal := make(map[corev1.ResourceName]int64)
+			gotBorrowingLimit := make(map[corev1.ResourceName]int64)
+			for rName, rQuota := range cache.clusterQueues["cq"].ResourceGroups[0].Flavors[0].Resources {
+				gotNominal[rName] = rQuota.Nominal
+				if rQuota.BorrowingLimit != nil {
+					gotBorrowingLimit[rName] = *rQuota.BorrowingLimit
+				}
+			}
+			if diff := cmp.Diff(tc.wantNominal, gotNominal); diff != "" {
+				t.Errorf("Unexpected nominal quotas (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantBorrowingLimit, gotBorrowingLimit); diff != "" {
+				t.Errorf("Unexpected borrowing limits (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestCohortCanFit(t *testing.T) {
 	cases := map[string]struct {
 		c       *Cohort
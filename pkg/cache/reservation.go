@@ -0,0 +1,246 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// ReservedQuota is the quota of a ClusterQueue that is held for a Reservation
+// in a snapshot.
+type ReservedQuota struct {
+	Name string
+	// Active indicates that the workloads of the Reservation can use the quota.
+	Active bool
+	// Quota is the reserved quota, limited to the flavors and resources in the
+	// quota of the ClusterQueue.
+	Quota FlavorResourceQuantities
+	// Usage is the usage of the reserved flavors and resources by the workloads
+	// of the Reservation.
+	Usage FlavorResourceQuantities
+
+	released bool
+}
+
+// held returns the reserved quota that is not in use by the workloads of the
+// Reservation.
+func (r *ReservedQuota) held() FlavorResourceQuantities {
+	held := make(FlavorResourceQuantities, len(r.Quota))
+	if r.released {
+		return held
+	}
+	for fName, resources := range r.Quota {
+		held[fName] = make(map[corev1.ResourceName]int64, len(resources))
+		for rName, v := range resources {
+			held[fName][rName] = max(0, v-r.Usage[fName][rName])
+		}
+	}
+	return held
+}
+
+func holdsQuota(r *kueue.Reservation) bool {
+	return r.Status.Phase == kueue.ReservationHolding || r.Status.Phase == kueue.ReservationActive
+}
+
+// AddOrUpdateReservation adds or updates the Reservation in the cache. It
+// returns the names of the ClusterQueues whose inadmissible workloads might
+// fit with the new reserved quota.
+func (c *Cache) AddOrUpdateReservation(r *kueue.Reservation) sets.Set[string] {
+	c.Lock()
+	defer c.Unlock()
+	cqs := sets.New[string]()
+	if old, found := c.reservations[r.Name]; found {
+		c.appendClusterQueueAndCohort(cqs, string(old.Spec.ClusterQueue))
+	}
+	c.reservations[r.Name] = r
+	c.appendClusterQueueAndCohort(cqs, string(r.Spec.ClusterQueue))
+	return cqs
+}
+
+// DeleteReservation deletes the Reservation from the cache. It returns the
+// names of the ClusterQueues whose inadmissible workloads might fit with the
+// released quota.
+func (c *Cache) DeleteReservation(r *kueue.Reservation) sets.Set[string] {
+	c.Lock()
+	defer c.Unlock()
+	cqs := sets.New[string]()
+	if old, found := c.reservations[r.Name]; found {
+		c.appendClusterQueueAndCohort(cqs, string(old.Spec.ClusterQueue))
+		delete(c.reservations, r.Name)
+	}
+	return cqs
+}
+
+func (c *Cache) appendClusterQueueAndCohort(cqs sets.Set[string], name string) {
+	cq := c.clusterQueues[name]
+	if cq == nil {
+		return
+	}
+	cqs.Insert(cq.Name)
+	if cq.Cohort != nil {
+		for member := range cq.Cohort.Members {
+			cqs.Insert(member.Name)
+		}
+	}
+}
+
+type ReservationUsageStats struct {
+	FlavorsUsage       []kueue.ReservedFlavor
+	ReservingWorkloads int
+}
+
+// ReservationUsage reports the reserved resources in use by the workloads of
+// the Reservation and the number of workloads holding them.
+func (c *Cache) ReservationUsage(r *kueue.Reservation) (*ReservationUsageStats, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	cq := c.clusterQueues[string(r.Spec.ClusterQueue)]
+	if cq == nil {
+		return nil, errCqNotFound
+	}
+	usage := reservedQuantities(r, cq.Usage)
+	count := 0
+	for _, wl := range cq.Workloads {
+		if wl.Obj.Labels[constants.ReservationLabel] == r.Name {
+			updateUsage(wl, usage, 1)
+			count++
+		}
+	}
+	stats := &ReservationUsageStats{
+		FlavorsUsage:       make([]kueue.ReservedFlavor, 0, len(r.Spec.Flavors)),
+		ReservingWorkloads: count,
+	}
+	for _, flv := range r.Spec.Flavors {
+		outFlvUsage := kueue.ReservedFlavor{
+			Name:      flv.Name,
+			Resources: make(corev1.ResourceList, len(flv.Resources)),
+		}
+		for rName := range flv.Resources {
+			outFlvUsage.Resources[rName] = workload.ResourceQuantity(rName, usage[flv.Name][rName])
+		}
+		stats.FlavorsUsage = append(stats.FlavorsUsage, outFlvUsage)
+	}
+	sort.Slice(stats.FlavorsUsage, func(i, j int) bool {
+		return stats.FlavorsUsage[i].Name < stats.FlavorsUsage[j].Name
+	})
+	return stats, nil
+}
+
+// reservedQuantities returns the zeroed quantities for the flavors and
+// resources of the Reservation that are part of the quota of the ClusterQueue,
+// as listed in the keys of cqUsage.
+func reservedQuantities(r *kueue.Reservation, cqUsage FlavorResourceQuantities) FlavorResourceQuantities {
+	quantities := make(FlavorResourceQuantities, len(r.Spec.Flavors))
+	for _, flv := range r.Spec.Flavors {
+		cqResources, found := cqUsage[flv.Name]
+		if !found {
+			continue
+		}
+		resources := make(map[corev1.ResourceName]int64, len(flv.Resources))
+		for rName := range flv.Resources {
+			if _, found := cqResources[rName]; found {
+				resources[rName] = 0
+			}
+		}
+		quantities[flv.Name] = resources
+	}
+	return quantities
+}
+
+// addReservation adds the reserved quota of the Reservation to the ClusterQueue
+// snapshot, and accounts the part that is not used by its workloads as usage
+// of the ClusterQueue, so that it can't be used by other workloads.
+// It must be called before the ClusterQueue is added to the cohort.
+func (c *ClusterQueue) addReservation(r *kueue.Reservation) {
+	rq := &ReservedQuota{
+		Name:   r.Name,
+		Active: r.Status.Phase == kueue.ReservationActive,
+		Quota:  reservedQuantities(r, c.Usage),
+		Usage:  reservedQuantities(r, c.Usage),
+	}
+	for _, flv := range r.Spec.Flavors {
+		for rName, q := range flv.Resources {
+			if _, found := rq.Quota[flv.Name][rName]; found {
+				rq.Quota[flv.Name][rName] = workload.ResourceValue(rName, q)
+			}
+		}
+	}
+	for _, wl := range c.Workloads {
+		if wl.Obj.Labels[constants.ReservationLabel] == r.Name {
+			updateUsage(wl, rq.Usage, 1)
+		}
+	}
+	if c.Reservations == nil {
+		c.Reservations = make(map[string]*ReservedQuota)
+	}
+	c.Reservations[r.Name] = rq
+	c.holdQuota(rq, 1)
+}
+
+// holdQuota adds, or removes when m is -1, the held quota of the Reservation
+// to the usage of the ClusterQueue and its cohort.
+func (c *ClusterQueue) holdQuota(rq *ReservedQuota, m int64) {
+	c.ownState()
+	for fName, resources := range rq.held() {
+		for rName, v := range resources {
+			c.Usage[fName][rName] += v * m
+			if c.Cohort != nil {
+				c.Cohort.Usage[fName][rName] += v * m
+			}
+		}
+	}
+}
+
+// updateReservationUsage updates the usage of the Reservation of the workload,
+// if any, and the quota held for it.
+func (c *ClusterQueue) updateReservationUsage(wl *workload.Info, m int64) {
+	rq := c.Reservations[wl.Obj.Labels[constants.ReservationLabel]]
+	if rq == nil {
+		return
+	}
+	c.holdQuota(rq, -1)
+	updateUsage(wl, rq.Usage, m)
+	c.holdQuota(rq, 1)
+}
+
+// ReleaseReservation makes the quota held for the Reservation of the workload
+// available in the snapshot, if the Reservation is active in the ClusterQueue
+// of the workload. It returns a function that holds the quota again.
+func (s *Snapshot) ReleaseReservation(wl *workload.Info) func() {
+	cq := s.ClusterQueues[wl.ClusterQueue]
+	if cq == nil {
+		return func() {}
+	}
+	rq := cq.Reservations[wl.Obj.Labels[constants.ReservationLabel]]
+	if rq == nil || !rq.Active || rq.released {
+		return func() {}
+	}
+	cq.holdQuota(rq, -1)
+	rq.released = true
+	return func() {
+		rq.released = false
+		cq.holdQuota(rq, 1)
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func newReservationTestCache(t *testing.T) *Cache {
+	t.Helper()
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("c1").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("c2").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+	}
+	workloads := []kueue.Workload{
+		*utiltesting.MakeWorkload("reserved", "").
+			Labels(map[string]string{constants.ReservationLabel: "res"}).
+			Request(corev1.ResourceCPU, "2").
+			ReserveQuota(utiltesting.MakeAdmission("c1").Assignment(corev1.ResourceCPU, "default", "2").Obj()).
+			Obj(),
+		*utiltesting.MakeWorkload("other", "").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission("c1").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+			Obj(),
+		*utiltesting.MakeWorkload("c2-wl", "").
+			Request(corev1.ResourceCPU, "3").
+			ReserveQuota(utiltesting.MakeAdmission("c2").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+			Obj(),
+	}
+	cl := utiltesting.NewClientBuilder().WithLists(&kueue.WorkloadList{Items: workloads}).Build()
+	cache := New(cl)
+	cache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cq := range clusterQueues {
+		if err := cache.AddClusterQueue(context.Background(), cq); err != nil {
+			t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+		}
+	}
+	return cache
+}
+
+func makeTestReservation(phase kueue.ReservationPhase) *kueue.Reservation {
+	return utiltesting.MakeReservation("res", "c1", time.Now(), time.Hour).
+		Flavor("default", corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("6"),
+			// Not in the quota of the ClusterQueue.
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+		}).
+		Phase(phase).
+		Obj()
+}
+
+func TestSnapshotReservations(t *testing.T) {
+	cases := map[string]struct {
+		phase           kueue.ReservationPhase
+		release         string
+		remove          string
+		wantCQUsage     int64
+		wantCohortUsage int64
+	}{
+		"pending": {
+			phase:           kueue.ReservationPending,
+			wantCQUsage:     3_000,
+			wantCohortUsage: 6_000,
+		},
+		"holding": {
+			phase:           kueue.ReservationHolding,
+			wantCQUsage:     7_000,
+			wantCohortUsage: 10_000,
+		},
+		"holding, released for a workload of the reservation": {
+			phase:           kueue.ReservationHolding,
+			release:         "/reserved",
+			wantCQUsage:     7_000,
+			wantCohortUsage: 10_000,
+		},
+		"active": {
+			phase:           kueue.ReservationActive,
+			wantCQUsage:     7_000,
+			wantCohortUsage: 10_000,
+		},
+		"active, released for a workload of the reservation": {
+			phase:           kueue.ReservationActive,
+			release:         "/reserved",
+			wantCQUsage:     3_000,
+			wantCohortUsage: 6_000,
+		},
+		"active, released for another workload": {
+			phase:           kueue.ReservationActive,
+			release:         "/other",
+			wantCQUsage:     7_000,
+			wantCohortUsage: 10_000,
+		},
+		"active, removed a workload of the reservation": {
+			phase:           kueue.ReservationActive,
+			remove:          "/reserved",
+			wantCQUsage:     7_000,
+			wantCohortUsage: 10_000,
+		},
+		"active, removed another workload": {
+			phase:           kueue.ReservationActive,
+			remove:          "/other",
+			wantCQUsage:     6_000,
+			wantCohortUsage: 9_000,
+		},
+		"expired": {
+			phase:           kueue.ReservationExpired,
+			wantCQUsage:     3_000,
+			wantCohortUsage: 6_000,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cache := newReservationTestCache(t)
+			gotCQs := cache.AddOrUpdateReservation(makeTestReservation(tc.phase))
+			if diff := cmp.Diff(sets.New("c1", "c2"), gotCQs); diff != "" {
+				t.Errorf("Unexpected ClusterQueues to requeue (-want,+got):\n%s", diff)
+			}
+
+			snapshot := cache.Snapshot()
+			cq := snapshot.ClusterQueues["c1"]
+			if tc.release != "" {
+				hold := snapshot.ReleaseReservation(cq.Workloads[tc.release])
+				checkReservationUsage(t, cq, tc.wantCQUsage, tc.wantCohortUsage)
+				hold()
+				return
+			}
+			if tc.remove != "" {
+				snapshot.RemoveWorkload(cq.Workloads[tc.remove])
+			}
+			checkReservationUsage(t, cq, tc.wantCQUsage, tc.wantCohortUsage)
+		})
+	}
+}
+
+func checkReservationUsage(t *testing.T, cq *ClusterQueue, wantCQUsage, wantCohortUsage int64) {
+	t.Helper()
+	if got := cq.Usage["default"][corev1.ResourceCPU]; got != wantCQUsage {
+		t.Errorf("Unexpected ClusterQueue usage %d, want %d", got, wantCQUsage)
+	}
+	if got := cq.Cohort.Usage["default"][corev1.ResourceCPU]; got != wantCohortUsage {
+		t.Errorf("Unexpected cohort usage %d, want %d", got, wantCohortUsage)
+	}
+}
+
+func TestSnapshotReleaseReservationRestoresUsage(t *testing.T) {
+	cache := newReservationTestCache(t)
+	cache.AddOrUpdateReservation(makeTestReservation(kueue.ReservationActive))
+	snapshot := cache.Snapshot()
+	cq := snapshot.ClusterQueues["c1"]
+	hold := snapshot.ReleaseReservation(cq.Workloads["/reserved"])
+	hold()
+	checkReservationUsage(t, cq, 7_000, 10_000)
+
+	newWl := workload.NewInfo(utiltesting.MakeWorkload("new", "").
+		Labels(map[string]string{constants.ReservationLabel: "res"}).
+		Request(corev1.ResourceCPU, "5").
+		ReserveQuota(utiltesting.MakeAdmission("c1").Assignment(corev1.ResourceCPU, "default", "5").Obj()).
+		Obj())
+	snapshot.AddWorkload(newWl)
+	// 7 CPUs used by the reservation, which exceeds the reserved 6 CPUs, and
+	// 1 CPU used by another workload.
+	checkReservationUsage(t, cq, 8_000, 11_000)
+}
+
+func TestCacheReservationUsage(t *testing.T) {
+	cache := newReservationTestCache(t)
+	res := makeTestReservation(kueue.ReservationActive)
+	cache.AddOrUpdateReservation(res)
+	got, err := cache.ReservationUsage(res)
+	if err != nil {
+		t.Fatalf("Failed getting the usage of the reservation: %v", err)
+	}
+	want := &ReservationUsageStats{
+		FlavorsUsage: []kueue.ReservedFlavor{{
+			Name: "default",
+			Resources: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("2"),
+				corev1.ResourceMemory: resource.MustParse("0"),
+			},
+		}},
+		ReservingWorkloads: 1,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected usage (-want,+got):\n%s", diff)
+	}
+
+	if gotCQs := cache.DeleteReservation(res); !gotCQs.Equal(sets.New("c1", "c2")) {
+		t.Errorf("Unexpected ClusterQueues to requeue after deletion: %v", sets.List(gotCQs))
+	}
+	snapshot := cache.Snapshot()
+	checkReservationUsage(t, snapshot.ClusterQueues["c1"], 3_000, 6_000)
+}
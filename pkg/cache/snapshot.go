@@ -17,6 +17,8 @@ limitations under the License.
 package cache
 
 import (
+	"maps"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -34,27 +36,37 @@ type Snapshot struct {
 // updates resources usage.
 func (s *Snapshot) RemoveWorkload(wl *workload.Info) {
 	cq := s.ClusterQueues[wl.ClusterQueue]
+	cq.ownState()
 	delete(cq.Workloads, workload.Key(wl.Obj))
 	updateUsage(wl, cq.Usage, -1)
 	if cq.Cohort != nil {
 		updateUsage(wl, cq.Cohort.Usage, -1)
 	}
+	cq.updateReservationUsage(wl, -1)
 }
 
 // AddWorkload removes a workload from its corresponding ClusterQueue and
 // updates resources usage.
 func (s *Snapshot) AddWorkload(wl *workload.Info) {
 	cq := s.ClusterQueues[wl.ClusterQueue]
+	cq.ownState()
 	cq.Workloads[workload.Key(wl.Obj)] = wl
 	updateUsage(wl, cq.Usage, 1)
 	if cq.Cohort != nil {
 		updateUsage(wl, cq.Cohort.Usage, 1)
 	}
+	cq.updateReservationUsage(wl, 1)
 }
 
+// Snapshot returns a copy of the active ClusterQueues, their cohorts and the
+// ResourceFlavors. The ClusterQueues of the snapshot share their usage and
+// workloads with a copy kept by the cache, which is only rebuilt when the
+// ClusterQueue changes, until the snapshot modifies them.
 func (c *Cache) Snapshot() Snapshot {
 	c.RLock()
 	defer c.RUnlock()
+	c.snapshotMu.Lock()
+	defer c.snapshotMu.Unlock()
 
 	snap := Snapshot{
 		ClusterQueues:            make(map[string]*ClusterQueue, len(c.clusterQueues)),
@@ -66,7 +78,17 @@ func (c *Cache) Snapshot() Snapshot {
 			snap.InactiveClusterQueueSets.Insert(cq.Name)
 			continue
 		}
-		snap.ClusterQueues[cq.Name] = cq.snapshot()
+		if cq.snapshotBase == nil || cq.snapshotBase.version != cq.version {
+			cq.snapshotBase = cq.snapshot()
+		}
+		cqCopy := *cq.snapshotBase
+		cqCopy.sharedState = true
+		snap.ClusterQueues[cq.Name] = &cqCopy
+	}
+	for _, r := range c.reservations {
+		if cq := snap.ClusterQueues[string(r.Spec.ClusterQueue)]; cq != nil && holdsQuota(r) {
+			cq.addReservation(r)
+		}
 	}
 	for name, rf := range c.resourceFlavors {
 		// Shallow copy is enough
@@ -102,14 +124,11 @@ func (c *ClusterQueue) snapshot() *ClusterQueue {
 		Preemption:                    c.Preemption,
 		NamespaceSelector:             c.NamespaceSelector,
 		Status:                        c.Status,
-		AdmissionChecks:               c.AdmissionChecks.Clone(),
+		AdmissionChecks:               maps.Clone(c.AdmissionChecks), // Shallow copy is enough.
+		version:                       c.version,
 	}
 	for fName, rUsage := range c.Usage {
-		rUsageCopy := make(map[corev1.ResourceName]int64, len(rUsage))
-		for k, v := range rUsage {
-			rUsageCopy[k] = v
-		}
-		cc.Usage[fName] = rUsageCopy
+		cc.Usage[fName] = maps.Clone(rUsage)
 	}
 	for k, v := range c.Workloads {
 		// Shallow copy is enough.
@@ -118,6 +137,21 @@ func (c *ClusterQueue) snapshot() *ClusterQueue {
 	return cc
 }
 
+// ownState copies the usage and the workloads of a ClusterQueue in a snapshot
+// that are shared with the copy kept by the cache, before they are modified.
+func (c *ClusterQueue) ownState() {
+	if !c.sharedState {
+		return
+	}
+	usage := make(FlavorResourceQuantities, len(c.Usage))
+	for fName, rUsage := range c.Usage {
+		usage[fName] = maps.Clone(rUsage)
+	}
+	c.Usage = usage
+	c.Workloads = maps.Clone(c.Workloads)
+	c.sharedState = false
+}
+
 func (c *ClusterQueue) accumulateResources(cohort *Cohort) {
 	if cohort.RequestableResources == nil {
 		cohort.RequestableResources = make(FlavorResourceQuantities, len(c.ResourceGroups))
@@ -18,6 +18,7 @@ package cache
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -662,3 +663,142 @@ func TestSnapshotAddRemoveWorkload(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotCopyOnWrite(t *testing.T) {
+	ctx := context.Background()
+	cqCache := New(utiltesting.NewFakeClient())
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, name := range []string{"c1", "c2"} {
+		cq := utiltesting.MakeClusterQueue(name).
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "6").Obj()).
+			Obj()
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+		}
+	}
+	makeWorkload := func(name, cq string) *kueue.Workload {
+		return utiltesting.MakeWorkload(name, "").
+			Request(corev1.ResourceCPU, "1").
+			ReserveQuota(utiltesting.MakeAdmission(cq).Assignment(corev1.ResourceCPU, "default", "1000m").Obj()).
+			Obj()
+	}
+	cqCache.AddOrUpdateWorkload(makeWorkload("c1-wl", "c1"))
+	cqCache.AddOrUpdateWorkload(makeWorkload("c2-wl", "c2"))
+
+	first := cqCache.Snapshot()
+	first.RemoveWorkload(first.ClusterQueues["c1"].Workloads["/c1-wl"])
+	if got := first.ClusterQueues["c1"].Usage["default"][corev1.ResourceCPU]; got != 0 {
+		t.Errorf("Got usage %d after removing the workload from the snapshot, want 0", got)
+	}
+
+	c2Base := cqCache.clusterQueues["c2"].snapshotBase
+	cqCache.AddOrUpdateWorkload(makeWorkload("c1-wl-2", "c1"))
+	second := cqCache.Snapshot()
+	if cqCache.clusterQueues["c2"].snapshotBase != c2Base {
+		t.Error("The copy of the unchanged ClusterQueue was rebuilt")
+	}
+	if !second.ClusterQueues["c2"].sharedState {
+		t.Error("The unchanged ClusterQueue doesn't share its state in the snapshot")
+	}
+	wantUsage := map[string]int64{"c1": 2_000, "c2": 1_000}
+	for name, want := range wantUsage {
+		if got := second.ClusterQueues[name].Usage["default"][corev1.ResourceCPU]; got != want {
+			t.Errorf("Got usage %d for ClusterQueue %s, want %d", got, name, want)
+		}
+	}
+	if got := second.ClusterQueues["c1"].Cohort.Usage["default"][corev1.ResourceCPU]; got != 3_000 {
+		t.Errorf("Got cohort usage %d, want 3000", got)
+	}
+	if diff := cmp.Diff(sets.New("/c1-wl", "/c1-wl-2"), sets.KeySet(second.ClusterQueues["c1"].Workloads)); diff != "" {
+		t.Errorf("Unexpected workloads in the snapshot (-want,+got):\n%s", diff)
+	}
+
+	second.RemoveWorkload(second.ClusterQueues["c2"].Workloads["/c2-wl"])
+	third := cqCache.Snapshot()
+	if got := third.ClusterQueues["c2"].Usage["default"][corev1.ResourceCPU]; got != 1_000 {
+		t.Errorf("Got usage %d after the workload was removed from a previous snapshot, want 1000", got)
+	}
+	if _, found := third.ClusterQueues["c2"].Workloads["/c2-wl"]; !found {
+		t.Error("The workload removed from a previous snapshot is missing")
+	}
+}
+
+func BenchmarkSnapshot(b *testing.B) {
+	const (
+		clusterQueues     = 1000
+		cohorts           = 10
+		workloadsPerQueue = 50
+	)
+	ctx := context.Background()
+	cqCache := New(utiltesting.NewFakeClient())
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for i := 0; i < clusterQueues; i++ {
+		name := fmt.Sprintf("cq-%d", i)
+		cq := utiltesting.MakeClusterQueue(name).
+			Cohort(fmt.Sprintf("cohort-%d", i%cohorts)).
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+				Resource(corev1.ResourceCPU, "1000").
+				Resource(corev1.ResourceMemory, "1000Gi").
+				Obj()).
+			Obj()
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			b.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+		}
+		for j := 0; j < workloadsPerQueue; j++ {
+			cqCache.AddOrUpdateWorkload(utiltesting.MakeWorkload(fmt.Sprintf("%s-wl-%d", name, j), "").
+				Request(corev1.ResourceCPU, "1").
+				Request(corev1.ResourceMemory, "1Gi").
+				ReserveQuota(utiltesting.MakeAdmission(name).
+					Assignment(corev1.ResourceCPU, "default", "1").
+					Assignment(corev1.ResourceMemory, "default", "1Gi").
+					Obj()).
+				Obj())
+		}
+	}
+	changed := utiltesting.MakeWorkload("changed", "").
+		Request(corev1.ResourceCPU, "1").
+		ReserveQuota(utiltesting.MakeAdmission("cq-0").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+		Obj()
+
+	b.Run("full copy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			// Drop the shared copies to measure a snapshot that copies every
+			// ClusterQueue.
+			for _, cq := range cqCache.clusterQueues {
+				cq.snapshotBase = nil
+			}
+			cqCache.Snapshot()
+		}
+	})
+	b.Run("no changes", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			cqCache.Snapshot()
+		}
+	})
+	b.Run("one ClusterQueue changed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if i%2 == 0 {
+				cqCache.AddOrUpdateWorkload(changed)
+			} else if err := cqCache.DeleteWorkload(changed); err != nil {
+				b.Fatalf("Couldn't delete the workload: %v", err)
+			}
+			cqCache.Snapshot()
+		}
+	})
+	b.Run("one ClusterQueue changed with preemption simulation", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if i%2 == 0 {
+				cqCache.AddOrUpdateWorkload(changed)
+			} else if err := cqCache.DeleteWorkload(changed); err != nil {
+				b.Fatalf("Couldn't delete the workload: %v", err)
+			}
+			snap := cqCache.Snapshot()
+			for _, wl := range snap.ClusterQueues["cq-0"].Workloads {
+				snap.RemoveWorkload(wl)
+				snap.AddWorkload(wl)
+				break
+			}
+		}
+	})
+}
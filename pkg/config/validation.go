@@ -18,27 +18,41 @@ package config
 
 import (
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/strings/slices"
 
 	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/util/fieldpath"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 const (
 	queueVisibilityClusterQueuesMaxValue              = 4000
 	queueVisibilityClusterQueuesUpdateIntervalSeconds = 1
+	genericFrameworkMaxPodSets                        = 8
 )
 
 var (
-	integrationsPath           = field.NewPath("integrations")
-	integrationsFrameworksPath = integrationsPath.Child("frameworks")
-	podOptionsPath             = integrationsPath.Child("podOptions")
-	namespaceSelectorPath      = podOptionsPath.Child("namespaceSelector")
+	integrationsPath            = field.NewPath("integrations")
+	integrationsFrameworksPath  = integrationsPath.Child("frameworks")
+	podOptionsPath              = integrationsPath.Child("podOptions")
+	genericFrameworksPath       = integrationsPath.Child("genericFrameworks")
+	externalFrameworksPath      = integrationsPath.Child("externalFrameworks")
+	namespaceSelectorPath       = podOptionsPath.Child("namespaceSelector")
+	nodeLabelKeysPath           = field.NewPath("resourceFlavorDiscovery", "nodeLabelKeys")
+	tracingPath                 = field.NewPath("tracing")
+	capacityCheckActionPath     = field.NewPath("capacityCheck", "action")
+	resourcesPath               = field.NewPath("resources")
+	maxHeadsPerClusterQueuePath = field.NewPath("batchAdmission", "maxHeadsPerClusterQueue")
 )
 
 func validate(c *configapi.Configuration) field.ErrorList {
@@ -49,6 +63,157 @@ func validate(c *configapi.Configuration) field.ErrorList {
 	// Validate PodNamespaceSelector for the pod framework
 	allErrs = append(allErrs, validateIntegrations(c)...)
 
+	allErrs = append(allErrs, validateResourceFlavorDiscovery(c)...)
+
+	allErrs = append(allErrs, validateTracing(c)...)
+
+	allErrs = append(allErrs, validateCapacityCheck(c)...)
+
+	allErrs = append(allErrs, validateResources(c)...)
+
+	allErrs = append(allErrs, validateBatchAdmission(c)...)
+
+	return allErrs
+}
+
+func validateTracing(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.Tracing == nil {
+		return allErrs
+	}
+	switch c.Tracing.Exporter {
+	case configapi.TracingExporterOTLP:
+		if c.Tracing.Endpoint == nil || len(*c.Tracing.Endpoint) == 0 {
+			allErrs = append(allErrs, field.Required(tracingPath.Child("endpoint"), "must be set for the otlp exporter"))
+		}
+	case configapi.TracingExporterStdout:
+	default:
+		allErrs = append(allErrs, field.NotSupported(tracingPath.Child("exporter"), c.Tracing.Exporter,
+			[]string{string(configapi.TracingExporterOTLP), string(configapi.TracingExporterStdout)}))
+	}
+	if rate := c.Tracing.SamplingRatePerMillion; rate != nil && (*rate < 0 || *rate > 1000000) {
+		allErrs = append(allErrs, field.Invalid(tracingPath.Child("samplingRatePerMillion"), *rate, "must be between 0 and 1000000"))
+	}
+	return allErrs
+}
+
+func validateCapacityCheck(c *configapi.Configuration) field.ErrorList {
+	if c.CapacityCheck == nil {
+		return nil
+	}
+	switch c.CapacityCheck.Action {
+	case configapi.CapacityCheckReject, configapi.CapacityCheckWarn, configapi.CapacityCheckMarkInadmissible:
+		return nil
+	}
+	return field.ErrorList{field.NotSupported(capacityCheckActionPath, c.CapacityCheck.Action, []string{
+		string(configapi.CapacityCheckReject), string(configapi.CapacityCheckWarn), string(configapi.CapacityCheckMarkInadmissible),
+	})}
+}
+
+func validateBatchAdmission(c *configapi.Configuration) field.ErrorList {
+	if c.BatchAdmission == nil || c.BatchAdmission.MaxHeadsPerClusterQueue == nil {
+		return nil
+	}
+	if maxHeads := *c.BatchAdmission.MaxHeadsPerClusterQueue; maxHeads < 1 {
+		return field.ErrorList{field.Invalid(maxHeadsPerClusterQueuePath, maxHeads, "must be greater than or equal to 1")}
+	}
+	return nil
+}
+
+func validateResources(c *configapi.Configuration) field.ErrorList {
+	if c.Resources == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	prefixesPath := resourcesPath.Child("excludeResourcePrefixes")
+	for i, prefix := range c.Resources.ExcludeResourcePrefixes {
+		if prefix == "" {
+			allErrs = append(allErrs, field.Invalid(prefixesPath.Index(i), prefix, "cannot be empty"))
+		}
+	}
+	seen := sets.New[corev1.ResourceName]()
+	for i, t := range c.Resources.Transformations {
+		path := resourcesPath.Child("transformations").Index(i)
+		allErrs = append(allErrs, validateResourceName(t.Input, path.Child("input"))...)
+		if seen.Has(t.Input) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("input"), t.Input))
+		}
+		seen.Insert(t.Input)
+		if workload.IsExcludedResource(c.Resources.ExcludeResourcePrefixes, t.Input) {
+			allErrs = append(allErrs, field.Invalid(path.Child("input"), t.Input, "is excluded by excludeResourcePrefixes"))
+		}
+		if t.Strategy != nil {
+			switch *t.Strategy {
+			case configapi.RetainResourceTransformation, configapi.ReplaceResourceTransformation:
+			default:
+				allErrs = append(allErrs, field.NotSupported(path.Child("strategy"), *t.Strategy, []string{
+					string(configapi.RetainResourceTransformation), string(configapi.ReplaceResourceTransformation),
+				}))
+			}
+		}
+		for _, name := range sets.List(sets.KeySet(t.Outputs)) {
+			q := t.Outputs[name]
+			outPath := path.Child("outputs").Key(string(name))
+			allErrs = append(allErrs, validateResourceName(name, outPath)...)
+			if name == t.Input {
+				allErrs = append(allErrs, field.Invalid(outPath, name, "cannot be the input resource"))
+			}
+			if q.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(outPath, q.String(), "must be greater than or equal to 0"))
+			}
+		}
+	}
+	seenNames := sets.New[corev1.ResourceName]()
+	seenClasses := sets.New[string]()
+	for i, m := range c.Resources.DeviceClassMappings {
+		path := resourcesPath.Child("deviceClassMappings").Index(i)
+		allErrs = append(allErrs, validateResourceName(m.Name, path.Child("name"))...)
+		if seenNames.Has(m.Name) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), m.Name))
+		}
+		seenNames.Insert(m.Name)
+		if len(m.DeviceClassNames) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("deviceClassNames"), ""))
+		}
+		for j, className := range m.DeviceClassNames {
+			classPath := path.Child("deviceClassNames").Index(j)
+			for _, msg := range utilvalidation.IsDNS1123Subdomain(className) {
+				allErrs = append(allErrs, field.Invalid(classPath, className, msg))
+			}
+			if seenClasses.Has(className) {
+				allErrs = append(allErrs, field.Duplicate(classPath, className))
+			}
+			seenClasses.Insert(className)
+		}
+	}
+	return allErrs
+}
+
+func validateResourceName(name corev1.ResourceName, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, msg := range utilvalidation.IsQualifiedName(string(name)) {
+		allErrs = append(allErrs, field.Invalid(path, name, msg))
+	}
+	return allErrs
+}
+
+func validateResourceFlavorDiscovery(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	if c.ResourceFlavorDiscovery == nil || !c.ResourceFlavorDiscovery.Enable {
+		return allErrs
+	}
+	if len(c.ResourceFlavorDiscovery.NodeLabelKeys) == 0 {
+		return field.ErrorList{field.Required(nodeLabelKeysPath, "cannot be empty when the discovery is enabled")}
+	}
+	seen := sets.New[string]()
+	for i, key := range c.ResourceFlavorDiscovery.NodeLabelKeys {
+		path := nodeLabelKeysPath.Index(i)
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(path, key))
+		}
+		seen.Insert(key)
+		allErrs = append(allErrs, validation.ValidateLabelName(key, path)...)
+	}
 	return allErrs
 }
 
@@ -81,10 +246,101 @@ func validateIntegrations(c *configapi.Configuration) field.ErrorList {
 	}
 
 	allErrs = append(allErrs, validatePodIntegrationOptions(c)...)
+	allErrs = append(allErrs, validateGenericFrameworks(c)...)
+	allErrs = append(allErrs, validateExternalFrameworks(c)...)
+
+	return allErrs
+}
+
+func validateExternalFrameworks(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := sets.New[schema.GroupVersionKind]()
+	for i, kindArg := range c.Integrations.ExternalFrameworks {
+		path := externalFrameworksPath.Index(i)
+		gvk, _ := schema.ParseKindArg(kindArg)
+		if gvk == nil {
+			allErrs = append(allErrs, field.Invalid(path, kindArg, "must be in the Kind.version.group format"))
+			continue
+		}
+		if seen.Has(*gvk) {
+			allErrs = append(allErrs, field.Duplicate(path, kindArg))
+		}
+		seen.Insert(*gvk)
+	}
+	return allErrs
+}
+
+func validateGenericFrameworks(c *configapi.Configuration) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := sets.New[schema.GroupKind]()
+	for i := range c.Integrations.GenericFrameworks {
+		fw := &c.Integrations.GenericFrameworks[i]
+		fwPath := genericFrameworksPath.Index(i)
+
+		for _, msg := range utilvalidation.IsDNS1123Subdomain(fw.Group) {
+			allErrs = append(allErrs, field.Invalid(fwPath.Child("group"), fw.Group, msg))
+		}
+		if fw.Version == "" {
+			allErrs = append(allErrs, field.Required(fwPath.Child("version"), ""))
+		}
+		if fw.Kind == "" {
+			allErrs = append(allErrs, field.Required(fwPath.Child("kind"), ""))
+		}
+		// The framework names are "<group>/<lowercase kind>", so the version
+		// and the case of the kind don't tell two entries apart.
+		gk := schema.GroupKind{Group: fw.Group, Kind: strings.ToLower(fw.Kind)}
+		if seen.Has(gk) {
+			allErrs = append(allErrs, field.Duplicate(fwPath.Child("kind"), fw.Kind))
+		}
+		seen.Insert(gk)
+
+		allErrs = append(allErrs, validateFieldPath(fw.SuspendPath, fwPath.Child("suspendPath"))...)
+		if fw.ConditionsPath != nil {
+			allErrs = append(allErrs, validateFieldPath(*fw.ConditionsPath, fwPath.Child("conditionsPath"))...)
+		}
+		if len(fw.FinishedConditionTypes) == 0 {
+			allErrs = append(allErrs, field.Required(fwPath.Child("finishedConditionTypes"), ""))
+		}
+		if fw.ActivePath != nil {
+			allErrs = append(allErrs, validateFieldPath(*fw.ActivePath, fwPath.Child("activePath"))...)
+		}
 
+		podSetsPath := fwPath.Child("podSets")
+		if len(fw.PodSets) == 0 {
+			allErrs = append(allErrs, field.Required(podSetsPath, ""))
+		} else if len(fw.PodSets) > genericFrameworkMaxPodSets {
+			allErrs = append(allErrs, field.TooMany(podSetsPath, len(fw.PodSets), genericFrameworkMaxPodSets))
+		}
+		podSetNames := sets.New[string]()
+		for j := range fw.PodSets {
+			ps := &fw.PodSets[j]
+			psPath := podSetsPath.Index(j)
+			for _, msg := range utilvalidation.IsDNS1123Label(ps.Name) {
+				allErrs = append(allErrs, field.Invalid(psPath.Child("name"), ps.Name, msg))
+			}
+			if podSetNames.Has(ps.Name) {
+				allErrs = append(allErrs, field.Duplicate(psPath.Child("name"), ps.Name))
+			}
+			podSetNames.Insert(ps.Name)
+			allErrs = append(allErrs, validateFieldPath(ps.TemplatePath, psPath.Child("templatePath"))...)
+			if ps.ReplicasPath != nil {
+				allErrs = append(allErrs, validateFieldPath(*ps.ReplicasPath, psPath.Child("replicasPath"))...)
+			}
+		}
+	}
 	return allErrs
 }
 
+func validateFieldPath(p string, path *field.Path) field.ErrorList {
+	if p == "" {
+		return field.ErrorList{field.Required(path, "")}
+	}
+	if _, err := fieldpath.Parse(p); err != nil {
+		return field.ErrorList{field.Invalid(path, p, err.Error())}
+	}
+	return nil
+}
+
 func validatePodIntegrationOptions(c *configapi.Configuration) field.ErrorList {
 	var allErrs field.ErrorList
 
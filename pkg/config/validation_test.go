@@ -22,6 +22,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
@@ -213,6 +215,430 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		"resource flavor discovery without node label keys": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				ResourceFlavorDiscovery: &configapi.ResourceFlavorDiscovery{
+					Enable: true,
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "resourceFlavorDiscovery.nodeLabelKeys",
+				},
+			},
+		},
+		"resource flavor discovery with invalid and duplicated node label keys": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				ResourceFlavorDiscovery: &configapi.ResourceFlavorDiscovery{
+					Enable:        true,
+					NodeLabelKeys: []string{"instance-type", "bad key", "instance-type"},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resourceFlavorDiscovery.nodeLabelKeys[1]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "resourceFlavorDiscovery.nodeLabelKeys[2]",
+				},
+			},
+		},
+		"disabled resource flavor discovery": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				ResourceFlavorDiscovery: &configapi.ResourceFlavorDiscovery{
+					NodeLabelKeys: []string{"bad key"},
+				},
+			},
+		},
+		"valid tracing": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				Tracing: &configapi.Tracing{
+					Exporter:               configapi.TracingExporterOTLP,
+					Endpoint:               ptr.To("collector:4318"),
+					SamplingRatePerMillion: ptr.To[int32](1000),
+				},
+			},
+		},
+		"tracing with unknown exporter and invalid sampling rate": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				Tracing: &configapi.Tracing{
+					Exporter:               "jaeger",
+					SamplingRatePerMillion: ptr.To[int32](2000000),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "tracing.exporter",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "tracing.samplingRatePerMillion",
+				},
+			},
+		},
+		"tracing with otlp exporter without endpoint": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				Tracing: &configapi.Tracing{
+					Exporter: configapi.TracingExporterOTLP,
+					Endpoint: ptr.To(""),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "tracing.endpoint",
+				},
+			},
+		},
+		"valid capacityCheck": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				CapacityCheck: &configapi.CapacityCheck{
+					Action: configapi.CapacityCheckMarkInadmissible,
+				},
+			},
+		},
+		"capacityCheck with unknown action": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				CapacityCheck: &configapi.CapacityCheck{
+					Action: "Ignore",
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "capacityCheck.action",
+				},
+			},
+		},
+		"valid batchAdmission": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				BatchAdmission: &configapi.BatchAdmission{
+					MaxHeadsPerClusterQueue: ptr.To[int32](5),
+				},
+			},
+		},
+		"batchAdmission with zero maxHeadsPerClusterQueue": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				BatchAdmission: &configapi.BatchAdmission{
+					MaxHeadsPerClusterQueue: ptr.To[int32](0),
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "batchAdmission.maxHeadsPerClusterQueue",
+				},
+			},
+		},
+		"valid resources": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				Resources: &configapi.Resources{
+					ExcludeResourcePrefixes: []string{"networking.example.com/"},
+					Transformations: []configapi.ResourceTransformation{{
+						Input:    "nvidia.com/mig-1g.5gb",
+						Strategy: ptr.To(configapi.ReplaceResourceTransformation),
+						Outputs: corev1.ResourceList{
+							"example.com/accelerator-memory": resource.MustParse("5Gi"),
+						},
+					}},
+				},
+			},
+		},
+		"invalid resources": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				Resources: &configapi.Resources{
+					ExcludeResourcePrefixes: []string{"networking.example.com/", ""},
+					Transformations: []configapi.ResourceTransformation{
+						{
+							Input:    "nvidia.com/mig-1g.5gb",
+							Strategy: ptr.To[configapi.ResourceTransformationStrategy]("Drop"),
+							Outputs: corev1.ResourceList{
+								"nvidia.com/mig-1g.5gb":          resource.MustParse("1"),
+								"example.com/accelerator-memory": resource.MustParse("-5Gi"),
+							},
+						},
+						{
+							Input: "nvidia.com/mig-1g.5gb",
+						},
+						{
+							Input: "networking.example.com/vpc1",
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.excludeResourcePrefixes[1]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeNotSupported,
+					Field: "resources.transformations[0].strategy",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.transformations[0].outputs[example.com/accelerator-memory]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.transformations[0].outputs[nvidia.com/mig-1g.5gb]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "resources.transformations[1].input",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.transformations[2].input",
+				},
+			},
+		},
+		"valid device class mappings": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				Resources: &configapi.Resources{
+					DeviceClassMappings: []configapi.DeviceClassMapping{
+						{
+							Name:             "example.com/gpu",
+							DeviceClassNames: []string{"gpu.example.com", "gpu-large.example.com"},
+						},
+						{
+							Name:             "example.com/fpga",
+							DeviceClassNames: []string{"fpga.example.com"},
+						},
+					},
+				},
+			},
+		},
+		"invalid device class mappings": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations:    defaultIntegrations,
+				Resources: &configapi.Resources{
+					DeviceClassMappings: []configapi.DeviceClassMapping{
+						{
+							Name:             "example.com/gpu",
+							DeviceClassNames: []string{"gpu.example.com", "GPU"},
+						},
+						{
+							Name:             "example.com/gpu",
+							DeviceClassNames: []string{"gpu.example.com"},
+						},
+						{
+							Name: "example.com/fpga",
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "resources.deviceClassMappings[0].deviceClassNames[1]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "resources.deviceClassMappings[1].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "resources.deviceClassMappings[1].deviceClassNames[0]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "resources.deviceClassMappings[2].deviceClassNames",
+				},
+			},
+		},
+		"valid genericFrameworks": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations: &configapi.Integrations{
+					Frameworks: []string{"batch/job"},
+					PodOptions: defaultPodIntegrationOptions,
+					GenericFrameworks: []configapi.GenericFramework{
+						{
+							Group:       "example.com",
+							Version:     "v1",
+							Kind:        "Trainer",
+							SuspendPath: ".spec.suspend",
+							PodSets: []configapi.GenericPodSet{
+								{Name: "launcher", TemplatePath: ".spec.launcher.template"},
+								{Name: "worker", TemplatePath: ".spec.workers[0].template", ReplicasPath: ptr.To(".spec.workers[0].replicas")},
+							},
+							ConditionsPath:         ptr.To(".status.conditions"),
+							FinishedConditionTypes: []string{"Succeeded", "Failed"},
+							ActivePath:             ptr.To(".status.active"),
+						},
+					},
+				},
+			},
+		},
+		"invalid genericFrameworks": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations: &configapi.Integrations{
+					Frameworks: []string{"batch/job"},
+					PodOptions: defaultPodIntegrationOptions,
+					GenericFrameworks: []configapi.GenericFramework{
+						{
+							Group:       "example.com",
+							Version:     "v1",
+							Kind:        "Trainer",
+							SuspendPath: "spec.suspend",
+							PodSets: []configapi.GenericPodSet{
+								{Name: "Main", TemplatePath: ".spec.template"},
+								{Name: "worker", TemplatePath: ".spec.workers[0].template", ReplicasPath: ptr.To(".spec.workers[*].replicas")},
+								{Name: "worker"},
+							},
+							ConditionsPath: ptr.To(""),
+						},
+						{
+							Group:                  "example_com",
+							Kind:                   "trainer",
+							FinishedConditionTypes: []string{"Succeeded"},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "integrations.genericFrameworks[0].suspendPath",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "integrations.genericFrameworks[0].conditionsPath",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "integrations.genericFrameworks[0].finishedConditionTypes",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "integrations.genericFrameworks[0].podSets[0].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "integrations.genericFrameworks[0].podSets[1].replicasPath",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "integrations.genericFrameworks[0].podSets[2].name",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "integrations.genericFrameworks[0].podSets[2].templatePath",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "integrations.genericFrameworks[1].group",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "integrations.genericFrameworks[1].version",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "integrations.genericFrameworks[1].suspendPath",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "integrations.genericFrameworks[1].podSets",
+				},
+			},
+		},
+		"duplicate genericFrameworks": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations: &configapi.Integrations{
+					Frameworks: []string{"batch/job"},
+					PodOptions: defaultPodIntegrationOptions,
+					GenericFrameworks: []configapi.GenericFramework{
+						{
+							Group:                  "example.com",
+							Version:                "v1",
+							Kind:                   "Trainer",
+							SuspendPath:            ".spec.suspend",
+							PodSets:                []configapi.GenericPodSet{{Name: "main", TemplatePath: ".spec.template"}},
+							FinishedConditionTypes: []string{"Finished"},
+						},
+						{
+							Group:                  "example.com",
+							Version:                "v2",
+							Kind:                   "Trainer",
+							SuspendPath:            ".spec.suspend",
+							PodSets:                []configapi.GenericPodSet{{Name: "main", TemplatePath: ".spec.template"}},
+							FinishedConditionTypes: []string{"Finished"},
+						},
+					},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "integrations.genericFrameworks[1].kind",
+				},
+			},
+		},
+		"valid externalFrameworks": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations: &configapi.Integrations{
+					Frameworks:         []string{"batch/job"},
+					PodOptions:         defaultPodIntegrationOptions,
+					ExternalFrameworks: []string{"FooJob.v1.example.com", "FooJob.v2.example.com"},
+				},
+			},
+		},
+		"invalid externalFrameworks": {
+			cfg: &configapi.Configuration{
+				QueueVisibility: defaultQueueVisibility,
+				Integrations: &configapi.Integrations{
+					Frameworks:         []string{"batch/job"},
+					PodOptions:         defaultPodIntegrationOptions,
+					ExternalFrameworks: []string{"example.com/foojob", "FooJob.v1.example.com", "FooJob.v1.example.com"},
+				},
+			},
+			wantErr: field.ErrorList{
+				&field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "integrations.externalFrameworks[0]",
+				},
+				&field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "integrations.externalFrameworks[2]",
+				},
+			},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -23,4 +23,28 @@ const (
 
 	CheckInactiveMessage = "the check is not active"
 	NoRequestNeeded      = "the provisioning request is not needed"
+
+	// BookingExpired is set by the cluster autoscaler on a provisioned request
+	// when the pods didn't consume the booked capacity in time.
+	BookingExpired = "BookingExpired"
+	// CapacityRevoked is set by the cluster autoscaler when the provisioned
+	// capacity is no longer available to the pods.
+	CapacityRevoked = "CapacityRevoked"
+)
+
+const (
+	// CheckAnnotationKey is the annotation with the name of the admission
+	// check that a ProvisioningRequest was created for.
+	CheckAnnotationKey = "kueue.x-k8s.io/admission-check"
+	// AttemptAnnotationKey is the annotation with the attempt of the check
+	// that a ProvisioningRequest was created for, starting at 1.
+	AttemptAnnotationKey = "kueue.x-k8s.io/provisioning-request-attempt"
+
+	// DefaultBackoffLimitCount is the number of times that a
+	// ProvisioningRequest is recreated after its booking expired, if the
+	// ProvisioningRequestConfig doesn't set it.
+	DefaultBackoffLimitCount int32 = 3
+	// DefaultBackoffBaseSeconds is the time to wait before the first retry,
+	// if the ProvisioningRequestConfig doesn't set it.
+	DefaultBackoffBaseSeconds int32 = 60
 )
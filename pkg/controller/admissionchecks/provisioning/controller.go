@@ -23,6 +23,8 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"strconv"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -40,6 +42,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/podset"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
@@ -70,6 +73,7 @@ var _ reconcile.Reconciler = (*Controller)(nil)
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;update;patch;delete
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=admissionchecks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=clusterqueues,verbs=get;list;watch
 // +kubebuilder:rbac:groups=kueue.x-k8s.io,resources=provisioningrequestconfigs,verbs=get;list;watch
 
 func NewController(client client.Client) *Controller {
@@ -97,7 +101,10 @@ func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	if !workload.HasQuotaReservation(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadFinished) {
 		//1.2 workload has no reservation or is finished
 		log.V(5).Info("workload with no reservation, delete owned requests")
-		return reconcile.Result{}, c.deleteOwnedProvisionRequests(ctx, req.Namespace, req.Name)
+		if err := c.deleteOwnedProvisionRequests(ctx, req.Namespace, req.Name); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, c.resetRetryChecks(ctx, wl)
 	}
 
 	// get the lists of relevant checks
@@ -113,13 +120,14 @@ func (c *Controller) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{}, c.syncCheckStates(ctx, wl, relevantChecks)
 	}
 
-	if err := c.syncOwnedProvisionRequest(ctx, wl, relevantChecks); err != nil {
+	requeueAfter, err := c.syncOwnedProvisionRequest(ctx, wl, relevantChecks)
+	if err != nil {
 		// this can also delete unneeded checks
 		log.V(2).Error(err, "syncOwnedProvisionRequest failed")
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, c.syncCheckStates(ctx, wl, relevantChecks)
+	return reconcile.Result{RequeueAfter: requeueAfter}, c.syncCheckStates(ctx, wl, relevantChecks)
 }
 
 func (c *Controller) deleteOwnedProvisionRequests(ctx context.Context, namespace string, name string) error {
@@ -136,58 +144,195 @@ func (c *Controller) deleteOwnedProvisionRequests(ctx context.Context, namespace
 	return nil
 }
 
-func (c *Controller) syncOwnedProvisionRequest(ctx context.Context, wl *kueue.Workload, relevantChecks []string) error {
-	log := ctrl.LoggerFrom(ctx)
+// resetRetryChecks sets the checks in Retry back to Pending once the workload
+// no longer reserves quota, so that it can be admitted again.
+func (c *Controller) resetRetryChecks(ctx context.Context, wl *kueue.Workload) error {
+	if workload.HasQuotaReservation(wl) || apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadFinished) {
+		return nil
+	}
+	checks, err := c.helper.FilterChecksForProvReq(ctx, wl.Status.AdmissionChecks)
+	if err != nil {
+		return err
+	}
+	wlPatch := workload.BaseSSAWorkload(wl)
+	updated := false
+	for _, check := range checks {
+		checkState := *workload.FindAdmissionCheck(wl.Status.AdmissionChecks, check)
+		if checkState.State == kueue.CheckStateRetry {
+			updated = true
+			checkState.State = kueue.CheckStatePending
+		}
+		workload.SetAdmissionCheckState(&wlPatch.Status.AdmissionChecks, checkState)
+	}
+	if updated {
+		return c.client.Status().Patch(ctx, wlPatch, client.Apply, client.FieldOwner(ControllerName), client.ForceOwnership)
+	}
+	return nil
+}
+
+// requestAttempt is the ProvisioningRequest of an attempt of a check.
+type requestAttempt struct {
+	check   string
+	attempt int32
+	request *autoscaling.ProvisioningRequest
+}
+
+func (c *Controller) ownedRequests(ctx context.Context, wl *kueue.Workload) ([]autoscaling.ProvisioningRequest, error) {
 	list := &autoscaling.ProvisioningRequestList{}
 	if err := c.client.List(ctx, list, client.InNamespace(wl.Namespace), client.MatchingFields{RequestsOwnedByWorkloadKey: wl.Name}); client.IgnoreNotFound(err) != nil {
-		return err
+		return nil, err
 	}
+	return list.Items, nil
+}
 
-	requestExists := slices.ToMap(relevantChecks, func(i int) (string, bool) { return GetProvisioningRequestName(wl.Name, relevantChecks[i]), false })
-	requestNeededForCheck := slices.ToMap(relevantChecks, func(i int) (string, bool) { return relevantChecks[i], c.requestIsNeeded(ctx, wl, relevantChecks[i]) })
-	requestToCheckName := slices.ToMap(relevantChecks, func(i int) (string, string) {
-		return GetProvisioningRequestName(wl.Name, relevantChecks[i]), relevantChecks[i]
-	})
+// latestRequests returns the request of the latest attempt of every check,
+// and the requests that are no longer needed, because they belong to a
+// previous attempt or to a check that is not relevant.
+func latestRequests(wlName string, checks []string, requests []autoscaling.ProvisioningRequest) (map[string]requestAttempt, []*autoscaling.ProvisioningRequest) {
+	relevant := sets.New(checks...)
+	latest := make(map[string]requestAttempt, len(checks))
+	var stale []*autoscaling.ProvisioningRequest
+	for i := range requests {
+		req := &requests[i]
+		ra, found := attemptOf(wlName, checks, req)
+		if !found || !relevant.Has(ra.check) {
+			stale = append(stale, req)
+			continue
+		}
+		if current, found := latest[ra.check]; found {
+			if current.attempt > ra.attempt {
+				stale = append(stale, req)
+				continue
+			}
+			stale = append(stale, current.request)
+		}
+		ra.request = req
+		latest[ra.check] = ra
+	}
+	return latest, stale
+}
 
-	for i := range list.Items {
-		req := &list.Items[i]
-		if _, needed := requestExists[req.Name]; !needed {
-			if err := c.client.Delete(ctx, req); client.IgnoreNotFound(err) != nil {
-				return err
+// attemptOf returns the check and the attempt that the request was created
+// for, as recorded in its annotations. The requests without annotations are
+// the first attempt of the check they are named after.
+func attemptOf(wlName string, checks []string, req *autoscaling.ProvisioningRequest) (requestAttempt, bool) {
+	if check, found := req.Annotations[CheckAnnotationKey]; found {
+		attempt, err := strconv.ParseInt(req.Annotations[AttemptAnnotationKey], 10, 32)
+		if err != nil || attempt < 1 {
+			return requestAttempt{}, false
+		}
+		return requestAttempt{check: check, attempt: int32(attempt)}, true
+	}
+	for _, check := range checks {
+		if req.Name == GetProvisioningRequestName(wlName, check, 1) {
+			return requestAttempt{check: check, attempt: 1}, true
+		}
+	}
+	return requestAttempt{}, false
+}
+
+func isBookingExpired(pr *autoscaling.ProvisioningRequest) bool {
+	return apimeta.IsStatusConditionTrue(pr.Status.Conditions, BookingExpired)
+}
+
+func isCapacityRevoked(pr *autoscaling.ProvisioningRequest) bool {
+	return apimeta.IsStatusConditionTrue(pr.Status.Conditions, CapacityRevoked)
+}
+
+// maxRetries returns the number of times that the requests of the config
+// are recreated after their booking expired.
+func maxRetries(prc *kueue.ProvisioningRequestConfig) int32 {
+	if prc.Spec.RetryStrategy == nil {
+		return DefaultBackoffLimitCount
+	}
+	return ptr.Deref(prc.Spec.RetryStrategy.BackoffLimitCount, DefaultBackoffLimitCount)
+}
+
+// retryBackoff returns the time to wait before the attempt that follows the
+// given one.
+func retryBackoff(prc *kueue.ProvisioningRequestConfig, attempt int32) time.Duration {
+	baseSeconds := DefaultBackoffBaseSeconds
+	if prc.Spec.RetryStrategy != nil {
+		baseSeconds = ptr.Deref(prc.Spec.RetryStrategy.BackoffBaseSeconds, DefaultBackoffBaseSeconds)
+	}
+	return time.Duration(baseSeconds) * time.Second << (attempt - 1)
+}
+
+func (c *Controller) syncOwnedProvisionRequest(ctx context.Context, wl *kueue.Workload, relevantChecks []string) (time.Duration, error) {
+	log := ctrl.LoggerFrom(ctx)
+	owned, err := c.ownedRequests(ctx, wl)
+	if err != nil {
+		return 0, err
+	}
+
+	latest, stale := latestRequests(wl.Name, relevantChecks, owned)
+	for _, req := range stale {
+		if err := c.client.Delete(ctx, req); client.IgnoreNotFound(err) != nil {
+			return 0, err
+		}
+	}
+
+	var requeueAfter time.Duration
+	for _, checkName := range relevantChecks {
+		//get the config
+		prc, err := c.helper.ProvReqConfigForAdmissionCheck(ctx, checkName)
+		var expectedPodSets []string
+		if err == nil {
+			if expectedPodSets, err = c.expectedPodSets(ctx, wl, checkName, prc); err != nil {
+				return 0, err
 			}
-		} else {
-			// get the parameters
-			checkName := requestToCheckName[req.Name]
-			prc, err := c.helper.ProvReqConfigForAdmissionCheck(ctx, checkName)
-			if err != nil || !requestNeededForCheck[checkName] || !requestHasParamaters(req, prc) {
+		}
+		needed := len(expectedPodSets) > 0
+
+		attempt := int32(1)
+		exists := false
+		if current, found := latest[checkName]; found {
+			attempt = current.attempt
+			switch {
+			case !needed || !requestHasParamaters(current.request, prc):
 				// the check is not active or the parameters are out of sync
-				if err := c.client.Delete(ctx, req); client.IgnoreNotFound(err) != nil {
+				if err := c.client.Delete(ctx, current.request); client.IgnoreNotFound(err) != nil {
 					log.V(5).Error(err, "deleting the request", "check", checkName)
-					return err
+					return 0, err
 				}
-			} else {
-				requestExists[req.Name] = true
+			case isBookingExpired(current.request):
+				if attempt > maxRetries(prc) {
+					// the check is rejected by syncCheckStates
+					continue
+				}
+				backoff := retryBackoff(prc, attempt)
+				expiredAt := apimeta.FindStatusCondition(current.request.Status.Conditions, BookingExpired).LastTransitionTime
+				if remaining := backoff - time.Since(expiredAt.Time); remaining > 0 {
+					if requeueAfter == 0 || remaining < requeueAfter {
+						requeueAfter = remaining
+					}
+					continue
+				}
+				log.V(2).Info("Recreating the provisioning request after its booking expired", "provisioningRequest", klog.KObj(current.request), "attempt", attempt+1)
+				if err := c.client.Delete(ctx, current.request); client.IgnoreNotFound(err) != nil {
+					return 0, err
+				}
+				attempt++
+				metrics.ProvisioningRequestRetry(checkName, backoff)
+			default:
+				exists = true
 			}
 		}
-	}
 
-	// create the missing ones
-	for requestName, exists := range requestExists {
-		checkName := requestToCheckName[requestName]
-		if !requestNeededForCheck[checkName] {
-			continue
-		}
-		//get the config
-		prc, err := c.helper.ProvReqConfigForAdmissionCheck(ctx, checkName)
-		if err != nil {
-			// the check is not active
+		// create the missing one
+		if !needed {
 			continue
 		}
+		requestName := GetProvisioningRequestName(wl.Name, checkName, attempt)
 		if !exists {
 			req := &autoscaling.ProvisioningRequest{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      requestName,
 					Namespace: wl.Namespace,
+					Annotations: map[string]string{
+						CheckAnnotationKey:   checkName,
+						AttemptAnnotationKey: strconv.Itoa(int(attempt)),
+					},
 				},
 				Spec: autoscaling.ProvisioningRequestSpec{
 					ProvisioningClassName: prc.Spec.ProvisioningClassName,
@@ -195,42 +340,41 @@ func (c *Controller) syncOwnedProvisionRequest(ctx context.Context, wl *kueue.Wo
 				},
 			}
 
-			expectedPodSets := requiredPodSets(wl.Spec.PodSets, prc.Spec.ManagedResources)
 			psaMap := slices.ToRefMap(wl.Status.Admission.PodSetAssignments, func(p *kueue.PodSetAssignment) string { return p.Name })
 			podSetMap := slices.ToRefMap(wl.Spec.PodSets, func(ps *kueue.PodSet) string { return ps.Name })
 			for _, psName := range expectedPodSets {
 				ps, psFound := podSetMap[psName]
 				psa, psaFound := psaMap[psName]
 				if !psFound || !psaFound {
-					return errInconsistentPodSetAssignments
+					return 0, errInconsistentPodSetAssignments
 				}
 				req.Spec.PodSets = append(req.Spec.PodSets, autoscaling.PodSet{
 					PodTemplateRef: autoscaling.Reference{
-						Name: getProvisioningRequestPodTemplateName(wl.Name, checkName, psName),
+						Name: getProvisioningRequestPodTemplateName(wl.Name, checkName, psName, attempt),
 					},
 					Count: ptr.Deref(psa.Count, ps.Count),
 				})
 			}
 
 			if err := ctrl.SetControllerReference(wl, req, c.client.Scheme()); err != nil {
-				return err
+				return 0, err
 			}
 
 			if err := c.client.Create(ctx, req); err != nil {
-				return err
+				return 0, err
 			}
 		}
-		if err := c.syncProvisionRequestsPodTemplates(ctx, wl, checkName, prc); err != nil {
-			return err
+		if err := c.syncProvisionRequestsPodTemplates(ctx, wl, checkName, attempt, expectedPodSets); err != nil {
+			return 0, err
 		}
 	}
-	return nil
+	return requeueAfter, nil
 }
 
-func (c *Controller) syncProvisionRequestsPodTemplates(ctx context.Context, wl *kueue.Workload, checkName string, prc *kueue.ProvisioningRequestConfig) error {
+func (c *Controller) syncProvisionRequestsPodTemplates(ctx context.Context, wl *kueue.Workload, checkName string, attempt int32, expectedPodSets []string) error {
 	request := &autoscaling.ProvisioningRequest{}
 	requestKey := types.NamespacedName{
-		Name:      GetProvisioningRequestName(wl.Name, checkName),
+		Name:      GetProvisioningRequestName(wl.Name, checkName, attempt),
 		Namespace: wl.Namespace,
 	}
 	err := c.client.Get(ctx, requestKey, request)
@@ -238,9 +382,8 @@ func (c *Controller) syncProvisionRequestsPodTemplates(ctx context.Context, wl *
 		return client.IgnoreNotFound(err)
 	}
 
-	expectedPodSets := requiredPodSets(wl.Spec.PodSets, prc.Spec.ManagedResources)
 	podsetRefsMap := slices.ToMap(expectedPodSets, func(i int) (string, string) {
-		return getProvisioningRequestPodTemplateName(wl.Name, checkName, expectedPodSets[i]), expectedPodSets[i]
+		return getProvisioningRequestPodTemplateName(wl.Name, checkName, expectedPodSets[i], attempt), expectedPodSets[i]
 	})
 
 	// the order of the podSets should be the same in the workload and prov. req.
@@ -308,13 +451,41 @@ func (c *Controller) syncProvisionRequestsPodTemplates(ctx context.Context, wl *
 	return nil
 }
 
-func (c *Controller) requestIsNeeded(ctx context.Context, wl *kueue.Workload, checkName string) bool {
+func (c *Controller) requestIsNeeded(ctx context.Context, wl *kueue.Workload, checkName string) (bool, error) {
 	prc, err := c.helper.ProvReqConfigForAdmissionCheck(ctx, checkName)
 	if err != nil {
-		return false
+		return false, nil
 	}
-	expectdPodsets := requiredPodSets(wl.Spec.PodSets, prc.Spec.ManagedResources)
-	return len(expectdPodsets) > 0
+	expectdPodsets, err := c.expectedPodSets(ctx, wl, checkName, prc)
+	return len(expectdPodsets) > 0, err
+}
+
+// expectedPodSets returns the podSets to provision for the check: the ones
+// using the managed resources and, if the ClusterQueue scopes the check to
+// some flavors, assigned any of them.
+func (c *Controller) expectedPodSets(ctx context.Context, wl *kueue.Workload, checkName string, prc *kueue.ProvisioningRequestConfig) ([]string, error) {
+	podSets := requiredPodSets(wl.Spec.PodSets, prc.Spec.ManagedResources)
+	flavors, err := c.helper.FlavorsForCheck(ctx, wl, checkName)
+	if err != nil || flavors.Len() == 0 || wl.Status.Admission == nil {
+		return podSets, err
+	}
+	psaMap := slices.ToRefMap(wl.Status.Admission.PodSetAssignments, func(p *kueue.PodSetAssignment) string { return p.Name })
+	ret := make([]string, 0, len(podSets))
+	for _, psName := range podSets {
+		if psa, found := psaMap[psName]; found && assignedAnyFlavor(psa, flavors) {
+			ret = append(ret, psName)
+		}
+	}
+	return ret, nil
+}
+
+func assignedAnyFlavor(psa *kueue.PodSetAssignment, flavors sets.Set[kueue.ResourceFlavorReference]) bool {
+	for _, f := range psa.Flavors {
+		if flavors.Has(f) {
+			return true
+		}
+	}
+	return false
 }
 
 func requiredPodSets(podSets []kueue.PodSet, resources []corev1.ResourceName) []string {
@@ -380,19 +551,28 @@ func requestHasParamaters(req *autoscaling.ProvisioningRequest, prc *kueue.Provi
 }
 
 func (c *Controller) syncCheckStates(ctx context.Context, wl *kueue.Workload, checks []string) error {
+	log := ctrl.LoggerFrom(ctx)
+	owned, err := c.ownedRequests(ctx, wl)
+	if err != nil {
+		return err
+	}
+	latest, _ := latestRequests(wl.Name, checks, owned)
 	checksMap := slices.ToRefMap(wl.Status.AdmissionChecks, func(c *kueue.AdmissionCheckState) string { return c.Name })
 	wlPatch := workload.BaseSSAWorkload(wl)
 	updated := false
 	for _, check := range checks {
 		checkState := *checksMap[check]
-		if _, err := c.helper.ProvReqConfigForAdmissionCheck(ctx, check); err != nil {
+		prc, err := c.helper.ProvReqConfigForAdmissionCheck(ctx, check)
+		if err != nil {
 			// the check is not active
 			if checkState.State != kueue.CheckStatePending || checkState.Message != CheckInactiveMessage {
 				updated = true
 				checkState.State = kueue.CheckStatePending
 				checkState.Message = CheckInactiveMessage
 			}
-		} else if !c.requestIsNeeded(ctx, wl, check) {
+		} else if needed, err := c.requestIsNeeded(ctx, wl, check); err != nil {
+			return err
+		} else if !needed {
 			if checkState.State != kueue.CheckStateReady {
 				updated = true
 				checkState.State = kueue.CheckStateReady
@@ -400,10 +580,11 @@ func (c *Controller) syncCheckStates(ctx context.Context, wl *kueue.Workload, ch
 				checkState.PodSetUpdates = nil
 			}
 		} else {
-			pr := &autoscaling.ProvisioningRequest{}
-			if err := c.client.Get(ctx, types.NamespacedName{Namespace: wl.Namespace, Name: GetProvisioningRequestName(wl.Name, check)}, pr); err != nil {
-				return client.IgnoreNotFound(err)
+			current, found := latest[check]
+			if !found {
+				return nil
 			}
+			pr := current.request
 
 			prFailed := apimeta.IsStatusConditionTrue(pr.Status.Conditions, autoscaling.Failed)
 			prAccepted := apimeta.IsStatusConditionTrue(pr.Status.Conditions, autoscaling.Provisioned)
@@ -416,17 +597,48 @@ func (c *Controller) syncCheckStates(ctx context.Context, wl *kueue.Workload, ch
 					checkState.State = kueue.CheckStateRejected
 					checkState.Message = apimeta.FindStatusCondition(pr.Status.Conditions, autoscaling.Failed).Message
 				}
+			case isCapacityRevoked(pr):
+				// evict the workload, the request is recreated once it reserves quota again
+				if checkState.State != kueue.CheckStateRetry {
+					updated = true
+					log.V(2).Info("The capacity of the provisioning request was revoked", "provisioningRequest", klog.KObj(pr))
+					checkState.State = kueue.CheckStateRetry
+					checkState.Message = fmt.Sprintf("the capacity of provisioning request %s was revoked", pr.Name)
+					checkState.PodSetUpdates = nil
+					metrics.ProvisioningRequestCapacityRevoked(check)
+				}
+			case isBookingExpired(pr) && !workload.IsAdmitted(wl):
+				// the pods didn't start, the request is recreated after a backoff
+				state := kueue.CheckStatePending
+				message := fmt.Sprintf("the booking of provisioning request %s expired, retrying after %s (attempt %d of %d)",
+					pr.Name, retryBackoff(prc, current.attempt), current.attempt+1, maxRetries(prc)+1)
+				if current.attempt > maxRetries(prc) {
+					state = kueue.CheckStateRejected
+					message = fmt.Sprintf("the booking of provisioning request %s expired after %d attempts", pr.Name, current.attempt)
+				}
+				if checkState.State != state || checkState.Message != message {
+					updated = true
+					checkState.State = state
+					checkState.Message = message
+					checkState.PodSetUpdates = nil
+				}
 			case prAccepted || prAvaiable:
 				if checkState.State != kueue.CheckStateReady {
 					updated = true
 					checkState.State = kueue.CheckStateReady
+					checkState.Message = ""
 					// add the pod podSetUpdates
-					checkState.PodSetUpdates = podSetUpdates(wl, pr, check)
+					checkState.PodSetUpdates = podSetUpdates(wl, current)
 				}
 			default:
-				if checkState.State != kueue.CheckStatePending {
+				message := ""
+				if current.attempt > 1 {
+					message = fmt.Sprintf("waiting for provisioning request %s (attempt %d of %d)", pr.Name, current.attempt, maxRetries(prc)+1)
+				}
+				if checkState.State != kueue.CheckStatePending || checkState.Message != message {
 					updated = true
 					checkState.State = kueue.CheckStatePending
+					checkState.Message = message
 				}
 			}
 		}
@@ -438,10 +650,11 @@ func (c *Controller) syncCheckStates(ctx context.Context, wl *kueue.Workload, ch
 	return nil
 }
 
-func podSetUpdates(wl *kueue.Workload, pr *autoscaling.ProvisioningRequest, checkName string) []kueue.PodSetUpdate {
+func podSetUpdates(wl *kueue.Workload, current requestAttempt) []kueue.PodSetUpdate {
+	pr := current.request
 	podSets := wl.Spec.PodSets
 	refMap := slices.ToMap(podSets, func(i int) (string, string) {
-		return getProvisioningRequestPodTemplateName(wl.Name, checkName, podSets[i].Name), podSets[i].Name
+		return getProvisioningRequestPodTemplateName(wl.Name, current.check, podSets[i].Name, current.attempt), podSets[i].Name
 	})
 	return slices.Map(pr.Spec.PodSets, func(ps *autoscaling.PodSet) kueue.PodSetUpdate {
 		return kueue.PodSetUpdate{
@@ -626,16 +839,41 @@ func (c *Controller) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(acReconciler)
 }
 
-func GetProvisioningRequestName(workloadName, checkName string) string {
+// GetProvisioningRequestName returns the name of the ProvisioningRequest
+// created for the attempt of the check. The first attempt has no suffix, the
+// retries are suffixed with the attempt and a hash. The attempt is only
+// recorded in the annotations of the ProvisioningRequest, as the names of
+// different checks and attempts could be equal.
+func GetProvisioningRequestName(workloadName, checkName string, attempt int32) string {
 	fullName := fmt.Sprintf("%s-%s", workloadName, checkName)
+	if attempt > 1 {
+		return hashedObjectName(fmt.Sprintf("%s-%d", fullName, attempt), workloadName, checkName, strconv.Itoa(int(attempt)))
+	}
 	return limitObjectName(fullName)
 }
 
-func getProvisioningRequestPodTemplateName(workloadName, checkName, podsetName string) string {
+func getProvisioningRequestPodTemplateName(workloadName, checkName, podsetName string, attempt int32) string {
+	if attempt > 1 {
+		return limitObjectName(fmt.Sprintf("%s-%s-%s", podTemplatesPrefix, GetProvisioningRequestName(workloadName, checkName, attempt), podsetName))
+	}
 	fullName := fmt.Sprintf("%s-%s-%s-%s", podTemplatesPrefix, workloadName, checkName, podsetName)
 	return limitObjectName(fullName)
 }
 
+// hashedObjectName returns the name, limited in length, with a hash of the
+// parts appended.
+func hashedObjectName(name string, parts ...string) string {
+	if len(name) > objNameMaxPrefixLength {
+		name = name[:objNameMaxPrefixLength]
+	}
+	h := sha1.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte("\n"))
+	}
+	return fmt.Sprintf("%s-%s", name, hex.EncodeToString(h.Sum(nil))[:objNameHashLength])
+}
+
 func limitObjectName(fullName string) string {
 	if len(fullName) <= objNameMaxPrefixLength {
 		return fullName
@@ -17,7 +17,10 @@ limitations under the License.
 package provisioning
 
 import (
+	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -70,6 +73,32 @@ func requestWithCondition(r *autoscaling.ProvisioningRequest, conditionType stri
 	return r
 }
 
+func requestWithConditionAt(r *autoscaling.ProvisioningRequest, name string, conditionType string, at time.Time) *autoscaling.ProvisioningRequest {
+	r = r.DeepCopy()
+	r.Name = name
+	apimeta.SetStatusCondition(&r.Status.Conditions, metav1.Condition{
+		Type:   autoscaling.Provisioned,
+		Status: metav1.ConditionTrue,
+	})
+	apimeta.SetStatusCondition(&r.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.NewTime(at),
+	})
+	return r
+}
+
+// retriedRequest returns a copy of the request of check1 for the attempt.
+func retriedRequest(r *autoscaling.ProvisioningRequest, attempt int32) *autoscaling.ProvisioningRequest {
+	r = r.DeepCopy()
+	r.Name = GetProvisioningRequestName("wl", "check1", attempt)
+	r.Annotations = map[string]string{
+		CheckAnnotationKey:   "check1",
+		AttemptAnnotationKey: strconv.Itoa(int(attempt)),
+	}
+	return r
+}
+
 func TestReconcile(t *testing.T) {
 	baseWorkload := utiltesting.MakeWorkload("wl", TestNamespace).
 		PodSets(
@@ -239,6 +268,7 @@ func TestReconcile(t *testing.T) {
 		checks               []kueue.AdmissionCheck
 		configs              []kueue.ProvisioningRequestConfig
 		flavors              []kueue.ResourceFlavor
+		clusterQueues        []kueue.ClusterQueue
 		workload             *kueue.Workload
 		wantReconcileError   error
 		wantWorkloads        map[string]*kueue.Workload
@@ -544,6 +574,285 @@ func TestReconcile(t *testing.T) {
 				baseTemplate2.Name: baseTemplate2.DeepCopy(),
 			},
 		},
+		"when the booking expired, within the backoff": {
+			workload: baseWorkload.DeepCopy(),
+			checks:   []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:  []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs:  []kueue.ProvisioningRequestConfig{*baseConfig.DeepCopy()},
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithConditionAt(baseRequest, "wl-check1", BookingExpired, time.Now()),
+			},
+			templates: []corev1.PodTemplate{*baseTemplate1.DeepCopy(), *baseTemplate2.DeepCopy()},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStatePending,
+						Message: "the booking of provisioning request wl-check1 expired, retrying after 1m0s (attempt 2 of 4)",
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+			wantRequests: map[string]*autoscaling.ProvisioningRequest{
+				"wl-check1": requestWithConditionAt(baseRequest, "wl-check1", BookingExpired, time.Now()),
+			},
+			wantRequestsNotFound: []string{GetProvisioningRequestName("wl", "check1", 2)},
+		},
+		"when the booking expired, after the backoff": {
+			workload: baseWorkload.DeepCopy(),
+			checks:   []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:  []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs:  []kueue.ProvisioningRequestConfig{*baseConfig.DeepCopy()},
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithConditionAt(baseRequest, "wl-check1", BookingExpired, time.Now().Add(-2*time.Minute)),
+			},
+			templates: []corev1.PodTemplate{*baseTemplate1.DeepCopy(), *baseTemplate2.DeepCopy()},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStatePending,
+						Message: fmt.Sprintf("waiting for provisioning request %s (attempt 2 of 4)", GetProvisioningRequestName("wl", "check1", 2)),
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+			wantRequests: map[string]*autoscaling.ProvisioningRequest{
+				GetProvisioningRequestName("wl", "check1", 2): {
+					Spec: autoscaling.ProvisioningRequestSpec{
+						PodSets: []autoscaling.PodSet{
+							{
+								PodTemplateRef: autoscaling.Reference{
+									Name: getProvisioningRequestPodTemplateName("wl", "check1", "ps1", 2),
+								},
+								Count: 4,
+							},
+							{
+								PodTemplateRef: autoscaling.Reference{
+									Name: getProvisioningRequestPodTemplateName("wl", "check1", "ps2", 2),
+								},
+								Count: 3,
+							},
+						},
+						ProvisioningClassName: "class1",
+						Parameters: map[string]autoscaling.Parameter{
+							"p1": "v1",
+						},
+					},
+				},
+			},
+			wantTemplates: map[string]*corev1.PodTemplate{
+				getProvisioningRequestPodTemplateName("wl", "check1", "ps1", 2): baseTemplate1.DeepCopy(),
+				getProvisioningRequestPodTemplateName("wl", "check1", "ps2", 2): baseTemplate2.DeepCopy(),
+			},
+			wantRequestsNotFound: []string{"wl-check1"},
+		},
+		"when the booking expired on the last attempt": {
+			workload: baseWorkload.DeepCopy(),
+			checks:   []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:  []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs:  []kueue.ProvisioningRequestConfig{*baseConfig.DeepCopy()},
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithConditionAt(retriedRequest(baseRequest, 3), GetProvisioningRequestName("wl", "check1", 3), BookingExpired, time.Now().Add(-time.Hour)),
+				*requestWithConditionAt(retriedRequest(baseRequest, 4), GetProvisioningRequestName("wl", "check1", 4), BookingExpired, time.Now().Add(-time.Hour)),
+			},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStateRejected,
+						Message: fmt.Sprintf("the booking of provisioning request %s expired after 4 attempts", GetProvisioningRequestName("wl", "check1", 4)),
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+			wantRequestsNotFound: []string{GetProvisioningRequestName("wl", "check1", 3), GetProvisioningRequestName("wl", "check1", 5)},
+		},
+		"when the booking expired, with a custom retry strategy": {
+			workload: baseWorkload.DeepCopy(),
+			checks:   []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:  []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs: func() []kueue.ProvisioningRequestConfig {
+				cfg := baseConfig.DeepCopy()
+				cfg.Spec.RetryStrategy = &kueue.ProvisioningRequestRetryStrategy{
+					BackoffLimitCount:  ptr.To[int32](1),
+					BackoffBaseSeconds: ptr.To[int32](10),
+				}
+				return []kueue.ProvisioningRequestConfig{*cfg}
+			}(),
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithConditionAt(baseRequest, "wl-check1", BookingExpired, time.Now()),
+			},
+			templates: []corev1.PodTemplate{*baseTemplate1.DeepCopy(), *baseTemplate2.DeepCopy()},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStatePending,
+						Message: "the booking of provisioning request wl-check1 expired, retrying after 10s (attempt 2 of 2)",
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+		},
+		"when the booking expired after the admission": {
+			workload: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:  "check1",
+					State: kueue.CheckStateReady,
+				}, kueue.AdmissionCheckState{
+					Name:  "not-provisioning",
+					State: kueue.CheckStateReady,
+				}).
+				Admitted(true).
+				Obj(),
+			checks:  []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors: []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs: []kueue.ProvisioningRequestConfig{*baseConfig.DeepCopy()},
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithConditionAt(baseRequest, "wl-check1", BookingExpired, time.Now()),
+			},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:  "check1",
+						State: kueue.CheckStateReady,
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStateReady,
+					}).
+					Admitted(true).
+					Obj(),
+			},
+		},
+		"when the capacity is revoked": {
+			workload: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:  "check1",
+					State: kueue.CheckStateReady,
+				}, kueue.AdmissionCheckState{
+					Name:  "not-provisioning",
+					State: kueue.CheckStateReady,
+				}).
+				Admitted(true).
+				Obj(),
+			checks:  []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors: []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs: []kueue.ProvisioningRequestConfig{*baseConfig.DeepCopy()},
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithConditionAt(baseRequest, "wl-check1", CapacityRevoked, time.Now()),
+			},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStateRetry,
+						Message: "the capacity of provisioning request wl-check1 was revoked",
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStateReady,
+					}).
+					Admitted(true).
+					Obj(),
+			},
+		},
+		"retry check reset after the eviction": {
+			workload: utiltesting.MakeWorkload("wl", TestNamespace).
+				AdmissionChecks(kueue.AdmissionCheckState{
+					Name:    "check1",
+					State:   kueue.CheckStateRetry,
+					Message: "the capacity of provisioning request wl-check1 was revoked",
+				}, kueue.AdmissionCheckState{
+					Name:  "not-provisioning",
+					State: kueue.CheckStatePending,
+				}).
+				Obj(),
+			checks:  []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			configs: []kueue.ProvisioningRequestConfig{*baseConfig.DeepCopy()},
+			requests: []autoscaling.ProvisioningRequest{
+				*requestWithConditionAt(baseRequest, "wl-check1", CapacityRevoked, time.Now()),
+			},
+			wantWorkloads: map[string]*kueue.Workload{
+				"wl": utiltesting.MakeWorkload("wl", TestNamespace).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStatePending,
+						Message: "the capacity of provisioning request wl-check1 was revoked",
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+			wantRequestsNotFound: []string{"wl-check1"},
+		},
+		"when the check is scoped to a flavor": {
+			workload: baseWorkload.DeepCopy(),
+			checks:   []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:  []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs:  []kueue.ProvisioningRequestConfig{*baseConfig.DeepCopy()},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("q1").
+					AdmissionCheckStrategy(kueue.AdmissionCheckStrategyRule{Name: "check1", OnFlavors: []kueue.ResourceFlavorReference{"flv2"}}).
+					Obj(),
+			},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: baseWorkload.DeepCopy(),
+			},
+			wantRequests: map[string]*autoscaling.ProvisioningRequest{
+				"wl-check1": {
+					Spec: autoscaling.ProvisioningRequestSpec{
+						PodSets: []autoscaling.PodSet{
+							{
+								PodTemplateRef: autoscaling.Reference{
+									Name: "ppt-wl-check1-ps2",
+								},
+								Count: 3,
+							},
+						},
+						ProvisioningClassName: "class1",
+						Parameters: map[string]autoscaling.Parameter{
+							"p1": "v1",
+						},
+					},
+				},
+			},
+			wantTemplates: map[string]*corev1.PodTemplate{
+				baseTemplate2.Name: baseTemplate2.DeepCopy(),
+			},
+		},
+		"when the check is scoped to flavors that are not assigned": {
+			workload: baseWorkload.DeepCopy(),
+			checks:   []kueue.AdmissionCheck{*baseCheck.DeepCopy()},
+			flavors:  []kueue.ResourceFlavor{*baseFlavor1.DeepCopy(), *baseFlavor2.DeepCopy()},
+			configs:  []kueue.ProvisioningRequestConfig{*baseConfig.DeepCopy()},
+			clusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("q1").
+					AdmissionCheckStrategy(kueue.AdmissionCheckStrategyRule{Name: "check1", OnFlavors: []kueue.ResourceFlavorReference{"flv3"}}).
+					Obj(),
+			},
+			wantWorkloads: map[string]*kueue.Workload{
+				baseWorkload.Name: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
+					AdmissionChecks(kueue.AdmissionCheckState{
+						Name:    "check1",
+						State:   kueue.CheckStateReady,
+						Message: NoRequestNeeded,
+					}, kueue.AdmissionCheckState{
+						Name:  "not-provisioning",
+						State: kueue.CheckStatePending,
+					}).
+					Obj(),
+			},
+			wantRequestsNotFound: []string{"wl-check1"},
+		},
 		"when the request is removed while the check is ready": {
 			workload: (&utiltesting.WorkloadWrapper{Workload: *baseWorkload.DeepCopy()}).
 				AdmissionChecks(kueue.AdmissionCheckState{
@@ -577,6 +886,7 @@ func TestReconcile(t *testing.T) {
 				&kueue.ProvisioningRequestConfigList{Items: tc.configs},
 				&kueue.AdmissionCheckList{Items: tc.checks},
 				&kueue.ResourceFlavorList{Items: tc.flavors},
+				&kueue.ClusterQueueList{Items: tc.clusterQueues},
 			)
 
 			k8sclient := builder.Build()
@@ -640,3 +950,95 @@ func TestReconcile(t *testing.T) {
 	}
 
 }
+
+func TestLatestRequests(t *testing.T) {
+	request := func(name string, annotations map[string]string) autoscaling.ProvisioningRequest {
+		return autoscaling.ProvisioningRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: annotations,
+			},
+		}
+	}
+	attempt := func(check string, attempt int32) map[string]string {
+		return map[string]string{
+			CheckAnnotationKey:   check,
+			AttemptAnnotationKey: strconv.Itoa(int(attempt)),
+		}
+	}
+	cases := map[string]struct {
+		checks     []string
+		requests   []autoscaling.ProvisioningRequest
+		wantLatest map[string]string
+		wantStale  []string
+	}{
+		"requests without annotations": {
+			checks: []string{"check1"},
+			requests: []autoscaling.ProvisioningRequest{
+				request("wl-check1", nil),
+				request("wl-check2", nil),
+			},
+			wantLatest: map[string]string{"check1": "wl-check1"},
+			wantStale:  []string{"wl-check2"},
+		},
+		"the latest attempt": {
+			checks: []string{"check1"},
+			requests: []autoscaling.ProvisioningRequest{
+				request("wl-check1", nil),
+				request(GetProvisioningRequestName("wl", "check1", 3), attempt("check1", 3)),
+				request(GetProvisioningRequestName("wl", "check1", 2), attempt("check1", 2)),
+			},
+			wantLatest: map[string]string{"check1": GetProvisioningRequestName("wl", "check1", 3)},
+			wantStale:  []string{"wl-check1", GetProvisioningRequestName("wl", "check1", 2)},
+		},
+		"a retry of a check named like another check": {
+			checks: []string{"a", "a-2"},
+			requests: []autoscaling.ProvisioningRequest{
+				request(GetProvisioningRequestName("wl", "a", 2), attempt("a", 2)),
+				request(GetProvisioningRequestName("wl", "a-2", 1), attempt("a-2", 1)),
+			},
+			wantLatest: map[string]string{
+				"a":   GetProvisioningRequestName("wl", "a", 2),
+				"a-2": "wl-a-2",
+			},
+		},
+		"invalid attempt": {
+			checks: []string{"check1"},
+			requests: []autoscaling.ProvisioningRequest{
+				request("wl-check1-x", map[string]string{CheckAnnotationKey: "check1", AttemptAnnotationKey: "x"}),
+			},
+			wantStale: []string{"wl-check1-x"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			latest, stale := latestRequests("wl", tc.checks, tc.requests)
+			gotLatest := make(map[string]string, len(latest))
+			for check, current := range latest {
+				gotLatest[check] = current.request.Name
+			}
+			gotStale := make([]string, len(stale))
+			for i := range stale {
+				gotStale[i] = stale[i].Name
+			}
+			if diff := cmp.Diff(tc.wantLatest, gotLatest, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected latest requests (-want/+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantStale, gotStale, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("unexpected stale requests (-want/+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetProvisioningRequestName(t *testing.T) {
+	if got := GetProvisioningRequestName("wl", "check1", 1); got != "wl-check1" {
+		t.Errorf("unexpected name of the first attempt %q, want %q", got, "wl-check1")
+	}
+	if got := getProvisioningRequestPodTemplateName("wl", "check1", "ps1", 1); got != "ppt-wl-check1-ps1" {
+		t.Errorf("unexpected pod template name of the first attempt %q, want %q", got, "ppt-wl-check1-ps1")
+	}
+	if retry, other := GetProvisioningRequestName("wl", "a", 2), GetProvisioningRequestName("wl", "a-2", 1); retry == other {
+		t.Errorf("the retry of check a has the same name %q as the first attempt of check a-2", retry)
+	}
+}
@@ -21,9 +21,11 @@ import (
 	"errors"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 )
 
@@ -72,6 +74,19 @@ func (c *storeHelper) ProvReqConfigForAdmissionCheck(ctx context.Context, checkN
 	return c.ProvReqConfig(ctx, ac.Spec.Parameters.Name)
 }
 
+// FlavorsForCheck - returns the flavors that the check applies to in the ClusterQueue that
+// reserved quota for the workload. An empty set means all the flavors.
+func (c *storeHelper) FlavorsForCheck(ctx context.Context, wl *kueue.Workload, checkName string) (sets.Set[kueue.ResourceFlavorReference], error) {
+	if wl.Status.Admission == nil {
+		return nil, nil
+	}
+	cq := &kueue.ClusterQueue{}
+	if err := c.client.Get(ctx, types.NamespacedName{Name: string(wl.Status.Admission.ClusterQueue)}, cq); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return admissioncheck.FlavorsByCheck(cq)[checkName], nil
+}
+
 // ProvReqConfig - returns the config identified by its name
 func (c *storeHelper) ProvReqConfig(ctx context.Context, name string) (*kueue.ProvisioningRequestConfig, error) {
 	cfg := &kueue.ProvisioningRequestConfig{}
@@ -41,4 +41,29 @@ const (
 	// workloadPriorityClass name.
 	// This label is always mutable because it might be useful for the preemption.
 	WorkloadPriorityClassLabel = "kueue.x-k8s.io/priority-class"
+
+	// ReservationLabel is the label key in the job and the workload that holds
+	// the name of the Reservation whose quota the workload can use.
+	ReservationLabel = "kueue.x-k8s.io/reservation"
+	// FlavorConsistentPodSetsAnnotation is the annotation key in the job that
+	// holds a comma separated list of names of PodSets that must be assigned
+	// consistent flavors. It is copied into the podSetFlavorGroups of the
+	// workload.
+	FlavorConsistentPodSetsAnnotation = "kueue.x-k8s.io/flavor-consistent-podsets"
+
+	// FlavorConsistencyNodeLabelAnnotation is the annotation key in the job that
+	// holds the node label whose value must be the same in the flavors assigned
+	// to the PodSets listed in FlavorConsistentPodSetsAnnotation.
+	// When not set, the PodSets must be assigned the same flavors.
+	FlavorConsistencyNodeLabelAnnotation = "kueue.x-k8s.io/flavor-consistency-node-label"
+
+	// FlavorEnvAnnotation is the annotation key in the pod template that holds,
+	// as a JSON object keyed by container name, the names of the environment
+	// variables added to each container from the podTemplateOverrides of the
+	// assigned ResourceFlavors.
+	FlavorEnvAnnotation = "kueue.x-k8s.io/flavor-env"
+
+	// DiscoveredResourceFlavorLabel is the label key in the ResourceFlavors
+	// that are created from the labels of the Nodes.
+	DiscoveredResourceFlavorLabel = "kueue.x-k8s.io/discovered-from-nodes"
 )
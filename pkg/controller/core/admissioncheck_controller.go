@@ -34,7 +34,7 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/queue"
-	"sigs.k8s.io/kueue/pkg/util/slices"
+	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
 )
 
 type AdmissionCheckUpdateWatcher interface {
@@ -49,6 +49,7 @@ type AdmissionCheckReconciler struct {
 	cache      *cache.Cache
 	cqUpdateCh chan event.GenericEvent
 	watchers   []AdmissionCheckUpdateWatcher
+	leader     *leaderGate
 }
 
 func NewAdmissionCheckReconciler(
@@ -72,6 +73,9 @@ func NewAdmissionCheckReconciler(
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *AdmissionCheckReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.leader.hold(req) {
+		return ctrl.Result{}, nil
+	}
 	ac := &kueue.AdmissionCheck{}
 
 	if err := r.client.Get(ctx, req.NamespacedName, ac); err != nil {
@@ -169,7 +173,7 @@ func (r *AdmissionCheckReconciler) Generic(e event.GenericEvent) bool {
 func (r *AdmissionCheckReconciler) NotifyClusterQueueUpdate(oldCq *kueue.ClusterQueue, newCq *kueue.ClusterQueue) {
 	log := r.log.WithValues("oldClusterQueue", klog.KObj(oldCq), "newClusterQueue", klog.KObj(newCq))
 	log.V(5).Info("Cluster queue notification")
-	noChange := newCq != nil && oldCq != nil && slices.CmpNoOrder(oldCq.Spec.AdmissionChecks, newCq.Spec.AdmissionChecks)
+	noChange := newCq != nil && oldCq != nil && admissioncheck.CheckNames(oldCq).Equal(admissioncheck.CheckNames(newCq))
 	if noChange {
 		return
 	}
@@ -201,7 +205,7 @@ func (h *acCqHandler) Generic(ctx context.Context, e event.GenericEvent, q workq
 	log := log.FromContext(ctx).WithValues("clusterQueue", klog.KObj(cq))
 	log.V(6).Info("Cluster queue generic event")
 
-	for _, ac := range cq.Spec.AdmissionChecks {
+	for ac := range admissioncheck.CheckNames(cq) {
 		if cqs := h.cache.ClusterQueuesUsingAdmissionCheck(ac); len(cqs) == 0 {
 			req := reconcile.Request{
 				NamespacedName: types.NamespacedName{
@@ -215,12 +219,15 @@ func (h *acCqHandler) Generic(ctx context.Context, e event.GenericEvent, q workq
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *AdmissionCheckReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.leader = newLeaderGate(mgr.Elected())
 	handler := acCqHandler{
 		cache: r.cache,
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.AdmissionCheck{}).
 		WatchesRawSource(&source.Channel{Source: r.cqUpdateCh}, &handler).
+		WatchesRawSource(r.leader.source(), nil).
+		WithOptions(r.leader.controllerOptions()).
 		WithEventFilter(r).
 		Complete(r)
 }
@@ -18,6 +18,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -30,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -43,12 +45,21 @@ import (
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
 	"sigs.k8s.io/kueue/pkg/util/resource"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
-const snapshotWorkers = 5
+const (
+	snapshotWorkers = 5
+
+	// timeOfDayLayout is the layout of the times of the day in the quota
+	// schedules.
+	timeOfDayLayout = "15:04"
+)
+
+var errNoQuotaScheduleWindow = errors.New("no window found for the quota schedule")
 
 type ClusterQueueUpdateWatcher interface {
 	NotifyClusterQueueUpdate(*kueue.ClusterQueue, *kueue.ClusterQueue)
@@ -69,6 +80,11 @@ type ClusterQueueReconciler struct {
 	reportResourceMetrics                bool
 	queueVisibilityUpdateInterval        time.Duration
 	queueVisibilityClusterQueuesMaxCount int32
+	clock                                clock.Clock
+	leader                               *leaderGate
+
+	// applyEviction can be overridden in tests.
+	applyEviction func(context.Context, *kueue.Workload) error
 }
 
 type ClusterQueueReconcilerOptions struct {
@@ -121,7 +137,7 @@ func NewClusterQueueReconciler(
 	for _, opt := range opts {
 		opt(&options)
 	}
-	return &ClusterQueueReconciler{
+	r := &ClusterQueueReconciler{
 		client:                               client,
 		log:                                  ctrl.Log.WithName("cluster-queue-reconciler"),
 		qManager:                             qMgr,
@@ -135,7 +151,14 @@ func NewClusterQueueReconciler(
 		reportResourceMetrics:                options.ReportResourceMetrics,
 		queueVisibilityUpdateInterval:        options.QueueVisibilityUpdateInterval,
 		queueVisibilityClusterQueuesMaxCount: options.QueueVisibilityClusterQueuesMaxCount,
+		clock:                                realClock,
 	}
+	r.applyEviction = r.applyEvictionWithSSA
+	return r
+}
+
+func (r *ClusterQueueReconciler) applyEvictionWithSSA(ctx context.Context, wl *kueue.Workload) error {
+	return workload.ApplyAdmissionStatus(ctx, r.client, wl, true)
 }
 
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
@@ -143,6 +166,7 @@ func NewClusterQueueReconciler(
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=clusterqueues,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=clusterqueues/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=clusterqueues/finalizers,verbs=update
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
 
 func (r *ClusterQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	var cqObj kueue.ClusterQueue
@@ -154,6 +178,25 @@ func (r *ClusterQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	ctx = ctrl.LoggerInto(ctx, log)
 	log.V(2).Info("Reconciling ClusterQueue")
 
+	// The termination and the quota schedules are kept up to date in the
+	// cache of the followers too.
+	if !cqObj.ObjectMeta.DeletionTimestamp.IsZero() && !r.cache.ClusterQueueTerminating(cqObj.Name) {
+		r.cache.TerminateClusterQueue(cqObj.Name)
+	}
+	now := r.clock.Now()
+	activeSchedules, nextScheduleChange := activeQuotaSchedules(&cqObj, now)
+	scheduleNames := make(map[kueue.ResourceFlavorReference]string, len(activeSchedules))
+	for _, s := range activeSchedules {
+		scheduleNames[s.Flavor] = s.Name
+	}
+	if cqNames := r.cache.UpdateQuotaSchedules(&cqObj, scheduleNames); len(cqNames) > 0 {
+		log.V(2).Info("Quota schedules changed", "activeQuotaSchedules", scheduleNames)
+		r.qManager.QueueInadmissibleWorkloads(ctx, cqNames)
+	}
+	if r.leader.hold(req) {
+		return requeueAt(nextScheduleChange, now), nil
+	}
+
 	if cqObj.ObjectMeta.DeletionTimestamp.IsZero() {
 		// Although we'll add the finalizer via webhook mutation now, this is still useful
 		// as a fallback.
@@ -163,30 +206,142 @@ func (r *ClusterQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request
 				return ctrl.Result{}, client.IgnoreNotFound(err)
 			}
 		}
-	} else {
-		if !r.cache.ClusterQueueTerminating(cqObj.Name) {
-			r.cache.TerminateClusterQueue(cqObj.Name)
+	} else if controllerutil.ContainsFinalizer(&cqObj, kueue.ResourceInUseFinalizerName) {
+		// The clusterQueue is being deleted, remove the finalizer only if
+		// there are no active reserving workloads.
+		if r.cache.ClusterQueueEmpty(cqObj.Name) {
+			controllerutil.RemoveFinalizer(&cqObj, kueue.ResourceInUseFinalizerName)
+			if err := r.client.Update(ctx, &cqObj); err != nil {
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
 		}
+		return ctrl.Result{}, nil
+	}
 
-		if controllerutil.ContainsFinalizer(&cqObj, kueue.ResourceInUseFinalizerName) {
-			// The clusterQueue is being deleted, remove the finalizer only if
-			// there are no active reserving workloads.
-			if r.cache.ClusterQueueEmpty(cqObj.Name) {
-				controllerutil.RemoveFinalizer(&cqObj, kueue.ResourceInUseFinalizerName)
-				if err := r.client.Update(ctx, &cqObj); err != nil {
-					return ctrl.Result{}, client.IgnoreNotFound(err)
-				}
+	// Only a quota that was lowered, by a quota schedule or by a change in the
+	// spec, leads to evictions.
+	if shrinks, pending := r.cache.PendingQuotaShrink(cqObj.Name); pending {
+		if cqObj.Spec.QuotaShrinkPolicy == kueue.QuotaShrinkEvictOverQuota {
+			if err := r.evictOverQuota(ctx, cqObj.Name); err != nil {
+				return ctrl.Result{}, err
 			}
-			return ctrl.Result{}, nil
 		}
+		r.cache.QuotaShrinkHandled(cqObj.Name, shrinks)
 	}
 
 	newCQObj := cqObj.DeepCopy()
 	cqCondition, reason, msg := r.cache.ClusterQueueReadiness(newCQObj.Name)
-	if err := r.updateCqStatusIfChanged(ctx, newCQObj, cqCondition, reason, msg); err != nil {
+	if err := r.updateCqStatusIfChanged(ctx, newCQObj, cqCondition, reason, msg, activeSchedules); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
-	return ctrl.Result{}, nil
+	return requeueAt(nextScheduleChange, now), nil
+}
+
+// requeueAt returns the result to reconcile again at the given time, if any.
+func requeueAt(t, now time.Time) ctrl.Result {
+	if t.IsZero() {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: t.Sub(now)}
+}
+
+// evictOverQuota evicts the workloads of the ClusterQueue that don't fit
+// within its quota.
+func (r *ClusterQueueReconciler) evictOverQuota(ctx context.Context, cqName string) error {
+	log := ctrl.LoggerFrom(ctx)
+	snapshot := r.cache.Snapshot()
+	targets := preemption.OverQuotaTargets(&snapshot, cqName)
+	var errs []error
+	for _, target := range targets {
+		if meta.IsStatusConditionTrue(target.Obj.Status.Conditions, kueue.WorkloadEvicted) {
+			continue
+		}
+		wl := target.Obj.DeepCopy()
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByQuotaShrink, "Evicted to fit the reduced quota of the ClusterQueue")
+		if err := r.applyEviction(ctx, wl); err != nil {
+			errs = append(errs, client.IgnoreNotFound(err))
+			continue
+		}
+		log.V(3).Info("Evicted over quota", "targetWorkload", klog.KObj(wl))
+	}
+	return errors.Join(errs...)
+}
+
+// activeQuotaSchedules returns the quota schedules that apply to the flavors
+// of the ClusterQueue at the given time, and the next time at which they can
+// change. The time is zero if the ClusterQueue has no quota schedules.
+func activeQuotaSchedules(cq *kueue.ClusterQueue, now time.Time) ([]kueue.ActiveQuotaSchedule, time.Time) {
+	var active []kueue.ActiveQuotaSchedule
+	var next time.Time
+	updateNext := func(t time.Time) {
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	for _, rg := range cq.Spec.ResourceGroups {
+		for _, fQuotas := range rg.Flavors {
+			flavorActive := false
+			for i := range fQuotas.Schedules {
+				schedule := &fQuotas.Schedules[i]
+				start, end, err := quotaScheduleWindow(schedule, now)
+				if err != nil {
+					continue
+				}
+				if start.After(now) {
+					updateNext(start)
+					continue
+				}
+				updateNext(end)
+				if !flavorActive {
+					active = append(active, kueue.ActiveQuotaSchedule{
+						Flavor:  fQuotas.Name,
+						Name:    schedule.Name,
+						EndTime: metav1.NewTime(end),
+					})
+					flavorActive = true
+				}
+			}
+		}
+	}
+	return active, next
+}
+
+// quotaScheduleWindow returns the start and end of the window of the quota
+// schedule that is active at the given time or, if none is, of the next one.
+func quotaScheduleWindow(schedule *kueue.QuotaSchedule, now time.Time) (time.Time, time.Time, error) {
+	loc := time.UTC
+	if schedule.TimeZone != nil {
+		var err error
+		if loc, err = time.LoadLocation(*schedule.TimeZone); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	startOfDay, err := time.Parse(timeOfDayLayout, schedule.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	endOfDay, err := time.Parse(timeOfDayLayout, schedule.EndTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	days := sets.New(schedule.DaysOfWeek...)
+	local := now.In(loc)
+	// The window that started the day before might still be active.
+	for d := -1; d <= 7; d++ {
+		start := time.Date(local.Year(), local.Month(), local.Day()+d, startOfDay.Hour(), startOfDay.Minute(), 0, 0, loc)
+		if days.Len() > 0 && !days.Has(kueue.DayOfWeek(start.Weekday().String())) {
+			continue
+		}
+		endDay := local.Day() + d
+		if !endOfDay.After(startOfDay) {
+			endDay++
+		}
+		end := time.Date(local.Year(), local.Month(), endDay, endOfDay.Hour(), endOfDay.Minute(), 0, 0, loc)
+		if end.After(now) {
+			return start, end, nil
+		}
+	}
+	return time.Time{}, time.Time{}, errNoQuotaScheduleWindow
 }
 
 func (r *ClusterQueueReconciler) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
@@ -300,12 +455,22 @@ func (r *ClusterQueueReconciler) Update(e event.UpdateEvent) bool {
 	}
 	defer r.notifyWatchers(oldCq, newCq)
 
+	// The ClusterQueues blocked by the workloads of this one change with its
+	// waitForPodsReady, the ones no longer blocked have to be requeued.
+	var podsReadyScope sets.Set[string]
+	podsReadyChanged := !equality.Semantic.DeepEqual(oldCq.Spec.WaitForPodsReady, newCq.Spec.WaitForPodsReady)
+	if podsReadyChanged {
+		podsReadyScope = r.cache.PodsReadyScope(newCq.Name)
+	}
 	if err := r.cache.UpdateClusterQueue(newCq); err != nil {
 		log.Error(err, "Failed to update clusterQueue in cache")
 	}
 	if err := r.qManager.UpdateClusterQueue(context.Background(), newCq); err != nil {
 		log.Error(err, "Failed to update clusterQueue in queue manager")
 	}
+	if podsReadyChanged {
+		r.qManager.QueueInadmissibleWorkloads(context.Background(), podsReadyScope.Union(r.cache.PodsReadyScope(newCq.Name)))
+	}
 
 	if r.reportResourceMetrics {
 		updateResourceMetrics(oldCq, newCq)
@@ -595,6 +760,7 @@ func (h *cqSnapshotHandler) Generic(_ context.Context, e event.GenericEvent, q w
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterQueueReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.leader = newLeaderGate(mgr.Elected())
 	wHandler := cqWorkloadHandler{
 		qManager: r.qManager,
 	}
@@ -618,6 +784,8 @@ func (r *ClusterQueueReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		WatchesRawSource(&source.Channel{Source: r.rfUpdateCh}, &rfHandler).
 		WatchesRawSource(&source.Channel{Source: r.acUpdateCh}, &acHandler).
 		WatchesRawSource(&source.Channel{Source: r.snapUpdateCh}, &snapHandler).
+		WatchesRawSource(r.leader.source(), nil).
+		WithOptions(r.leader.controllerOptions()).
 		WithEventFilter(r).
 		Complete(r)
 }
@@ -627,6 +795,7 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 	cq *kueue.ClusterQueue,
 	conditionStatus metav1.ConditionStatus,
 	reason, msg string,
+	activeSchedules []kueue.ActiveQuotaSchedule,
 ) error {
 	oldStatus := cq.Status.DeepCopy()
 	pendingWorkloads := r.qManager.Pending(cq)
@@ -643,6 +812,7 @@ func (r *ClusterQueueReconciler) updateCqStatusIfChanged(
 	cq.Status.AdmittedWorkloads = int32(stats.AdmittedWorkloads)
 	cq.Status.PendingWorkloads = int32(pendingWorkloads)
 	cq.Status.PendingWorkloadsStatus = r.getWorkloadsStatus(cq)
+	cq.Status.ActiveQuotaSchedules = activeSchedules
 	meta.SetStatusCondition(&cq.Status.Conditions, metav1.Condition{
 		Type:    kueue.ClusterQueueActive,
 		Status:  conditionStatus,
@@ -676,6 +846,12 @@ func (r *ClusterQueueReconciler) getWorkloadsStatus(cq *kueue.ClusterQueue) *kue
 	return cq.Status.PendingWorkloadsStatus
 }
 
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so that the
+// snapshots of the pending workloads are up to date in all the replicas.
+func (r *ClusterQueueReconciler) NeedLeaderElection() bool {
+	return false
+}
+
 func (r *ClusterQueueReconciler) Start(ctx context.Context) error {
 	if !r.isVisibilityEnabled() {
 		return nil
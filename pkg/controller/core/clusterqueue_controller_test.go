@@ -26,8 +26,11 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
@@ -36,6 +39,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/queue"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingmetrics "sigs.k8s.io/kueue/pkg/util/testing/metrics"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 func TestUpdateCqStatusIfChanged(t *testing.T) {
@@ -200,7 +204,7 @@ func TestUpdateCqStatusIfChanged(t *testing.T) {
 			if tc.newWl != nil {
 				r.qManager.AddOrUpdateWorkload(tc.newWl)
 			}
-			err := r.updateCqStatusIfChanged(ctx, cq, tc.newConditionStatus, tc.newReason, tc.newMessage)
+			err := r.updateCqStatusIfChanged(ctx, cq, tc.newConditionStatus, tc.newReason, tc.newMessage, nil)
 			if err != nil {
 				t.Errorf("Updating ClusterQueueStatus: %v", err)
 			}
@@ -571,3 +575,367 @@ func TestClusterQueuePendingWorkloadsStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestActiveQuotaSchedules(t *testing.T) {
+	// A Monday.
+	now := time.Date(2023, time.October, 16, 22, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		now        time.Time
+		flavors    []kueue.FlavorQuotas
+		wantActive []kueue.ActiveQuotaSchedule
+		wantNext   time.Time
+	}{
+		"no schedules": {
+			now: now,
+			flavors: []kueue.FlavorQuotas{
+				*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj(),
+			},
+		},
+		"window across midnight is active": {
+			now: now,
+			flavors: []kueue.FlavorQuotas{
+				*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Schedule(*utiltesting.MakeQuotaSchedule("night", "20:00", "08:00").Resource(corev1.ResourceCPU, "2").Obj()).
+					Obj(),
+			},
+			wantActive: []kueue.ActiveQuotaSchedule{{
+				Flavor:  "default",
+				Name:    "night",
+				EndTime: metav1.NewTime(time.Date(2023, time.October, 17, 8, 0, 0, 0, time.UTC)),
+			}},
+			wantNext: time.Date(2023, time.October, 17, 8, 0, 0, 0, time.UTC),
+		},
+		"window not started": {
+			now: time.Date(2023, time.October, 16, 10, 0, 0, 0, time.UTC),
+			flavors: []kueue.FlavorQuotas{
+				*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Schedule(*utiltesting.MakeQuotaSchedule("night", "20:00", "08:00").Resource(corev1.ResourceCPU, "2").Obj()).
+					Obj(),
+			},
+			wantNext: time.Date(2023, time.October, 16, 20, 0, 0, 0, time.UTC),
+		},
+		"window started the day before": {
+			now: time.Date(2023, time.October, 16, 7, 0, 0, 0, time.UTC),
+			flavors: []kueue.FlavorQuotas{
+				*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Schedule(*utiltesting.MakeQuotaSchedule("sunday-night", "20:00", "08:00").
+						DaysOfWeek("Sunday").
+						Resource(corev1.ResourceCPU, "2").
+						Obj()).
+					Obj(),
+			},
+			wantActive: []kueue.ActiveQuotaSchedule{{
+				Flavor:  "default",
+				Name:    "sunday-night",
+				EndTime: metav1.NewTime(time.Date(2023, time.October, 16, 8, 0, 0, 0, time.UTC)),
+			}},
+			wantNext: time.Date(2023, time.October, 16, 8, 0, 0, 0, time.UTC),
+		},
+		"whole day window on another day of the week": {
+			now: now,
+			flavors: []kueue.FlavorQuotas{
+				*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Schedule(*utiltesting.MakeQuotaSchedule("weekend", "00:00", "00:00").
+						DaysOfWeek("Saturday", "Sunday").
+						Resource(corev1.ResourceCPU, "2").
+						Obj()).
+					Obj(),
+			},
+			wantNext: time.Date(2023, time.October, 21, 0, 0, 0, 0, time.UTC),
+		},
+		"window in a time zone": {
+			now: time.Date(2023, time.October, 16, 8, 0, 0, 0, time.UTC),
+			flavors: []kueue.FlavorQuotas{
+				*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Schedule(*utiltesting.MakeQuotaSchedule("office", "09:00", "17:00").
+						TimeZone("Europe/Madrid").
+						Resource(corev1.ResourceCPU, "2").
+						Obj()).
+					Obj(),
+			},
+			wantActive: []kueue.ActiveQuotaSchedule{{
+				Flavor:  "default",
+				Name:    "office",
+				EndTime: metav1.NewTime(time.Date(2023, time.October, 16, 15, 0, 0, 0, time.UTC)),
+			}},
+			wantNext: time.Date(2023, time.October, 16, 15, 0, 0, 0, time.UTC),
+		},
+		"first active schedule applies": {
+			now: now,
+			flavors: []kueue.FlavorQuotas{
+				*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "10").
+					Schedule(*utiltesting.MakeQuotaSchedule("late", "21:00", "23:00").Resource(corev1.ResourceCPU, "4").Obj()).
+					Schedule(*utiltesting.MakeQuotaSchedule("night", "20:00", "08:00").Resource(corev1.ResourceCPU, "2").Obj()).
+					Obj(),
+				*utiltesting.MakeFlavorQuotas("other").
+					Resource(corev1.ResourceCPU, "10").
+					Schedule(*utiltesting.MakeQuotaSchedule("morning", "06:00", "12:00").Resource(corev1.ResourceCPU, "2").Obj()).
+					Obj(),
+			},
+			wantActive: []kueue.ActiveQuotaSchedule{{
+				Flavor:  "default",
+				Name:    "late",
+				EndTime: metav1.NewTime(time.Date(2023, time.October, 16, 23, 0, 0, 0, time.UTC)),
+			}},
+			wantNext: time.Date(2023, time.October, 16, 23, 0, 0, 0, time.UTC),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cq := utiltesting.MakeClusterQueue("cq").ResourceGroup(tc.flavors...).Obj()
+			gotActive, gotNext := activeQuotaSchedules(cq, tc.now)
+			if diff := cmp.Diff(tc.wantActive, gotActive); diff != "" {
+				t.Errorf("Unexpected active schedules (-want,+got):\n%s", diff)
+			}
+			if !gotNext.Equal(tc.wantNext) {
+				t.Errorf("Unexpected next change %v, want %v", gotNext, tc.wantNext)
+			}
+		})
+	}
+}
+
+func TestClusterQueueReconcileQuotaSchedules(t *testing.T) {
+	now := time.Date(2023, time.October, 16, 22, 0, 0, 0, time.UTC)
+	admitted := func(name string, prio int32) kueue.Workload {
+		return *utiltesting.MakeWorkload(name, "ns").
+			Request(corev1.ResourceCPU, "4").
+			Priority(prio).
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			Obj()
+	}
+	workloads := []kueue.Workload{
+		admitted("high", 10),
+		admitted("mid", 5),
+		admitted("low", 0),
+	}
+	cases := map[string]struct {
+		policy      kueue.QuotaShrinkPolicy
+		wantEvicted []string
+	}{
+		"keep the workloads": {
+			policy: kueue.QuotaShrinkKeep,
+		},
+		"evict over quota": {
+			policy:      kueue.QuotaShrinkEvictOverQuota,
+			wantEvicted: []string{"ns/low", "ns/mid"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cq := utiltesting.MakeClusterQueue("cq").
+				QuotaShrinkPolicy(tc.policy).
+				ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+					Resource(corev1.ResourceCPU, "12").
+					Schedule(*utiltesting.MakeQuotaSchedule("night", "20:00", "08:00").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj()).
+				Obj()
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(cq).
+				WithStatusSubresource(cq).
+				WithLists(&kueue.WorkloadList{Items: workloads}).
+				Build()
+			cqCache := cache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in cache: %v", err)
+			}
+			qManager := queue.NewManager(cl, cqCache)
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in manager: %v", err)
+			}
+			r := NewClusterQueueReconciler(cl, qManager, cqCache)
+			r.clock = testingclock.NewFakeClock(now)
+			var gotEvicted []string
+			r.applyEviction = func(_ context.Context, wl *kueue.Workload) error {
+				gotEvicted = append(gotEvicted, workload.Key(wl))
+				return nil
+			}
+
+			result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "cq"}})
+			if err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+			if diff := cmp.Diff(ctrl.Result{RequeueAfter: 10 * time.Hour}, result); diff != "" {
+				t.Errorf("Unexpected result (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantEvicted, gotEvicted); diff != "" {
+				t.Errorf("Unexpected evicted workloads (-want,+got):\n%s", diff)
+			}
+
+			// The quota didn't change since the last reconcile.
+			gotEvicted = nil
+			if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "cq"}}); err != nil {
+				t.Fatalf("Second reconcile failed: %v", err)
+			}
+			if len(gotEvicted) != 0 {
+				t.Errorf("Unexpected evicted workloads in the second reconcile: %v", gotEvicted)
+			}
+
+			var gotCQ kueue.ClusterQueue
+			if err := cl.Get(ctx, types.NamespacedName{Name: "cq"}, &gotCQ); err != nil {
+				t.Fatalf("Failed getting the ClusterQueue: %v", err)
+			}
+			wantActive := []kueue.ActiveQuotaSchedule{{
+				Flavor:  "default",
+				Name:    "night",
+				EndTime: metav1.NewTime(time.Date(2023, time.October, 17, 8, 0, 0, 0, time.UTC)),
+			}}
+			if diff := cmp.Diff(wantActive, gotCQ.Status.ActiveQuotaSchedules); diff != "" {
+				t.Errorf("Unexpected active quota schedules (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClusterQueueReconcileQuotaShrink(t *testing.T) {
+	admitted := func(name string, prio int32) kueue.Workload {
+		return *utiltesting.MakeWorkload(name, "ns").
+			Request(corev1.ResourceCPU, "4").
+			Priority(prio).
+			ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			Obj()
+	}
+	workloads := []kueue.Workload{
+		admitted("high", 10),
+		admitted("mid", 5),
+		admitted("low", 0),
+	}
+	makeCQ := func(quota string) *kueue.ClusterQueue {
+		return utiltesting.MakeClusterQueue("cq").
+			QuotaShrinkPolicy(kueue.QuotaShrinkEvictOverQuota).
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, quota).Obj()).
+			Obj()
+	}
+	cases := map[string]struct {
+		cq          *kueue.ClusterQueue
+		updatedCQ   *kueue.ClusterQueue
+		wantEvicted []string
+	}{
+		"usage over the quota without a change of quota": {
+			cq: makeCQ("4"),
+		},
+		"quota raised": {
+			cq:        makeCQ("4"),
+			updatedCQ: makeCQ("8"),
+		},
+		"quota lowered": {
+			cq:          makeCQ("12"),
+			updatedCQ:   makeCQ("4"),
+			wantEvicted: []string{"ns/low", "ns/mid"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithObjects(tc.cq).
+				WithStatusSubresource(tc.cq).
+				WithLists(&kueue.WorkloadList{Items: workloads}).
+				Build()
+			cqCache := cache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, tc.cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in cache: %v", err)
+			}
+			if tc.updatedCQ != nil {
+				if err := cqCache.UpdateClusterQueue(tc.updatedCQ); err != nil {
+					t.Fatalf("Updating clusterQueue in cache: %v", err)
+				}
+			}
+			qManager := queue.NewManager(cl, cqCache)
+			if err := qManager.AddClusterQueue(ctx, tc.cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in manager: %v", err)
+			}
+			r := NewClusterQueueReconciler(cl, qManager, cqCache)
+			var gotEvicted []string
+			r.applyEviction = func(_ context.Context, wl *kueue.Workload) error {
+				gotEvicted = append(gotEvicted, workload.Key(wl))
+				return nil
+			}
+
+			if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "cq"}}); err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantEvicted, gotEvicted); diff != "" {
+				t.Errorf("Unexpected evicted workloads (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// TestClusterQueueReconcileFollower ensures that a follower applies the quota
+// schedules to its cache without writing to the API.
+func TestClusterQueueReconcileFollower(t *testing.T) {
+	now := time.Date(2023, time.October, 16, 22, 0, 0, 0, time.UTC)
+	cq := utiltesting.MakeClusterQueue("cq").
+		QuotaShrinkPolicy(kueue.QuotaShrinkEvictOverQuota).
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+			Resource(corev1.ResourceCPU, "12").
+			Schedule(*utiltesting.MakeQuotaSchedule("night", "20:00", "08:00").Resource(corev1.ResourceCPU, "4").Obj()).
+			Obj()).
+		Obj()
+	wl := utiltesting.MakeWorkload("a", "ns").
+		Request(corev1.ResourceCPU, "8").
+		ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "default", "8").Obj()).
+		Obj()
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().
+		WithObjects(cq, wl).
+		WithStatusSubresource(cq).
+		Build()
+	cqCache := cache.New(cl)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in cache: %v", err)
+	}
+	cqCache.AddOrUpdateWorkload(wl)
+	qManager := queue.NewManager(cl, cqCache)
+	if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Inserting clusterQueue in manager: %v", err)
+	}
+	r := NewClusterQueueReconciler(cl, qManager, cqCache)
+	r.clock = testingclock.NewFakeClock(now)
+	r.leader = newLeaderGate(make(chan struct{}))
+	var gotEvicted []string
+	r.applyEviction = func(_ context.Context, wl *kueue.Workload) error {
+		gotEvicted = append(gotEvicted, workload.Key(wl))
+		return nil
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "cq"}}
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if diff := cmp.Diff(ctrl.Result{RequeueAfter: 10 * time.Hour}, result); diff != "" {
+		t.Errorf("Unexpected result (-want,+got):\n%s", diff)
+	}
+	if len(gotEvicted) != 0 {
+		t.Errorf("Unexpected evicted workloads: %v", gotEvicted)
+	}
+	if !r.leader.held.Has(req) {
+		t.Errorf("The request isn't held until the election")
+	}
+	gotNominal := cqCache.Snapshot().ClusterQueues["cq"].ResourceGroups[0].Flavors[0].Resources[corev1.ResourceCPU].Nominal
+	if gotNominal != 4000 {
+		t.Errorf("Unexpected nominal quota in the cache, got %d, want 4000", gotNominal)
+	}
+
+	var gotCQ kueue.ClusterQueue
+	if err := cl.Get(ctx, req.NamespacedName, &gotCQ); err != nil {
+		t.Fatalf("Failed getting the ClusterQueue: %v", err)
+	}
+	if diff := cmp.Diff(cq.Status, gotCQ.Status); diff != "" {
+		t.Errorf("Unexpected status update (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(cq.Finalizers, gotCQ.Finalizers); diff != "" {
+		t.Errorf("Unexpected finalizers (-want,+got):\n%s", diff)
+	}
+}
@@ -39,7 +39,7 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 	if err := acRec.SetupWithManager(mgr); err != nil {
 		return "AdmissionCheck", err
 	}
-	qRec := NewLocalQueueReconciler(mgr.GetClient(), qManager, cc)
+	qRec := NewLocalQueueReconciler(mgr.GetClient(), qManager, cc, WithReportLocalQueueMetrics(cfg.Metrics.EnableLocalQueueMetrics))
 	if err := qRec.SetupWithManager(mgr); err != nil {
 		return "LocalQueue", err
 	}
@@ -61,9 +61,20 @@ func SetupControllers(mgr ctrl.Manager, qManager *queue.Manager, cc *cache.Cache
 	if err := cqRec.SetupWithManager(mgr); err != nil {
 		return "ClusterQueue", err
 	}
-	if err := NewWorkloadReconciler(mgr.GetClient(), qManager, cc, WithWorkloadUpdateWatchers(qRec, cqRec), WithPodsReadyTimeout(podsReadyTimeout(cfg))).SetupWithManager(mgr); err != nil {
+	if err := NewWorkloadReconciler(mgr.GetClient(), qManager, cc, WithWorkloadUpdateWatchers(qRec, cqRec), WithPodsReadyTimeout(podsReadyTimeout(cfg)), WithCapacityCheck(markNeverFitInadmissible(cfg))).SetupWithManager(mgr); err != nil {
 		return "Workload", err
 	}
+	if err := NewWorkloadPriorityClassReconciler(mgr.GetClient()).SetupWithManager(mgr); err != nil {
+		return "WorkloadPriorityClass", err
+	}
+	if err := NewReservationReconciler(mgr.GetClient(), qManager, cc).SetupWithManager(mgr); err != nil {
+		return "Reservation", err
+	}
+	if cfg.ResourceFlavorDiscovery != nil && cfg.ResourceFlavorDiscovery.Enable {
+		if err := NewResourceFlavorDiscoveryReconciler(mgr.GetClient(), cfg.ResourceFlavorDiscovery.NodeLabelKeys).SetupWithManager(mgr); err != nil {
+			return "ResourceFlavorDiscovery", err
+		}
+	}
 	return "", nil
 }
 
@@ -74,6 +85,10 @@ func podsReadyTimeout(cfg *config.Configuration) *time.Duration {
 	return nil
 }
 
+func markNeverFitInadmissible(cfg *config.Configuration) bool {
+	return cfg.CapacityCheck != nil && cfg.CapacityCheck.Action == config.CapacityCheckMarkInadmissible
+}
+
 func queueVisibilityUpdateInterval(cfg *config.Configuration) time.Duration {
 	if cfg.QueueVisibility != nil {
 		return time.Duration(cfg.QueueVisibility.UpdateIntervalSeconds) * time.Second
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/constants"
 )
 
 const (
@@ -36,6 +37,7 @@ const (
 	LimitRangeHasContainerType = "spec.hasContainerType"
 	WorkloadQuotaReservedKey   = "status.quotaReserved"
 	WorkloadRuntimeClassKey    = "spec.runtimeClass"
+	WorkloadPriorityClassKey   = "spec.workloadPriorityClass"
 )
 
 func IndexQueueClusterQueue(obj client.Object) []string {
@@ -110,6 +112,17 @@ func IndexWorkloadRuntimeClass(obj client.Object) []string {
 	return nil
 }
 
+func IndexWorkloadPriorityClass(obj client.Object) []string {
+	wl, ok := obj.(*kueue.Workload)
+	if !ok {
+		return nil
+	}
+	if wl.Spec.PriorityClassSource != constants.WorkloadPriorityClassSource || wl.Spec.PriorityClassName == "" {
+		return nil
+	}
+	return []string{wl.Spec.PriorityClassName}
+}
+
 // Setup sets the index with the given fields for core apis.
 func Setup(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadQueueKey, IndexWorkloadQueue); err != nil {
@@ -124,6 +137,9 @@ func Setup(ctx context.Context, indexer client.FieldIndexer) error {
 	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadRuntimeClassKey, IndexWorkloadRuntimeClass); err != nil {
 		return fmt.Errorf("setting index on runtimeClass for Workload: %w", err)
 	}
+	if err := indexer.IndexField(ctx, &kueue.Workload{}, WorkloadPriorityClassKey, IndexWorkloadPriorityClass); err != nil {
+		return fmt.Errorf("setting index on workloadPriorityClass for Workload: %w", err)
+	}
 	if err := indexer.IndexField(ctx, &kueue.LocalQueue{}, QueueClusterQueueKey, IndexQueueClusterQueue); err != nil {
 		return fmt.Errorf("setting index on clusterQueue for localQueue: %w", err)
 	}
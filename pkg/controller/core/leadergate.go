@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// leaderGate lets a controller run in all the replicas, so that the event
+// filters keep the cache and the queues of the followers up to date, while
+// the requests that write to the API are held until the replica is elected
+// leader.
+//
+// A nil leaderGate holds no requests.
+type leaderGate struct {
+	elected <-chan struct{}
+
+	mu       sync.Mutex
+	released bool
+	held     sets.Set[reconcile.Request]
+}
+
+func newLeaderGate(elected <-chan struct{}) *leaderGate {
+	return &leaderGate{
+		elected: elected,
+		held:    sets.New[reconcile.Request](),
+	}
+}
+
+// hold returns whether the replica is still a follower, in which case the
+// request is reconciled again once the replica is elected.
+func (g *leaderGate) hold(req reconcile.Request) bool {
+	if g == nil {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.released {
+		return false
+	}
+	g.held.Insert(req)
+	return true
+}
+
+// isElected returns whether the replica is the leader.
+func (g *leaderGate) isElected() bool {
+	if g == nil {
+		return true
+	}
+	select {
+	case <-g.elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// release enqueues the held requests and stops holding new ones.
+func (g *leaderGate) release(q workqueue.Interface) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.released = true
+	for req := range g.held {
+		q.Add(req)
+	}
+	g.held = nil
+}
+
+// source returns the source that releases the gate when the replica is
+// elected. The event handler is not used.
+func (g *leaderGate) source() source.Source {
+	return source.Func(func(ctx context.Context, _ handler.EventHandler, q workqueue.RateLimitingInterface, _ ...predicate.Predicate) error {
+		go func() {
+			select {
+			case <-g.elected:
+				g.release(q)
+			case <-ctx.Done():
+			}
+		}()
+		return nil
+	})
+}
+
+// controllerOptions returns the options for the controller to start
+// without waiting for the election.
+func (g *leaderGate) controllerOptions() controller.Options {
+	return controller.Options{NeedLeaderElection: ptr.To(false)}
+}
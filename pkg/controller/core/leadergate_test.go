@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestLeaderGate(t *testing.T) {
+	var nilGate *leaderGate
+	if nilGate.hold(reconcile.Request{}) {
+		t.Errorf("A nil gate held a request")
+	}
+	if !nilGate.isElected() {
+		t.Errorf("A nil gate isn't elected")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	elected := make(chan struct{})
+	g := newLeaderGate(elected)
+	q := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+	defer q.ShutDown()
+	if err := g.source().Start(ctx, nil, q); err != nil {
+		t.Fatalf("Starting the source: %v", err)
+	}
+
+	a := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "a"}}
+	b := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "b"}}
+	for _, req := range []reconcile.Request{a, b, a} {
+		if !g.hold(req) {
+			t.Errorf("The request %v wasn't held before the election", req)
+		}
+	}
+	if q.Len() != 0 {
+		t.Errorf("Requests enqueued before the election: %d", q.Len())
+	}
+	if g.isElected() {
+		t.Errorf("The gate is elected before the election")
+	}
+
+	close(elected)
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, time.Second, true, func(context.Context) (bool, error) {
+		return q.Len() == 2, nil
+	}); err != nil {
+		t.Fatalf("The held requests weren't enqueued after the election, got %d", q.Len())
+	}
+	if g.hold(a) {
+		t.Errorf("A request was held after the election")
+	}
+	if !g.isElected() {
+		t.Errorf("The gate isn't elected after the election")
+	}
+}
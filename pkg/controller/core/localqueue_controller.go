@@ -37,7 +37,9 @@ import (
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/resource"
 )
 
 const (
@@ -52,19 +54,47 @@ type LocalQueueReconciler struct {
 	queues     *queue.Manager
 	cache      *cache.Cache
 	wlUpdateCh chan event.GenericEvent
+	// reportMetrics indicates if the per LocalQueue metrics are reported.
+	reportMetrics bool
+	leader        *leaderGate
 }
 
-func NewLocalQueueReconciler(client client.Client, queues *queue.Manager, cache *cache.Cache) *LocalQueueReconciler {
+type LocalQueueReconcilerOptions struct {
+	ReportMetrics bool
+}
+
+// LocalQueueReconcilerOption configures the reconciler.
+type LocalQueueReconcilerOption func(*LocalQueueReconcilerOptions)
+
+// WithReportLocalQueueMetrics indicates if the per LocalQueue metrics are reported.
+func WithReportLocalQueueMetrics(report bool) LocalQueueReconcilerOption {
+	return func(o *LocalQueueReconcilerOptions) {
+		o.ReportMetrics = report
+	}
+}
+
+var defaultLQOptions = LocalQueueReconcilerOptions{}
+
+func NewLocalQueueReconciler(client client.Client, queues *queue.Manager, cache *cache.Cache, opts ...LocalQueueReconcilerOption) *LocalQueueReconciler {
+	options := defaultLQOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 	return &LocalQueueReconciler{
-		log:        ctrl.Log.WithName("localqueue-reconciler"),
-		queues:     queues,
-		cache:      cache,
-		client:     client,
-		wlUpdateCh: make(chan event.GenericEvent, updateChBuffer),
+		log:           ctrl.Log.WithName("localqueue-reconciler"),
+		queues:        queues,
+		cache:         cache,
+		client:        client,
+		wlUpdateCh:    make(chan event.GenericEvent, updateChBuffer),
+		reportMetrics: options.ReportMetrics,
 	}
 }
 
 func (r *LocalQueueReconciler) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload) {
+	// The leader counts the evictions, as all the replicas get the updates.
+	if r.reportMetrics && newWl != nil && r.leader.isElected() {
+		reportEvictedWorkload(oldWl, newWl)
+	}
 	if oldWl != nil {
 		r.wlUpdateCh <- event.GenericEvent{Object: oldWl}
 		if newWl != nil && oldWl.Spec.QueueName != newWl.Spec.QueueName {
@@ -83,6 +113,9 @@ func (r *LocalQueueReconciler) NotifyWorkloadUpdate(oldWl, newWl *kueue.Workload
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=localqueues/finalizers,verbs=update
 
 func (r *LocalQueueReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.leader.hold(req) {
+		return ctrl.Result{}, nil
+	}
 	var queueObj kueue.LocalQueue
 	if err := r.client.Get(ctx, req.NamespacedName, &queueObj); err != nil {
 		// we'll ignore not-found errors, since there is nothing to do.
@@ -135,6 +168,9 @@ func (r *LocalQueueReconciler) Delete(e event.DeleteEvent) bool {
 	r.log.V(2).Info("LocalQueue delete event", "localQueue", klog.KObj(q))
 	r.queues.DeleteLocalQueue(q)
 	r.cache.DeleteLocalQueue(q)
+	if r.reportMetrics {
+		metrics.ClearLocalQueueMetrics(q.Name, q.Namespace)
+	}
 	return true
 }
 
@@ -249,6 +285,7 @@ func (h *qCQHandler) addLocalQueueToWorkQueue(ctx context.Context, cq *kueue.Clu
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *LocalQueueReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.leader = newLeaderGate(mgr.Elected())
 	queueCQHandler := qCQHandler{
 		client: r.client,
 	}
@@ -256,6 +293,8 @@ func (r *LocalQueueReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&kueue.LocalQueue{}).
 		WatchesRawSource(&source.Channel{Source: r.wlUpdateCh}, &qWorkloadHandler{}).
 		Watches(&kueue.ClusterQueue{}, &queueCQHandler).
+		WatchesRawSource(r.leader.source(), nil).
+		WithOptions(r.leader.controllerOptions()).
 		WithEventFilter(r).
 		Complete(r)
 }
@@ -282,6 +321,12 @@ func (r *LocalQueueReconciler) UpdateStatusIfChanged(
 	queue.Status.AdmittedWorkloads = int32(stats.AdmittedWorkloads)
 	queue.Status.FlavorsReservation = stats.ReservedResources
 	queue.Status.FlavorUsage = stats.AdmittedResources
+	if r.reportMetrics {
+		if err := r.reportLocalQueueMetrics(queue, stats); err != nil {
+			r.log.Error(err, failedUpdateLqStatusMsg)
+			return err
+		}
+	}
 	if len(conditionStatus) != 0 && len(reason) != 0 && len(msg) != 0 {
 		meta.SetStatusCondition(&queue.Status.Conditions, metav1.Condition{
 			Type:    kueue.LocalQueueActive,
@@ -295,3 +340,40 @@ func (r *LocalQueueReconciler) UpdateStatusIfChanged(
 	}
 	return nil
 }
+
+func (r *LocalQueueReconciler) reportLocalQueueMetrics(queue *kueue.LocalQueue, stats *cache.LocalQueueUsageStats) error {
+	active, inadmissible, err := r.queues.PendingWorkloadsByStatus(queue)
+	if err != nil {
+		return err
+	}
+	metrics.ReportLocalQueuePendingWorkloads(queue.Name, queue.Namespace, active, inadmissible)
+	metrics.ReportLocalQueueActiveWorkloads(queue.Name, queue.Namespace, stats.ReservingWorkloads, stats.AdmittedWorkloads)
+
+	// The flavors of the ClusterQueue might have changed, drop the series
+	// of the flavors that are no longer in use.
+	metrics.ClearLocalQueueResourceMetrics(queue.Name, queue.Namespace)
+	for _, fr := range stats.ReservedResources {
+		for _, res := range fr.Resources {
+			metrics.ReportLocalQueueResourceReservations(queue.Name, queue.Namespace, string(fr.Name), string(res.Name), resource.QuantityToFloat(&res.Total))
+		}
+	}
+	for _, fu := range stats.AdmittedResources {
+		for _, res := range fu.Resources {
+			metrics.ReportLocalQueueResourceUsage(queue.Name, queue.Namespace, string(fu.Name), string(res.Name), resource.QuantityToFloat(&res.Total))
+		}
+	}
+	return nil
+}
+
+// reportEvictedWorkload counts the workload as evicted from its LocalQueue
+// when the update sets its Evicted condition.
+func reportEvictedWorkload(oldWl, newWl *kueue.Workload) {
+	evicted := meta.FindStatusCondition(newWl.Status.Conditions, kueue.WorkloadEvicted)
+	if evicted == nil || evicted.Status != metav1.ConditionTrue {
+		return
+	}
+	if oldWl != nil && meta.IsStatusConditionTrue(oldWl.Status.Conditions, kueue.WorkloadEvicted) {
+		return
+	}
+	metrics.LocalQueueEvictedWorkload(newWl.Spec.QueueName, newWl.Namespace, evicted.Reason)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestReportEvictedWorkload(t *testing.T) {
+	pending := utiltesting.MakeWorkload("wl", "ns").Queue("lq").Obj()
+	evicted := utiltesting.MakeWorkload("wl", "ns").Queue("lq").Condition(metav1.Condition{
+		Type:   kueue.WorkloadEvicted,
+		Status: metav1.ConditionTrue,
+		Reason: kueue.WorkloadEvictedByPreemption,
+	}).Obj()
+
+	cases := map[string]struct {
+		oldWl     *kueue.Workload
+		newWl     *kueue.Workload
+		wantCount float64
+	}{
+		"created evicted": {
+			newWl:     evicted,
+			wantCount: 1,
+		},
+		"evicted": {
+			oldWl:     pending,
+			newWl:     evicted,
+			wantCount: 1,
+		},
+		"already evicted": {
+			oldWl: evicted,
+			newWl: evicted,
+		},
+		"not evicted": {
+			oldWl: pending,
+			newWl: pending,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer metrics.ClearLocalQueueMetrics("lq", "ns")
+			reportEvictedWorkload(tc.oldWl, tc.newWl)
+			got := testutil.ToFloat64(metrics.LocalQueueEvictedWorkloadsTotal.WithLabelValues("lq", "ns", kueue.WorkloadEvictedByPreemption))
+			if got != tc.wantCount {
+				t.Errorf("Unexpected evicted workloads count, got %v, want %v", got, tc.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+const reservationUsageRefreshInterval = time.Minute
+
+// ReservationReconciler moves the Reservations through the phases of their
+// time window, preempts the workloads borrowing the reserved quota when a
+// Reservation starts, and reports the usage of the reserved quota.
+type ReservationReconciler struct {
+	log      logr.Logger
+	qManager *queue.Manager
+	client   client.Client
+	cache    *cache.Cache
+	clock    clock.Clock
+	leader   *leaderGate
+
+	// applyEviction can be overridden in tests.
+	applyEviction func(context.Context, *kueue.Workload) error
+}
+
+func NewReservationReconciler(client client.Client, qMgr *queue.Manager, cache *cache.Cache) *ReservationReconciler {
+	r := &ReservationReconciler{
+		log:      ctrl.Log.WithName("reservation-reconciler"),
+		qManager: qMgr,
+		client:   client,
+		cache:    cache,
+		clock:    realClock,
+	}
+	r.applyEviction = r.applyEvictionWithSSA
+	return r
+}
+
+func (r *ReservationReconciler) applyEvictionWithSSA(ctx context.Context, wl *kueue.Workload) error {
+	return workload.ApplyAdmissionStatus(ctx, r.client, wl, true)
+}
+
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=reservations,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=reservations/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads/status,verbs=get;update;patch
+
+func (r *ReservationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var res kueue.Reservation
+	if err := r.client.Get(ctx, req.NamespacedName, &res); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconciling Reservation")
+
+	now := r.clock.Now()
+	newRes := res.DeepCopy()
+	phase, next := reservationPhase(&res, now)
+	if phase != res.Status.Phase {
+		log.V(2).Info("Reservation changed phase", "phase", phase)
+		newRes.Status.Phase = phase
+		// Hold the quota in the cache right away, so that the preemptions
+		// below account for it.
+		r.notifyReservationUpdate(newRes)
+	}
+	if r.leader.hold(req) {
+		return requeueAt(next, now), nil
+	}
+
+	if phase == kueue.ReservationActive {
+		if err := r.preemptBorrowers(ctx, newRes); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	newRes.Status.FlavorsUsage = nil
+	newRes.Status.ReservingWorkloads = 0
+	if stats, err := r.cache.ReservationUsage(newRes); err == nil {
+		newRes.Status.FlavorsUsage = stats.FlavorsUsage
+		newRes.Status.ReservingWorkloads = int32(stats.ReservingWorkloads)
+	}
+	if !equality.Semantic.DeepEqual(res.Status, newRes.Status) {
+		if err := r.client.Status().Update(ctx, newRes); err != nil {
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+	}
+
+	switch phase {
+	case kueue.ReservationExpired:
+		return ctrl.Result{}, nil
+	case kueue.ReservationActive:
+		// Refresh the usage in case a workload event was processed before the
+		// cache was updated.
+		return ctrl.Result{RequeueAfter: min(next.Sub(now), reservationUsageRefreshInterval)}, nil
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+// preemptBorrowers evicts the workloads of the other ClusterQueues in the
+// cohort that are borrowing the quota held for the Reservation.
+func (r *ReservationReconciler) preemptBorrowers(ctx context.Context, res *kueue.Reservation) error {
+	log := ctrl.LoggerFrom(ctx)
+	snapshot := r.cache.Snapshot()
+	targets := preemption.ReservationTargets(&snapshot, string(res.Spec.ClusterQueue), res.Name)
+	var errs []error
+	for _, target := range targets {
+		if apimeta.IsStatusConditionTrue(target.Obj.Status.Conditions, kueue.WorkloadEvicted) {
+			continue
+		}
+		wl := target.Obj.DeepCopy()
+		workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByPreemption, fmt.Sprintf("Preempted to release the quota of Reservation %s", res.Name))
+		if err := r.applyEviction(ctx, wl); err != nil {
+			errs = append(errs, client.IgnoreNotFound(err))
+			continue
+		}
+		log.V(3).Info("Preempted", "targetWorkload", klog.KObj(wl))
+	}
+	return errors.Join(errs...)
+}
+
+// reservationPhase returns the phase of the Reservation at the given time and
+// the time at which the next phase starts.
+func reservationPhase(res *kueue.Reservation, now time.Time) (kueue.ReservationPhase, time.Time) {
+	start := res.Spec.StartTime.Time
+	holdStart := start.Add(-ptr.Deref(res.Spec.HoldPeriod, metav1.Duration{}).Duration)
+	end := start.Add(res.Spec.Duration.Duration)
+	switch {
+	case now.Before(holdStart):
+		return kueue.ReservationPending, holdStart
+	case now.Before(start):
+		return kueue.ReservationHolding, start
+	case now.Before(end):
+		return kueue.ReservationActive, end
+	}
+	return kueue.ReservationExpired, time.Time{}
+}
+
+func (r *ReservationReconciler) notifyReservationUpdate(res *kueue.Reservation) {
+	if cqNames := r.cache.AddOrUpdateReservation(res); len(cqNames) > 0 {
+		r.qManager.QueueInadmissibleWorkloads(context.Background(), cqNames)
+	}
+}
+
+func (r *ReservationReconciler) Create(e event.CreateEvent) bool {
+	res, isReservation := e.Object.(*kueue.Reservation)
+	if !isReservation {
+		return true
+	}
+	r.log.WithValues("reservation", klog.KObj(res)).V(5).Info("Create event")
+	r.notifyReservationUpdate(res)
+	return true
+}
+
+func (r *ReservationReconciler) Update(e event.UpdateEvent) bool {
+	res, isReservation := e.ObjectNew.(*kueue.Reservation)
+	if !isReservation {
+		return true
+	}
+	r.log.WithValues("reservation", klog.KObj(res)).V(5).Info("Update event")
+	r.notifyReservationUpdate(res)
+	return true
+}
+
+func (r *ReservationReconciler) Delete(e event.DeleteEvent) bool {
+	res, isReservation := e.Object.(*kueue.Reservation)
+	if !isReservation {
+		return true
+	}
+	r.log.WithValues("reservation", klog.KObj(res)).V(5).Info("Delete event")
+	if cqNames := r.cache.DeleteReservation(res); len(cqNames) > 0 {
+		r.qManager.QueueInadmissibleWorkloads(context.Background(), cqNames)
+	}
+	return false
+}
+
+func (r *ReservationReconciler) Generic(e event.GenericEvent) bool {
+	r.log.WithValues("object", klog.KObj(e.Object), "kind", e.Object.GetObjectKind().GroupVersionKind()).V(5).Info("Generic event")
+	return true
+}
+
+// workloadReservation maps a Workload to its Reservation, to report the
+// usage of the reserved quota.
+func workloadReservation(_ context.Context, obj client.Object) []reconcile.Request {
+	name := obj.GetLabels()[constants.ReservationLabel]
+	if name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ReservationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.leader = newLeaderGate(mgr.Elected())
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kueue.Reservation{}).
+		Watches(&kueue.Workload{}, handler.EnqueueRequestsFromMapFunc(workloadReservation)).
+		WatchesRawSource(r.leader.source(), nil).
+		WithOptions(r.leader.controllerOptions()).
+		WithEventFilter(r).
+		Complete(r)
+}
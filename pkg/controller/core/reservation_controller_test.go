@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	testingclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestReservationPhase(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	res := utiltesting.MakeReservation("res", "cq", start, 2*time.Hour).HoldPeriod(time.Hour).Obj()
+	cases := map[string]struct {
+		now       time.Time
+		wantPhase kueue.ReservationPhase
+		wantNext  time.Time
+	}{
+		"before the hold period": {
+			now:       start.Add(-2 * time.Hour),
+			wantPhase: kueue.ReservationPending,
+			wantNext:  start.Add(-time.Hour),
+		},
+		"in the hold period": {
+			now:       start.Add(-time.Minute),
+			wantPhase: kueue.ReservationHolding,
+			wantNext:  start,
+		},
+		"at the start": {
+			now:       start,
+			wantPhase: kueue.ReservationActive,
+			wantNext:  start.Add(2 * time.Hour),
+		},
+		"after the end": {
+			now:       start.Add(2 * time.Hour),
+			wantPhase: kueue.ReservationExpired,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotPhase, gotNext := reservationPhase(res, tc.now)
+			if gotPhase != tc.wantPhase {
+				t.Errorf("Unexpected phase %q, want %q", gotPhase, tc.wantPhase)
+			}
+			if !gotNext.Equal(tc.wantNext) {
+				t.Errorf("Unexpected next phase time %v, want %v", gotNext, tc.wantNext)
+			}
+		})
+	}
+}
+
+func TestReservationReconcile(t *testing.T) {
+	start := time.Now().Truncate(time.Second)
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("reserved").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("borrowing").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+	}
+	admitted := func(name, cq, cpu string, prio int32) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, "ns").
+			Request(corev1.ResourceCPU, cpu).
+			Priority(prio).
+			ReserveQuota(utiltesting.MakeAdmission(cq).Assignment(corev1.ResourceCPU, "default", cpu).Obj())
+	}
+	workloads := []kueue.Workload{
+		*admitted("reserved", "reserved", "2", 0).Labels(map[string]string{constants.ReservationLabel: "res"}).Obj(),
+		*admitted("low", "borrowing", "6", 0).Obj(),
+		*admitted("mid", "borrowing", "6", 5).Obj(),
+		*admitted("high", "borrowing", "6", 10).Obj(),
+	}
+	res := utiltesting.MakeReservation("res", "reserved", start, time.Hour).
+		Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")}).
+		Phase(kueue.ReservationHolding).
+		Obj()
+
+	ctx, _ := utiltesting.ContextWithLog(t)
+	cl := utiltesting.NewClientBuilder().
+		WithObjects(res).
+		WithStatusSubresource(res).
+		WithLists(&kueue.WorkloadList{Items: workloads}).
+		Build()
+	cqCache := cache.New(cl)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cq := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+		}
+	}
+	cqCache.AddOrUpdateReservation(res)
+
+	r := NewReservationReconciler(cl, queue.NewManager(cl, cqCache), cqCache)
+	r.clock = testingclock.NewFakeClock(start.Add(time.Minute))
+	gotEvicted := sets.New[string]()
+	r.applyEviction = func(_ context.Context, wl *kueue.Workload) error {
+		gotEvicted.Insert(workload.Key(wl))
+		return nil
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "res"}})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if diff := cmp.Diff(ctrl.Result{RequeueAfter: reservationUsageRefreshInterval}, result); diff != "" {
+		t.Errorf("Unexpected result (-want,+got):\n%s", diff)
+	}
+	if diff := cmp.Diff(sets.New("ns/low", "ns/mid"), gotEvicted); diff != "" {
+		t.Errorf("Unexpected evicted workloads (-want,+got):\n%s", diff)
+	}
+
+	var gotRes kueue.Reservation
+	if err := cl.Get(ctx, types.NamespacedName{Name: "res"}, &gotRes); err != nil {
+		t.Fatalf("Failed getting the reservation: %v", err)
+	}
+	wantStatus := kueue.ReservationStatus{
+		Phase: kueue.ReservationActive,
+		FlavorsUsage: []kueue.ReservedFlavor{{
+			Name:      "default",
+			Resources: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		}},
+		ReservingWorkloads: 1,
+	}
+	if diff := cmp.Diff(wantStatus, gotRes.Status); diff != "" {
+		t.Errorf("Unexpected status (-want,+got):\n%s", diff)
+	}
+}
@@ -48,6 +48,7 @@ type ResourceFlavorReconciler struct {
 	client     client.Client
 	cqUpdateCh chan event.GenericEvent
 	watchers   []ResourceFlavorUpdateWatcher
+	leader     *leaderGate
 }
 
 func NewResourceFlavorReconciler(
@@ -68,6 +69,9 @@ func NewResourceFlavorReconciler(
 //+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors/finalizers,verbs=update
 
 func (r *ResourceFlavorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.leader.hold(req) {
+		return ctrl.Result{}, nil
+	}
 	var flavor kueue.ResourceFlavor
 	if err := r.client.Get(ctx, req.NamespacedName, &flavor); err != nil {
 		// we'll ignore not-found errors, since there is nothing to do.
@@ -250,12 +254,15 @@ func (h *cqHandler) Generic(_ context.Context, e event.GenericEvent, q workqueue
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ResourceFlavorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.leader = newLeaderGate(mgr.Elected())
 	handler := cqHandler{
 		cache: r.cache,
 	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&kueue.ResourceFlavor{}).
 		WatchesRawSource(&source.Channel{Source: r.cqUpdateCh}, &handler).
+		WatchesRawSource(r.leader.source(), nil).
+		WithOptions(r.leader.controllerOptions()).
 		WithEventFilter(r).
 		Complete(r)
 }
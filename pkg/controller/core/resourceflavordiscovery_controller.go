@@ -0,0 +1,321 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"maps"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+)
+
+// discoveryRequest is the only request processed by the
+// ResourceFlavorDiscoveryReconciler. Every reconciliation processes all the
+// Nodes and ResourceFlavors.
+var discoveryRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "resourceflavor-discovery"}}
+
+var invalidFlavorNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+const (
+	flavorNameHashLength = 5
+	// 253 is the maximal length for a ResourceFlavor name. We need to subtract
+	// one for '-', and the hash length.
+	maxFlavorNamePrefixLength = 252 - flavorNameHashLength
+)
+
+// ResourceFlavorDiscoveryReconciler creates ResourceFlavors for the groups of
+// Nodes with the same values for the configured label keys, and keeps the
+// capacity of the Nodes of every discovered ResourceFlavor in its status.
+// The discovered ResourceFlavors that match no Nodes and that no ClusterQueue
+// uses are deleted.
+type ResourceFlavorDiscoveryReconciler struct {
+	client        client.Client
+	nodeLabelKeys []string
+}
+
+func NewResourceFlavorDiscoveryReconciler(client client.Client, nodeLabelKeys []string) *ResourceFlavorDiscoveryReconciler {
+	return &ResourceFlavorDiscoveryReconciler{
+		client:        client,
+		nodeLabelKeys: nodeLabelKeys,
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors,verbs=create;delete
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=clusterqueues,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=resourceflavors/status,verbs=get;update;patch
+
+func (r *ResourceFlavorDiscoveryReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconciling ResourceFlavors from Nodes")
+
+	var nodes corev1.NodeList
+	if err := r.client.List(ctx, &nodes); err != nil {
+		return ctrl.Result{}, err
+	}
+	var flavors kueue.ResourceFlavorList
+	if err := r.client.List(ctx, &flavors); err != nil {
+		return ctrl.Result{}, err
+	}
+	var cqs kueue.ClusterQueueList
+	if err := r.client.List(ctx, &cqs); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	existing := make(map[string]*kueue.ResourceFlavor, len(flavors.Items))
+	for i := range flavors.Items {
+		existing[flavors.Items[i].Name] = &flavors.Items[i]
+	}
+	groups := r.nodeGroups(nodes.Items)
+	names := flavorNames(groups, existing)
+	for key, group := range groups {
+		name := names[key]
+		if flv, found := existing[name]; found {
+			if !flavorForGroup(flv, group) {
+				log.V(2).Info("ResourceFlavor name already in use for other node label values", "resourceFlavor", name, "nodeLabels", group.nodeLabels)
+			}
+			continue
+		}
+		flv := kueue.ResourceFlavor{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{constants.DiscoveredResourceFlavorLabel: "true"},
+			},
+			Spec: kueue.ResourceFlavorSpec{
+				NodeLabels: group.nodeLabels,
+			},
+		}
+		if err := r.client.Create(ctx, &flv); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				continue
+			}
+			return ctrl.Result{}, err
+		}
+		log.V(2).Info("Created ResourceFlavor for Nodes", "resourceFlavor", name, "nodeLabels", group.nodeLabels)
+		flavors.Items = append(flavors.Items, flv)
+		existing[name] = &flavors.Items[len(flavors.Items)-1]
+	}
+
+	var errs []error
+	for i := range flavors.Items {
+		flv := &flavors.Items[i]
+		if !flv.DeletionTimestamp.IsZero() || !isDiscoveredFlavor(flv) {
+			continue
+		}
+		status := flavorStatusFromNodes(flv, nodes.Items)
+		if status.NodeCount == 0 && !flavorInUse(flv.Name, cqs.Items) {
+			if err := r.client.Delete(ctx, flv); err != nil {
+				errs = append(errs, client.IgnoreNotFound(err))
+				continue
+			}
+			log.V(2).Info("Deleted ResourceFlavor without Nodes", "resourceFlavor", flv.Name)
+			continue
+		}
+		if equality.Semantic.DeepEqual(flv.Status, status) {
+			continue
+		}
+		flv.Status = status
+		if err := r.client.Status().Update(ctx, flv); err != nil {
+			errs = append(errs, client.IgnoreNotFound(err))
+		}
+	}
+	return ctrl.Result{}, errors.Join(errs...)
+}
+
+// nodeGroup is a group of Nodes with the same values for the nodeLabelKeys.
+type nodeGroup struct {
+	values     []string
+	nodeLabels map[string]string
+}
+
+// nodeGroups returns the groups of schedulable Nodes, by their values for the
+// nodeLabelKeys.
+func (r *ResourceFlavorDiscoveryReconciler) nodeGroups(nodes []corev1.Node) map[string]nodeGroup {
+	groups := make(map[string]nodeGroup)
+	if len(r.nodeLabelKeys) == 0 {
+		return groups
+	}
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Spec.Unschedulable {
+			continue
+		}
+		nodeLabels := make(map[string]string, len(r.nodeLabelKeys))
+		values := make([]string, 0, len(r.nodeLabelKeys))
+		for _, key := range r.nodeLabelKeys {
+			value, found := node.Labels[key]
+			if !found {
+				break
+			}
+			nodeLabels[key] = value
+			values = append(values, value)
+		}
+		if len(values) != len(r.nodeLabelKeys) {
+			continue
+		}
+		if len(validation.IsDNS1123Subdomain(flavorNameForValues(values))) > 0 {
+			continue
+		}
+		groups[strings.Join(values, "\n")] = nodeGroup{values: values, nodeLabels: nodeLabels}
+	}
+	return groups
+}
+
+// flavorNames returns the name of the ResourceFlavor of every group.
+// A group is named after its values, unless other groups get the same name
+// or a ResourceFlavor with that name is for other values. In that case, a hash
+// of the values is appended to the name.
+func flavorNames(groups map[string]nodeGroup, existing map[string]*kueue.ResourceFlavor) map[string]string {
+	groupsPerName := make(map[string]int, len(groups))
+	for _, group := range groups {
+		groupsPerName[flavorNameForValues(group.values)]++
+	}
+	names := make(map[string]string, len(groups))
+	for key, group := range groups {
+		name := flavorNameForValues(group.values)
+		if flv, found := existing[name]; found {
+			if !flavorForGroup(flv, group) {
+				name = hashedFlavorName(name, group.values)
+			}
+		} else if groupsPerName[name] > 1 {
+			name = hashedFlavorName(name, group.values)
+		}
+		names[key] = name
+	}
+	return names
+}
+
+func flavorNameForValues(values []string) string {
+	name := strings.ToLower(strings.Join(values, "-"))
+	name = invalidFlavorNameChars.ReplaceAllString(name, "-")
+	return strings.Trim(name, "-.")
+}
+
+func hashedFlavorName(name string, values []string) string {
+	if len(name) > maxFlavorNamePrefixLength {
+		name = strings.TrimRight(name[:maxFlavorNamePrefixLength], "-.")
+	}
+	h := sha1.New()
+	for _, v := range values {
+		h.Write([]byte(v))
+		h.Write([]byte("\n"))
+	}
+	return name + "-" + hex.EncodeToString(h.Sum(nil))[:flavorNameHashLength]
+}
+
+// flavorForGroup returns whether the nodeLabels of the flavor have the values
+// of the group.
+func flavorForGroup(flv *kueue.ResourceFlavor, group nodeGroup) bool {
+	for key, value := range group.nodeLabels {
+		if flvValue, found := flv.Spec.NodeLabels[key]; !found || flvValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+func isDiscoveredFlavor(flv *kueue.ResourceFlavor) bool {
+	return flv.Labels[constants.DiscoveredResourceFlavorLabel] == "true"
+}
+
+// flavorInUse returns whether any of the ClusterQueues has quotas for the
+// flavor.
+func flavorInUse(name string, cqs []kueue.ClusterQueue) bool {
+	for i := range cqs {
+		for _, rg := range cqs[i].Spec.ResourceGroups {
+			for _, fQuotas := range rg.Flavors {
+				if string(fQuotas.Name) == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// flavorStatusFromNodes returns the status of the flavor based on the
+// schedulable Nodes that have its nodeLabels.
+func flavorStatusFromNodes(flv *kueue.ResourceFlavor, nodes []corev1.Node) kueue.ResourceFlavorStatus {
+	selector := labels.SelectorFromSet(flv.Spec.NodeLabels)
+	status := kueue.ResourceFlavorStatus{}
+	for i := range nodes {
+		node := &nodes[i]
+		if node.Spec.Unschedulable || !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		status.NodeCount++
+		if status.Capacity == nil {
+			status.Capacity = make(corev1.ResourceList, len(node.Status.Allocatable))
+		}
+		for rName, q := range node.Status.Allocatable {
+			total := status.Capacity[rName]
+			total.Add(q)
+			status.Capacity[rName] = total
+		}
+	}
+	return status
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResourceFlavorDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{discoveryRequest}
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("resourceflavor-discovery").
+		Watches(&corev1.Node{}, enqueue, builder.WithPredicates(predicate.Funcs{UpdateFunc: nodeCapacityChanged})).
+		Watches(&kueue.ResourceFlavor{}, enqueue, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&kueue.ClusterQueue{}, enqueue, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
+
+// nodeCapacityChanged returns whether the update of a Node changes the
+// ResourceFlavors it belongs to or its capacity.
+func nodeCapacityChanged(e event.UpdateEvent) bool {
+	oldNode, isNode := e.ObjectOld.(*corev1.Node)
+	if !isNode {
+		return false
+	}
+	newNode, isNode := e.ObjectNew.(*corev1.Node)
+	if !isNode {
+		return false
+	}
+	return oldNode.Spec.Unschedulable != newNode.Spec.Unschedulable ||
+		!maps.Equal(oldNode.Labels, newNode.Labels) ||
+		!equality.Semantic.DeepEqual(oldNode.Status.Allocatable, newNode.Status.Allocatable)
+}
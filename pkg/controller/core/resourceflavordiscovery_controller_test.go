@@ -0,0 +1,214 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestResourceFlavorDiscoveryReconcile(t *testing.T) {
+	makeNode := func(name, cpu string, unschedulable bool, labels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			},
+		}
+	}
+	discovered := func(rf *utiltesting.ResourceFlavorWrapper) *kueue.ResourceFlavor {
+		obj := rf.Obj()
+		obj.Labels = map[string]string{constants.DiscoveredResourceFlavorLabel: "true"}
+		return obj
+	}
+
+	cases := map[string]struct {
+		nodeLabelKeys []string
+		nodes         []*corev1.Node
+		flavors       []*kueue.ResourceFlavor
+		clusterQueues []*kueue.ClusterQueue
+		wantFlavors   []kueue.ResourceFlavor
+	}{
+		"creates a flavor per group of nodes": {
+			nodeLabelKeys: []string{"instance-type", "zone"},
+			nodes: []*corev1.Node{
+				makeNode("a1", "4", false, map[string]string{"instance-type": "a", "zone": "z1"}),
+				makeNode("a2", "4", false, map[string]string{"instance-type": "a", "zone": "z1"}),
+				makeNode("b1", "8", false, map[string]string{"instance-type": "B_large", "zone": "z1"}),
+				makeNode("c1", "8", false, map[string]string{"instance-type": "c"}),
+				makeNode("d1", "8", true, map[string]string{"instance-type": "d", "zone": "z1"}),
+			},
+			wantFlavors: []kueue.ResourceFlavor{
+				*discovered(utiltesting.MakeResourceFlavor("a-z1").
+					Label("instance-type", "a").
+					Label("zone", "z1").
+					NodeCount(2).
+					Capacity(corev1.ResourceCPU, "8")),
+				*discovered(utiltesting.MakeResourceFlavor("b-large-z1").
+					Label("instance-type", "B_large").
+					Label("zone", "z1").
+					NodeCount(1).
+					Capacity(corev1.ResourceCPU, "8")),
+			},
+		},
+		"updates the status of existing flavors": {
+			nodeLabelKeys: []string{"instance-type"},
+			nodes: []*corev1.Node{
+				makeNode("a1", "4", false, map[string]string{"instance-type": "a", "zone": "z1"}),
+				makeNode("a2", "4", false, map[string]string{"instance-type": "a", "zone": "z2"}),
+			},
+			flavors: []*kueue.ResourceFlavor{
+				discovered(utiltesting.MakeResourceFlavor("a").Label("instance-type", "a").Label("zone", "z1")),
+				discovered(utiltesting.MakeResourceFlavor("gone").Label("instance-type", "gone").NodeCount(3).Capacity(corev1.ResourceCPU, "12")),
+			},
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("gone").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+			wantFlavors: []kueue.ResourceFlavor{
+				*discovered(utiltesting.MakeResourceFlavor("a").
+					Label("instance-type", "a").
+					Label("zone", "z1").
+					NodeCount(1).
+					Capacity(corev1.ResourceCPU, "4")),
+				*discovered(utiltesting.MakeResourceFlavor("gone").Label("instance-type", "gone")),
+			},
+		},
+		"deletes the flavors without nodes that no ClusterQueue uses": {
+			nodeLabelKeys: []string{"instance-type"},
+			nodes: []*corev1.Node{
+				makeNode("a1", "4", false, map[string]string{"instance-type": "a"}),
+				makeNode("b1", "4", true, map[string]string{"instance-type": "b"}),
+			},
+			flavors: []*kueue.ResourceFlavor{
+				discovered(utiltesting.MakeResourceFlavor("a").Label("instance-type", "a")),
+				discovered(utiltesting.MakeResourceFlavor("b").Label("instance-type", "b").NodeCount(1).Capacity(corev1.ResourceCPU, "4")),
+				discovered(utiltesting.MakeResourceFlavor("gone").Label("instance-type", "gone")),
+				utiltesting.MakeResourceFlavor("manual").Label("instance-type", "manual").Obj(),
+			},
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("a").Resource(corev1.ResourceCPU, "4").Obj()).
+					Obj(),
+			},
+			wantFlavors: []kueue.ResourceFlavor{
+				*discovered(utiltesting.MakeResourceFlavor("a").
+					Label("instance-type", "a").
+					NodeCount(1).
+					Capacity(corev1.ResourceCPU, "4")),
+				*utiltesting.MakeResourceFlavor("manual").Label("instance-type", "manual").Obj(),
+			},
+		},
+		"doesn't update the status of flavors that weren't discovered": {
+			nodeLabelKeys: []string{"instance-type"},
+			nodes: []*corev1.Node{
+				makeNode("a1", "4", false, map[string]string{"instance-type": "a"}),
+			},
+			flavors: []*kueue.ResourceFlavor{
+				utiltesting.MakeResourceFlavor("a").Label("instance-type", "a").Obj(),
+				utiltesting.MakeResourceFlavor("manual").Label("instance-type", "a").NodeCount(3).Obj(),
+			},
+			wantFlavors: []kueue.ResourceFlavor{
+				*utiltesting.MakeResourceFlavor("a").Label("instance-type", "a").Obj(),
+				*utiltesting.MakeResourceFlavor("manual").Label("instance-type", "a").NodeCount(3).Obj(),
+			},
+		},
+		"disambiguates the names of groups of nodes that collide": {
+			nodeLabelKeys: []string{"instance-type"},
+			nodes: []*corev1.Node{
+				makeNode("a1", "4", false, map[string]string{"instance-type": "A_B"}),
+				makeNode("a2", "8", false, map[string]string{"instance-type": "a-b"}),
+			},
+			wantFlavors: []kueue.ResourceFlavor{
+				*discovered(utiltesting.MakeResourceFlavor("a-b-28215").
+					Label("instance-type", "A_B").
+					NodeCount(1).
+					Capacity(corev1.ResourceCPU, "4")),
+				*discovered(utiltesting.MakeResourceFlavor("a-b-5ae06").
+					Label("instance-type", "a-b").
+					NodeCount(1).
+					Capacity(corev1.ResourceCPU, "8")),
+			},
+		},
+		"keeps the name of an existing flavor when a colliding group appears": {
+			nodeLabelKeys: []string{"instance-type"},
+			nodes: []*corev1.Node{
+				makeNode("a1", "4", false, map[string]string{"instance-type": "A_B"}),
+				makeNode("a2", "8", false, map[string]string{"instance-type": "a-b"}),
+			},
+			flavors: []*kueue.ResourceFlavor{
+				discovered(utiltesting.MakeResourceFlavor("a-b").Label("instance-type", "a-b")),
+			},
+			wantFlavors: []kueue.ResourceFlavor{
+				*discovered(utiltesting.MakeResourceFlavor("a-b-28215").
+					Label("instance-type", "A_B").
+					NodeCount(1).
+					Capacity(corev1.ResourceCPU, "4")),
+				*discovered(utiltesting.MakeResourceFlavor("a-b").
+					Label("instance-type", "a-b").
+					NodeCount(1).
+					Capacity(corev1.ResourceCPU, "8")),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			builder := utiltesting.NewClientBuilder().WithStatusSubresource(&kueue.ResourceFlavor{})
+			for _, n := range tc.nodes {
+				builder = builder.WithObjects(n)
+			}
+			for _, rf := range tc.flavors {
+				builder = builder.WithObjects(rf)
+			}
+			for _, cq := range tc.clusterQueues {
+				builder = builder.WithObjects(cq)
+			}
+			cl := builder.Build()
+			ctx := context.Background()
+
+			r := NewResourceFlavorDiscoveryReconciler(cl, tc.nodeLabelKeys)
+			if _, err := r.Reconcile(ctx, discoveryRequest); err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+
+			var gotFlavors kueue.ResourceFlavorList
+			if err := cl.List(ctx, &gotFlavors); err != nil {
+				t.Fatalf("Failed listing ResourceFlavors: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantFlavors, gotFlavors.Items,
+				cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
+				cmpopts.IgnoreTypes(metav1.TypeMeta{}),
+				cmpopts.SortSlices(func(a, b kueue.ResourceFlavor) bool { return a.Name < b.Name }),
+				cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Unexpected ResourceFlavors (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
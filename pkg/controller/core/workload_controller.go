@@ -19,11 +19,13 @@ package core
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	nodev1 "k8s.io/api/node/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,7 +44,11 @@ import (
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/util/admissioncheck"
+	"sigs.k8s.io/kueue/pkg/util/api"
+	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
@@ -61,6 +67,7 @@ var (
 type options struct {
 	watchers         []WorkloadUpdateWatcher
 	podsReadyTimeout *time.Duration
+	capacityCheck    bool
 }
 
 // Option configures the reconciler.
@@ -74,6 +81,14 @@ func WithPodsReadyTimeout(value *time.Duration) Option {
 	}
 }
 
+// WithCapacityCheck indicates if the controller should set the Inadmissible
+// condition in the pending workloads that can never fit in their ClusterQueue.
+func WithCapacityCheck(value bool) Option {
+	return func(o *options) {
+		o.capacityCheck = value
+	}
+}
+
 // WithWorkloadUpdateWatchers allows to specify the workload update watchers
 func WithWorkloadUpdateWatchers(value ...WorkloadUpdateWatcher) Option {
 	return func(o *options) {
@@ -95,6 +110,8 @@ type WorkloadReconciler struct {
 	client           client.Client
 	watchers         []WorkloadUpdateWatcher
 	podsReadyTimeout *time.Duration
+	capacityCheck    bool
+	leader           *leaderGate
 }
 
 func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *cache.Cache, opts ...Option) *WorkloadReconciler {
@@ -110,6 +127,7 @@ func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *c
 		cache:            cache,
 		watchers:         options.watchers,
 		podsReadyTimeout: options.podsReadyTimeout,
+		capacityCheck:    options.capacityCheck,
 	}
 }
 
@@ -121,6 +139,9 @@ func NewWorkloadReconciler(client client.Client, queues *queue.Manager, cache *c
 //+kubebuilder:rbac:groups=node.k8s.io,resources=runtimeclasses,verbs=get;list;watch
 
 func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.leader.hold(req) {
+		return ctrl.Result{}, nil
+	}
 	var wl kueue.Workload
 	if err := r.client.Get(ctx, req.NamespacedName, &wl); err != nil {
 		// we'll ignore not-found errors, since there is nothing to do.
@@ -137,6 +158,11 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	if rejectedChecks := workload.GetRejectedChecks(&wl); len(rejectedChecks) > 0 {
 		// Finish the workload
 		log.V(3).Info("Workload has Rejected admission checks, Finish with failure")
+		if workload.CloseAdmissionRecord(&wl, "AdmissionChecksRejected") {
+			if err := workload.ApplyAdmissionStatus(ctx, r.client, &wl, true); err != nil {
+				return ctrl.Result{}, client.IgnoreNotFound(err)
+			}
+		}
 		err := workload.UpdateStatus(ctx, r.client, &wl, kueue.WorkloadFinished,
 			metav1.ConditionTrue,
 			"AdmissionChecksRejected",
@@ -189,9 +215,36 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	if r.capacityCheck {
+		return ctrl.Result{}, client.IgnoreNotFound(r.reconcileMaxCapacity(ctx, &wl))
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// reconcileMaxCapacity sets the Inadmissible condition when the pending
+// workload can never fit in its ClusterQueue, and resets it once the quotas
+// are big enough.
+func (r *WorkloadReconciler) reconcileMaxCapacity(ctx context.Context, wl *kueue.Workload) error {
+	log := ctrl.LoggerFrom(ctx)
+	cond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadInadmissible)
+	if reasons := r.cache.MaxCapacityViolations(wl); len(reasons) > 0 {
+		message := api.TruncateConditionMessage(strings.Join(reasons, "; "))
+		if cond != nil && cond.Status == metav1.ConditionTrue && cond.Message == message {
+			return nil
+		}
+		log.V(3).Info("Workload can never fit in its ClusterQueue", "reasons", reasons)
+		return workload.UpdateStatus(ctx, r.client, wl, kueue.WorkloadInadmissible, metav1.ConditionTrue,
+			"ExceedsMaximumCapacity", message, constants.KueueName)
+	}
+	if cond == nil || cond.Status == metav1.ConditionFalse {
+		return nil
+	}
+	log.V(3).Info("Workload fits in the maximum capacity of its ClusterQueue")
+	return workload.UpdateStatus(ctx, r.client, wl, kueue.WorkloadInadmissible, metav1.ConditionFalse,
+		"FitsMaximumCapacity", "The workload fits in the maximum capacity of its ClusterQueue", constants.KueueName)
+}
+
 func (r *WorkloadReconciler) reconcileCheckBasedEviction(ctx context.Context, wl *kueue.Workload) (bool, error) {
 	if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadEvicted) || !workload.HasRetryOrRejectedChecks(wl) {
 		return false, nil
@@ -210,7 +263,8 @@ func (r *WorkloadReconciler) reconcileSyncAdmissionChecks(ctx context.Context, w
 		return false, err
 	}
 
-	queueAdmissionChecks := queue.Spec.AdmissionChecks
+	// only the checks that apply to the flavors assigned to the workload
+	queueAdmissionChecks := sets.List(workload.AdmissionChecksForWorkload(wl, admissioncheck.FlavorsByCheck(&queue)))
 	newChecks, shouldUpdate := syncAdmissionCheckConditions(wl.Status.AdmissionChecks, queueAdmissionChecks)
 	if shouldUpdate {
 		log := ctrl.LoggerFrom(ctx)
@@ -354,6 +408,7 @@ func (r *WorkloadReconciler) Delete(e event.DeleteEvent) bool {
 				}
 			}
 		})
+		r.requeueWaitingForPodsReady(ctx, wl, nil)
 	}
 
 	// Even if the state is unknown, the last cached state tells us whether the
@@ -372,6 +427,9 @@ func (r *WorkloadReconciler) Update(e event.UpdateEvent) bool {
 	}
 	wl := e.ObjectNew.(*kueue.Workload)
 	defer r.notifyWatchers(oldWl, wl)
+	if r.leader.isElected() {
+		recordLifecycleMetrics(oldWl, wl)
+	}
 
 	status := workloadStatus(wl)
 	log := r.log.WithValues("workload", klog.KObj(wl), "queue", wl.Spec.QueueName, "status", status)
@@ -411,6 +469,7 @@ func (r *WorkloadReconciler) Update(e event.UpdateEvent) bool {
 				log.Error(err, "Failed to delete workload from cache")
 			}
 		})
+		r.requeueWaitingForPodsReady(ctx, oldWl, nil)
 
 	case prevStatus == pending && status == pending:
 		if !r.queues.UpdateWorkload(oldWl, wlCopy) {
@@ -432,16 +491,28 @@ func (r *WorkloadReconciler) Update(e event.UpdateEvent) bool {
 				log.Error(err, "Failed to delete workload from cache")
 			}
 		})
+		r.requeueWaitingForPodsReady(ctx, oldWl, wl)
 		if !r.queues.AddOrUpdateWorkload(wlCopy) {
 			log.V(2).Info("Queue for workload didn't exist; ignored for now")
 		}
 
+	case prevStatus == admitted && status == admitted && preemptionEligibilityChanged(oldWl, wl):
+		// The workloads that couldn't preempt the old workload might be able
+		// to preempt the updated one.
+		r.queues.QueueAssociatedInadmissibleWorkloadsAfter(ctx, wl, func() {
+			if err := r.cache.UpdateWorkload(oldWl, wlCopy); err != nil {
+				log.Error(err, "Updating workload in cache")
+			}
+		})
+		r.requeueWaitingForPodsReady(ctx, oldWl, wlCopy)
+
 	default:
 		// Workload update in the cache is handled here; however, some fields are immutable
 		// and are not supposed to actually change anything.
 		if err := r.cache.UpdateWorkload(oldWl, wlCopy); err != nil {
 			log.Error(err, "Updating workload in cache")
 		}
+		r.requeueWaitingForPodsReady(ctx, oldWl, wlCopy)
 	}
 
 	return true
@@ -460,6 +531,7 @@ func (r *WorkloadReconciler) notifyWatchers(oldWl, newWl *kueue.Workload) {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.leader = newLeaderGate(mgr.Elected())
 	ruh := &resourceUpdatesHandler{
 		r: r,
 	}
@@ -467,7 +539,9 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&kueue.Workload{}).
 		Watches(&corev1.LimitRange{}, ruh).
 		Watches(&nodev1.RuntimeClass{}, ruh).
-		Watches(&kueue.ClusterQueue{}, &workloadCqHandler{client: r.client}).
+		Watches(&kueue.ClusterQueue{}, &workloadCqHandler{client: r.client, capacityCheck: r.capacityCheck}).
+		WatchesRawSource(r.leader.source(), nil).
+		WithOptions(r.leader.controllerOptions()).
 		WithEventFilter(r).
 		Complete(r)
 }
@@ -479,7 +553,8 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // specified timeout counted since max of the LastTransitionTime's for the
 // Admitted and PodsReady conditions.
 func (r *WorkloadReconciler) admittedNotReadyWorkload(wl *kueue.Workload, clock clock.Clock) (bool, time.Duration) {
-	if r.podsReadyTimeout == nil {
+	podsReadyTimeout := r.podsReadyTimeoutFor(wl)
+	if podsReadyTimeout == nil {
 		// the timeout is not configured for the workload controller
 		return false, 0
 	}
@@ -497,13 +572,84 @@ func (r *WorkloadReconciler) admittedNotReadyWorkload(wl *kueue.Workload, clock
 	if podsReadyCond != nil && podsReadyCond.Status == metav1.ConditionFalse && podsReadyCond.LastTransitionTime.After(admittedCond.LastTransitionTime.Time) {
 		elapsedTime = clock.Since(podsReadyCond.LastTransitionTime.Time)
 	}
-	waitFor := *r.podsReadyTimeout - elapsedTime
+	waitFor := *podsReadyTimeout - elapsedTime
 	if waitFor < 0 {
 		waitFor = 0
 	}
 	return true, waitFor
 }
 
+// podsReadyTimeoutFor returns the PodsReady timeout of the ClusterQueue that
+// admitted the workload if it overrides the waitForPodsReady configuration, or
+// the timeout of the configuration otherwise.
+func (r *WorkloadReconciler) podsReadyTimeoutFor(wl *kueue.Workload) *time.Duration {
+	if r.cache != nil && wl.Status.Admission != nil {
+		if timeout, overridden := r.cache.PodsReadyTimeout(string(wl.Status.Admission.ClusterQueue)); overridden {
+			return timeout
+		}
+	}
+	return r.podsReadyTimeout
+}
+
+// requeueWaitingForPodsReady requeues the workloads whose admission was
+// blocked by the old workload not being in the PodsReady condition, when the
+// new workload no longer blocks them. The new workload is nil when the
+// workload is deleted or finished. It must be called after updating the cache.
+func (r *WorkloadReconciler) requeueWaitingForPodsReady(ctx context.Context, oldWl, wl *kueue.Workload) {
+	if !workload.HasQuotaReservation(oldWl) || apimeta.IsStatusConditionTrue(oldWl.Status.Conditions, kueue.WorkloadPodsReady) {
+		return
+	}
+	cqName := oldWl.Status.Admission.ClusterQueue
+	if wl != nil && workload.HasQuotaReservation(wl) && wl.Status.Admission.ClusterQueue == cqName && !apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadPodsReady) {
+		return
+	}
+	r.queues.QueueInadmissibleWorkloads(ctx, r.cache.PodsReadyScope(string(cqName)))
+}
+
+// preemptionEligibilityChanged returns whether the changes to the priority of
+// the workload affect which workloads can preempt it.
+func preemptionEligibilityChanged(oldWl, wl *kueue.Workload) bool {
+	return priority.Priority(oldWl) != priority.Priority(wl) || oldWl.Spec.Protected != wl.Spec.Protected
+}
+
+// recordLifecycleMetrics records the evictions, the time spent in the
+// admission checks and the time waiting for the pods to be ready, based on the
+// conditions that the update sets.
+func recordLifecycleMetrics(oldWl, wl *kueue.Workload) {
+	var cqName kueue.ClusterQueueReference
+	switch {
+	case wl.Status.Admission != nil:
+		cqName = wl.Status.Admission.ClusterQueue
+	case oldWl.Status.Admission != nil:
+		cqName = oldWl.Status.Admission.ClusterQueue
+	default:
+		return
+	}
+	if evicted := conditionSetToTrue(oldWl, wl, kueue.WorkloadEvicted); evicted != nil {
+		metrics.ReportEvictedWorkloads(cqName, evicted.Reason)
+	}
+	if admittedCond := conditionSetToTrue(oldWl, wl, kueue.WorkloadAdmitted); admittedCond != nil && len(wl.Status.AdmissionChecks) > 0 {
+		if reserved := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadQuotaReserved); reserved != nil && reserved.Status == metav1.ConditionTrue {
+			metrics.AdmissionChecksWaitTime(cqName, admittedCond.LastTransitionTime.Sub(reserved.LastTransitionTime.Time))
+		}
+	}
+	if ready := conditionSetToTrue(oldWl, wl, kueue.WorkloadPodsReady); ready != nil {
+		if admittedCond := apimeta.FindStatusCondition(wl.Status.Conditions, kueue.WorkloadAdmitted); admittedCond != nil && admittedCond.Status == metav1.ConditionTrue {
+			metrics.ReadyWaitTime(cqName, ready.LastTransitionTime.Sub(admittedCond.LastTransitionTime.Time))
+		}
+	}
+}
+
+// conditionSetToTrue returns the condition of the given type when it is true
+// in the new workload, but not in the old one.
+func conditionSetToTrue(oldWl, wl *kueue.Workload, conditionType string) *metav1.Condition {
+	cond := apimeta.FindStatusCondition(wl.Status.Conditions, conditionType)
+	if cond == nil || cond.Status != metav1.ConditionTrue || apimeta.IsStatusConditionTrue(oldWl.Status.Conditions, conditionType) {
+		return nil
+	}
+	return cond
+}
+
 func workloadStatus(w *kueue.Workload) string {
 	if apimeta.IsStatusConditionTrue(w.Status.Conditions, kueue.WorkloadFinished) {
 		return finished
@@ -579,6 +725,10 @@ func (h *resourceUpdatesHandler) queueReconcileForPending(ctx context.Context, _
 
 type workloadCqHandler struct {
 	client client.Client
+	// capacityCheck indicates if the workloads in the cohort need to be
+	// reconciled when the quotas change, to update their Inadmissible
+	// condition.
+	capacityCheck bool
 }
 
 var _ handler.EventHandler = (*workloadCqHandler)(nil)
@@ -587,6 +737,9 @@ var _ handler.EventHandler = (*workloadCqHandler)(nil)
 func (w *workloadCqHandler) Create(ctx context.Context, ev event.CreateEvent, wq workqueue.RateLimitingInterface) {
 	if cq, isQueue := ev.Object.(*kueue.ClusterQueue); isQueue {
 		w.queueReconcileForWorkloads(ctx, cq.Name, wq)
+		if w.capacityCheck {
+			w.queueReconcileForCohortWorkloads(ctx, cq.Name, cq.Spec.Cohort, wq)
+		}
 	}
 }
 
@@ -602,8 +755,16 @@ func (w *workloadCqHandler) Update(ctx context.Context, ev event.UpdateEvent, wq
 		return
 	}
 
-	if !newCq.DeletionTimestamp.IsZero() || !slices.CmpNoOrder(oldCq.Spec.AdmissionChecks, newCq.Spec.AdmissionChecks) {
+	if !newCq.DeletionTimestamp.IsZero() || !equality.Semantic.DeepEqual(admissioncheck.FlavorsByCheck(oldCq), admissioncheck.FlavorsByCheck(newCq)) {
+		w.queueReconcileForWorkloads(ctx, newCq.Name, wq)
+	}
+
+	if w.capacityCheck && (oldCq.Spec.Cohort != newCq.Spec.Cohort || !equality.Semantic.DeepEqual(oldCq.Spec.ResourceGroups, newCq.Spec.ResourceGroups)) {
 		w.queueReconcileForWorkloads(ctx, newCq.Name, wq)
+		w.queueReconcileForCohortWorkloads(ctx, newCq.Name, oldCq.Spec.Cohort, wq)
+		if newCq.Spec.Cohort != oldCq.Spec.Cohort {
+			w.queueReconcileForCohortWorkloads(ctx, newCq.Name, newCq.Spec.Cohort, wq)
+		}
 	}
 }
 
@@ -611,6 +772,9 @@ func (w *workloadCqHandler) Update(ctx context.Context, ev event.UpdateEvent, wq
 func (w *workloadCqHandler) Delete(ctx context.Context, ev event.DeleteEvent, wq workqueue.RateLimitingInterface) {
 	if cq, isQueue := ev.Object.(*kueue.ClusterQueue); isQueue {
 		w.queueReconcileForWorkloads(ctx, cq.Name, wq)
+		if w.capacityCheck {
+			w.queueReconcileForCohortWorkloads(ctx, cq.Name, cq.Spec.Cohort, wq)
+		}
 	}
 }
 
@@ -634,6 +798,25 @@ func (w *workloadCqHandler) queueReconcileForWorkloads(ctx context.Context, cqNa
 	}
 }
 
+// queueReconcileForCohortWorkloads queues the workloads of the other members
+// of the cohort, whose maximum capacity depends on the quotas of cqName.
+func (w *workloadCqHandler) queueReconcileForCohortWorkloads(ctx context.Context, cqName, cohort string, wq workqueue.RateLimitingInterface) {
+	if cohort == "" {
+		return
+	}
+	log := ctrl.LoggerFrom(ctx)
+	lst := kueue.ClusterQueueList{}
+	if err := w.client.List(ctx, &lst); err != nil {
+		log.Error(err, "Could not list cluster queues")
+		return
+	}
+	for _, cq := range lst.Items {
+		if cq.Name != cqName && cq.Spec.Cohort == cohort {
+			w.queueReconcileForWorkloads(ctx, cq.Name, wq)
+		}
+	}
+}
+
 func (w *workloadCqHandler) queueReconcileForWorkloadsOfLocalQueue(ctx context.Context, namespace string, name string, wq workqueue.RateLimitingInterface) {
 	log := ctrl.LoggerFrom(ctx)
 	lst := kueue.WorkloadList{}
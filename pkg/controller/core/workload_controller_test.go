@@ -22,11 +22,19 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	testingclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
 func TestAdmittedNotReadyWorkload(t *testing.T) {
@@ -290,3 +298,167 @@ func TestSyncCheckStates(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordEvictionMetrics(t *testing.T) {
+	admitted := utiltesting.MakeWorkload("wl", "ns").ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).Admitted(true)
+	evictedCondition := metav1.Condition{
+		Type:   kueue.WorkloadEvicted,
+		Status: metav1.ConditionTrue,
+		Reason: kueue.WorkloadEvictedByPodsReadyTimeout,
+	}
+
+	cases := map[string]struct {
+		oldWl     *kueue.Workload
+		newWl     *kueue.Workload
+		wantCount float64
+	}{
+		"evicted": {
+			oldWl:     admitted.Clone().Obj(),
+			newWl:     admitted.Clone().Condition(evictedCondition).Obj(),
+			wantCount: 1,
+		},
+		"already evicted": {
+			oldWl: admitted.Clone().Condition(evictedCondition).Obj(),
+			newWl: admitted.Clone().Condition(evictedCondition).Obj(),
+		},
+		"evicted without admission": {
+			oldWl: utiltesting.MakeWorkload("wl", "ns").Obj(),
+			newWl: utiltesting.MakeWorkload("wl", "ns").Condition(evictedCondition).Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer metrics.ClearQueueSystemMetrics("cq")
+			recordLifecycleMetrics(tc.oldWl, tc.newWl)
+			got := testutil.ToFloat64(metrics.EvictedWorkloadsTotal.WithLabelValues("cq", kueue.WorkloadEvictedByPodsReadyTimeout))
+			if got != tc.wantCount {
+				t.Errorf("Unexpected evicted workloads count, got %v, want %v", got, tc.wantCount)
+			}
+		})
+	}
+}
+
+func TestReconcileMaxCapacity(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource("example.com/gpu", "16").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj()
+	cases := map[string]struct {
+		wl            *kueue.Workload
+		wantCondition *metav1.Condition
+	}{
+		"exceeds the maximum capacity": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request("example.com/gpu", "64").Obj(),
+			wantCondition: &metav1.Condition{
+				Type:    kueue.WorkloadInadmissible,
+				Status:  metav1.ConditionTrue,
+				Reason:  "ExceedsMaximumCapacity",
+				Message: "podSet main requests example.com/gpu=64, which exceed the maximum quota of every flavor in ClusterQueue cq",
+			},
+		},
+		"fits after being marked": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request("example.com/gpu", "8").
+				Condition(metav1.Condition{
+					Type:   kueue.WorkloadInadmissible,
+					Status: metav1.ConditionTrue,
+					Reason: "ExceedsMaximumCapacity",
+				}).
+				Obj(),
+			wantCondition: &metav1.Condition{
+				Type:    kueue.WorkloadInadmissible,
+				Status:  metav1.ConditionFalse,
+				Reason:  "FitsMaximumCapacity",
+				Message: "The workload fits in the maximum capacity of its ClusterQueue",
+			},
+		},
+		"fits": {
+			wl: utiltesting.MakeWorkload("wl", "ns").Queue("lq").Request("example.com/gpu", "8").Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().WithObjects(tc.wl, lq, cq).WithStatusSubresource(tc.wl).Build()
+			cqCache := cache.New(cl)
+			qManager := queue.NewManager(cl, cqCache)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in cache: %v", err)
+			}
+			if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+				t.Fatalf("Inserting clusterQueue in manager: %v", err)
+			}
+			if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+				t.Fatalf("Inserting localQueue in manager: %v", err)
+			}
+			r := NewWorkloadReconciler(cl, qManager, cqCache, WithCapacityCheck(true))
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.wl)}); err != nil {
+				t.Fatalf("Reconciling: %v", err)
+			}
+			var gotWl kueue.Workload
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.wl), &gotWl); err != nil {
+				t.Fatalf("Getting workload: %v", err)
+			}
+			gotCondition := apimeta.FindStatusCondition(gotWl.Status.Conditions, kueue.WorkloadInadmissible)
+			if diff := cmp.Diff(tc.wantCondition, gotCondition, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Unexpected Inadmissible condition (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileSyncAdmissionChecks(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		AdmissionCheckStrategy(
+			kueue.AdmissionCheckStrategyRule{Name: "all"},
+			kueue.AdmissionCheckStrategyRule{Name: "gpu", OnFlavors: []kueue.ResourceFlavorReference{"gpu"}},
+		).
+		Obj()
+	cases := map[string]struct {
+		wl         *kueue.Workload
+		wantChecks []string
+	}{
+		"without quota reservation": {
+			wl:         utiltesting.MakeWorkload("wl", "ns").Obj(),
+			wantChecks: []string{"all"},
+		},
+		"reserving quota in the flavor of a check": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment("example.com/gpu", "gpu", "1").Obj()).
+				Obj(),
+			wantChecks: []string{"all", "gpu"},
+		},
+		"reserving quota in other flavors": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment("cpu", "default", "1").Obj()).
+				AdmissionChecks(
+					kueue.AdmissionCheckState{Name: "all", State: kueue.CheckStatePending},
+					kueue.AdmissionCheckState{Name: "gpu", State: kueue.CheckStatePending},
+				).
+				Obj(),
+			wantChecks: []string{"all"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().WithObjects(tc.wl, cq).WithStatusSubresource(tc.wl).Build()
+			cqCache := cache.New(cl)
+			r := NewWorkloadReconciler(cl, queue.NewManager(cl, cqCache), cqCache)
+			if _, err := r.reconcileSyncAdmissionChecks(ctx, tc.wl.DeepCopy(), "cq"); err != nil {
+				t.Fatalf("Syncing the admission checks: %v", err)
+			}
+			var gotWl kueue.Workload
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tc.wl), &gotWl); err != nil {
+				t.Fatalf("Getting workload: %v", err)
+			}
+			gotChecks := make([]string, 0, len(gotWl.Status.AdmissionChecks))
+			for _, c := range gotWl.Status.AdmissionChecks {
+				gotChecks = append(gotChecks, c.Name)
+			}
+			if diff := cmp.Diff(tc.wantChecks, gotChecks); diff != "" {
+				t.Errorf("Unexpected admission checks (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"errors"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/core/indexer"
+	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
+)
+
+// WorkloadPriorityClassReconciler propagates the value, the preemption policy
+// and the protection of the WorkloadPriorityClasses to the unfinished
+// Workloads that use them, so that the pending Workloads are reordered in the
+// queues and the admitted ones are preempted according to their new priority.
+type WorkloadPriorityClassReconciler struct {
+	client client.Client
+}
+
+func NewWorkloadPriorityClassReconciler(client client.Client) *WorkloadPriorityClassReconciler {
+	return &WorkloadPriorityClassReconciler{
+		client: client,
+	}
+}
+
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloadpriorityclasses,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kueue.x-k8s.io,resources=workloads,verbs=get;list;watch;update
+
+func (r *WorkloadPriorityClassReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var wpc kueue.WorkloadPriorityClass
+	if err := r.client.Get(ctx, req.NamespacedName, &wpc); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := ctrl.LoggerFrom(ctx)
+	log.V(2).Info("Reconciling WorkloadPriorityClass")
+
+	var workloads kueue.WorkloadList
+	if err := r.client.List(ctx, &workloads, client.MatchingFields{indexer.WorkloadPriorityClassKey: wpc.Name}); err != nil {
+		return ctrl.Result{}, err
+	}
+	var errs []error
+	for i := range workloads.Items {
+		wl := &workloads.Items[i]
+		if apimeta.IsStatusConditionTrue(wl.Status.Conditions, kueue.WorkloadFinished) || !wl.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if !utilpriority.SetFromWorkloadPriorityClass(wl, &wpc) {
+			continue
+		}
+		if err := r.client.Update(ctx, wl); err != nil {
+			errs = append(errs, client.IgnoreNotFound(err))
+			continue
+		}
+		log.V(3).Info("Updated the priority of the workload", "workload", klog.KObj(wl), "priority", wpc.Value)
+	}
+	return ctrl.Result{}, errors.Join(errs...)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkloadPriorityClassReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&kueue.WorkloadPriorityClass{}, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc:  workloadPriorityClassChanged,
+			DeleteFunc:  func(event.DeleteEvent) bool { return false },
+			GenericFunc: func(event.GenericEvent) bool { return false },
+		})).
+		Complete(r)
+}
+
+// workloadPriorityClassChanged returns whether the update changes any of the
+// fields populated into the Workloads.
+func workloadPriorityClassChanged(e event.UpdateEvent) bool {
+	oldWpc, isOld := e.ObjectOld.(*kueue.WorkloadPriorityClass)
+	newWpc, isNew := e.ObjectNew.(*kueue.WorkloadPriorityClass)
+	if !isOld || !isNew {
+		return false
+	}
+	return oldWpc.Value != newWpc.Value ||
+		!ptr.Equal(oldWpc.PreemptionPolicy, newWpc.PreemptionPolicy) ||
+		oldWpc.Protected != newWpc.Protected
+}
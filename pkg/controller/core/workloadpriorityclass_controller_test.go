@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/constants"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadPriorityClassReconcile(t *testing.T) {
+	wpc := utiltesting.MakeWorkloadPriorityClass("high").
+		PriorityValue(200).
+		PreemptionPolicy(corev1.PreemptNever).
+		Protected().
+		Obj()
+	withClass := func(name string, class string, value int32) *utiltesting.WorkloadWrapper {
+		return utiltesting.MakeWorkload(name, "ns").
+			PriorityClass(class).
+			PriorityClassSource(constants.WorkloadPriorityClassSource).
+			Priority(value)
+	}
+
+	workloads := []*kueue.Workload{
+		withClass("pending", "high", 100).Obj(),
+		withClass("admitted", "high", 100).ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).Obj(),
+		withClass("finished", "high", 100).Condition(metav1.Condition{
+			Type:   kueue.WorkloadFinished,
+			Status: metav1.ConditionTrue,
+		}).Obj(),
+		withClass("other-class", "low", 100).Obj(),
+		utiltesting.MakeWorkload("pod-priority-class", "ns").
+			PriorityClass("high").
+			PriorityClassSource(constants.PodPriorityClassSource).
+			Priority(100).
+			Obj(),
+	}
+	wantWorkloads := []kueue.Workload{
+		*withClass("admitted", "high", 200).PreemptionPolicy(corev1.PreemptNever).Protected().
+			ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).Obj(),
+		*withClass("finished", "high", 100).Condition(metav1.Condition{
+			Type:   kueue.WorkloadFinished,
+			Status: metav1.ConditionTrue,
+		}).Obj(),
+		*withClass("other-class", "low", 100).Obj(),
+		*withClass("pending", "high", 200).PreemptionPolicy(corev1.PreemptNever).Protected().Obj(),
+		*utiltesting.MakeWorkload("pod-priority-class", "ns").
+			PriorityClass("high").
+			PriorityClassSource(constants.PodPriorityClassSource).
+			Priority(100).
+			Obj(),
+	}
+
+	builder := utiltesting.NewClientBuilder().WithObjects(wpc)
+	for _, wl := range workloads {
+		builder = builder.WithObjects(wl)
+	}
+	cl := builder.Build()
+	ctx := context.Background()
+
+	r := NewWorkloadPriorityClassReconciler(cl)
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "high"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var gotWorkloads kueue.WorkloadList
+	if err := cl.List(ctx, &gotWorkloads); err != nil {
+		t.Fatalf("Failed listing Workloads: %v", err)
+	}
+	if diff := cmp.Diff(wantWorkloads, gotWorkloads.Items,
+		cmpopts.IgnoreFields(metav1.ObjectMeta{}, "ResourceVersion"),
+		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+		cmpopts.IgnoreTypes(metav1.TypeMeta{}),
+		cmpopts.SortSlices(func(a, b kueue.Workload) bool { return a.Name < b.Name }),
+		cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Unexpected Workloads (-want,+got):\n%s", diff)
+	}
+}
+
+func TestWorkloadPriorityClassChanged(t *testing.T) {
+	base := utiltesting.MakeWorkloadPriorityClass("wpc").PriorityValue(100).Obj()
+	cases := map[string]struct {
+		newWpc *kueue.WorkloadPriorityClass
+		want   bool
+	}{
+		"description changed": {
+			newWpc: func() *kueue.WorkloadPriorityClass {
+				wpc := base.DeepCopy()
+				wpc.Description = "updated"
+				return wpc
+			}(),
+		},
+		"value changed": {
+			newWpc: utiltesting.MakeWorkloadPriorityClass("wpc").PriorityValue(200).Obj(),
+			want:   true,
+		},
+		"preemptionPolicy changed": {
+			newWpc: utiltesting.MakeWorkloadPriorityClass("wpc").PriorityValue(100).PreemptionPolicy(corev1.PreemptNever).Obj(),
+			want:   true,
+		},
+		"protected changed": {
+			newWpc: utiltesting.MakeWorkloadPriorityClass("wpc").PriorityValue(100).Protected().Obj(),
+			want:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := workloadPriorityClassChanged(event.UpdateEvent{ObjectOld: base, ObjectNew: tc.newWpc})
+			if got != tc.want {
+				t.Errorf("workloadPriorityClassChanged() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
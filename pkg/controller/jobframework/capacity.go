@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jobframework
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// CapacityChecker returns the reasons why a workload can never fit in the
+// ClusterQueue of its LocalQueue.
+type CapacityChecker interface {
+	MaxCapacityViolations(*kueue.Workload) []string
+}
+
+// CapacityCheck configures the job webhooks to detect the jobs whose
+// workload can never fit in their ClusterQueue.
+type CapacityCheck struct {
+	Checker CapacityChecker
+	// Reject makes the webhooks reject the jobs, instead of returning
+	// warnings.
+	Reject bool
+}
+
+// WithCapacityCheck makes the job webhooks reject the jobs whose workload
+// can never fit in their ClusterQueue, or warn about them if reject is false.
+func WithCapacityCheck(checker CapacityChecker, reject bool) Option {
+	return func(o *Options) {
+		o.CapacityCheck = &CapacityCheck{Checker: checker, Reject: reject}
+	}
+}
+
+// ValidateCreateForCapacity returns the warnings, or the errors if the check
+// rejects them, for a job whose workload can never fit in its ClusterQueue.
+// The jobs without a queue name, and the ones whose workload is owned by a
+// parent, aren't checked.
+func ValidateCreateForCapacity(job GenericJob, check *CapacityCheck) (admission.Warnings, field.ErrorList) {
+	if check == nil || QueueName(job) == "" || ParentWorkloadName(job) != "" {
+		return nil, nil
+	}
+	wl := &kueue.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: job.Object().GetNamespace(),
+		},
+		Spec: kueue.WorkloadSpec{
+			PodSets:   resetMinCounts(job.PodSets()),
+			QueueName: QueueName(job),
+		},
+	}
+	reasons := check.Checker.MaxCapacityViolations(wl)
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+	if !check.Reject {
+		warnings := make(admission.Warnings, 0, len(reasons))
+		for _, r := range reasons {
+			warnings = append(warnings, "the job can never be admitted: "+r)
+		}
+		return warnings, nil
+	}
+	allErrs := make(field.ErrorList, 0, len(reasons))
+	for _, r := range reasons {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "the job can never be admitted: "+r))
+	}
+	return nil, allErrs
+}
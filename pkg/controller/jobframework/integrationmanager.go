@@ -24,6 +24,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -33,6 +34,7 @@ import (
 var (
 	errDuplicateFrameworkName = errors.New("duplicate framework name")
 	errMissingMadatoryField   = errors.New("mandatory field missing")
+	errInvalidExternalKind    = errors.New("invalid external framework kind, expected Kind.version.group")
 )
 
 type JobReconcilerInterface interface {
@@ -65,6 +67,9 @@ type IntegrationCallbacks struct {
 type integrationManager struct {
 	names        []string
 	integrations map[string]IntegrationCallbacks
+	// externalTypes holds the job types managed by controllers outside of
+	// Kueue, which create their own Workloads.
+	externalTypes map[schema.GroupVersionKind]*metav1.PartialObjectMetadata
 }
 
 var manager integrationManager
@@ -95,6 +100,34 @@ func (m *integrationManager) register(name string, cb IntegrationCallbacks) erro
 	return nil
 }
 
+func (m *integrationManager) registerExternal(kindArg string) error {
+	gvk, _ := schema.ParseKindArg(kindArg)
+	if gvk == nil {
+		return fmt.Errorf("%w: %q", errInvalidExternalKind, kindArg)
+	}
+	if m.externalTypes == nil {
+		m.externalTypes = make(map[schema.GroupVersionKind]*metav1.PartialObjectMetadata)
+	}
+	if _, exists := m.externalTypes[*gvk]; exists {
+		return fmt.Errorf("%w %q", errDuplicateFrameworkName, kindArg)
+	}
+	jobType := &metav1.PartialObjectMetadata{}
+	jobType.SetGroupVersionKind(*gvk)
+	m.externalTypes[*gvk] = jobType
+	return nil
+}
+
+func (m *integrationManager) getExternalType(ownerRef *metav1.OwnerReference) *metav1.PartialObjectMetadata {
+	if len(m.externalTypes) == 0 {
+		return nil
+	}
+	gv, err := schema.ParseGroupVersion(ownerRef.APIVersion)
+	if err != nil {
+		return nil
+	}
+	return m.externalTypes[gv.WithKind(ownerRef.Kind)]
+}
+
 func (m *integrationManager) forEach(f func(name string, cb IntegrationCallbacks) error) error {
 	for _, name := range m.names {
 		if err := f(name, m.integrations[name]); err != nil {
@@ -150,17 +183,28 @@ func GetIntegrationsList() []string {
 	return manager.getList()
 }
 
+// RegisterExternalJobType registers a job type, given as "Kind.version.group",
+// whose Workloads are created by a controller outside of Kueue. Kueue treats
+// the objects of the type as managed parents of the jobs and pods they own.
+func RegisterExternalJobType(kindArg string) error {
+	return manager.registerExternal(kindArg)
+}
+
 // IsOwnerManagedByKueue returns true if the provided owner can be managed by
 // kueue.
 func IsOwnerManagedByKueue(owner *metav1.OwnerReference) bool {
-	return manager.getCallbacksForOwner(owner) != nil
+	return manager.getCallbacksForOwner(owner) != nil || manager.getExternalType(owner) != nil
 }
 
 // GetEmptyOwnerObject returns an empty object of the owner's type,
 // returns nil if the owner is not manageable by kueue.
+// For the external job types, it returns a *metav1.PartialObjectMetadata.
 func GetEmptyOwnerObject(owner *metav1.OwnerReference) client.Object {
 	if cbs := manager.getCallbacksForOwner(owner); cbs != nil {
 		return cbs.JobType.DeepCopyObject().(client.Object)
 	}
+	if jobType := manager.getExternalType(owner); jobType != nil {
+		return jobType.DeepCopy()
+	}
 	return nil
 }
@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -321,3 +322,57 @@ func TestGetCallbacksForOwner(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterExternal(t *testing.T) {
+	cases := map[string]struct {
+		manager   *integrationManager
+		kindArg   string
+		wantError error
+		wantGVK   schema.GroupVersionKind
+	}{
+		"successful": {
+			manager: &integrationManager{},
+			kindArg: "FooJob.v1.example.com",
+			wantGVK: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "FooJob"},
+		},
+		"missing version": {
+			manager:   &integrationManager{},
+			kindArg:   "FooJob.example",
+			wantError: errInvalidExternalKind,
+		},
+		"duplicate": {
+			manager: func() *integrationManager {
+				m := &integrationManager{}
+				_ = m.registerExternal("FooJob.v1.example.com")
+				return m
+			}(),
+			kindArg:   "FooJob.v1.example.com",
+			wantError: errDuplicateFrameworkName,
+			wantGVK:   schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "FooJob"},
+		},
+	}
+	for tcName, tc := range cases {
+		t.Run(tcName, func(t *testing.T) {
+			gotError := tc.manager.registerExternal(tc.kindArg)
+			if diff := cmp.Diff(tc.wantError, gotError, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Unexpected error (-want +got):\n%s", diff)
+			}
+			if tc.wantGVK.Empty() {
+				return
+			}
+			apiVersion, kind := tc.wantGVK.ToAPIVersionAndKind()
+			owner := &metav1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: "parent"}
+			got := tc.manager.getExternalType(owner)
+			if got == nil {
+				t.Fatalf("The owner isn't recognized as an external job")
+			}
+			if diff := cmp.Diff(tc.wantGVK, got.GroupVersionKind()); diff != "" {
+				t.Errorf("Unexpected job type (-want +got):\n%s", diff)
+			}
+			otherVersion := &metav1.OwnerReference{APIVersion: tc.wantGVK.Group + "/v2", Kind: kind, Name: "parent"}
+			if tc.manager.getExternalType(otherVersion) != nil {
+				t.Errorf("An owner with a different version is recognized as an external job")
+			}
+		})
+	}
+}
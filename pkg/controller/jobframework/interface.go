@@ -15,9 +15,12 @@ package jobframework
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -107,3 +110,31 @@ func workloadPriorityClassName(job GenericJob) string {
 	}
 	return ""
 }
+
+// podSetFlavorGroups returns the PodSet flavor groups requested through the
+// annotations of the job. Names that don't match any of the PodSets are ignored.
+func podSetFlavorGroups(job GenericJob, podSets []kueue.PodSet) []kueue.PodSetFlavorGroup {
+	annotations := job.Object().GetAnnotations()
+	value := annotations[constants.FlavorConsistentPodSetsAnnotation]
+	if value == "" {
+		return nil
+	}
+	known := sets.New[string]()
+	for i := range podSets {
+		known.Insert(podSets[i].Name)
+	}
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if known.Has(name) && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) < 2 {
+		return nil
+	}
+	return []kueue.PodSetFlavorGroup{{
+		PodSets:   names,
+		NodeLabel: annotations[constants.FlavorConsistencyNodeLabelAnnotation],
+	}}
+}
@@ -18,6 +18,8 @@ import (
 	"errors"
 	"fmt"
 
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
@@ -36,6 +38,7 @@ import (
 	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/podset"
+	"sigs.k8s.io/kueue/pkg/tracing"
 	"sigs.k8s.io/kueue/pkg/util/equality"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
 	utilpriority "sigs.k8s.io/kueue/pkg/util/priority"
@@ -69,6 +72,7 @@ type Options struct {
 	KubeServerVersion          *kubeversion.ServerVersionFetcher
 	PodNamespaceSelector       *metav1.LabelSelector
 	PodSelector                *metav1.LabelSelector
+	CapacityCheck              *CapacityCheck
 }
 
 // Option configures the reconciler.
@@ -133,6 +137,29 @@ func NewReconciler(
 }
 
 func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Request, job GenericJob) (ctrl.Result, error) {
+	spanOpts := []trace.SpanStartOption{
+		trace.WithAttributes(
+			tracing.JobKindKey.String(job.GVK().Kind),
+			tracing.JobNameKey.String(req.Name),
+			tracing.WorkloadNamespaceKey.String(req.Namespace),
+		),
+	}
+	// The lifecycle of the workload can only be linked once it exists.
+	var wl kueue.Workload
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: req.Namespace, Name: GetWorkloadNameForOwnerWithGVK(req.Name, job.GVK())}, &wl); err == nil {
+		spanOpts = append(spanOpts, trace.WithLinks(tracing.WorkloadLink(&wl)))
+	}
+	ctx, span := tracing.Tracer().Start(ctx, "JobReconciler.ReconcileGenericJob", spanOpts...)
+	defer span.End()
+	result, err := r.reconcileGenericJob(ctx, req, job)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to reconcile the job")
+	}
+	return result, err
+}
+
+func (r *JobReconciler) reconcileGenericJob(ctx context.Context, req ctrl.Request, job GenericJob) (ctrl.Result, error) {
 	object := job.Object()
 	log := ctrl.LoggerFrom(ctx).WithValues("job", req.String(), "gvk", job.GVK())
 	ctx = ctrl.LoggerInto(ctx, log)
@@ -261,6 +288,16 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	// 3.1 update the priority of the workload when the workloadPriorityClass of the job changes.
+	if wpcName := workloadPriorityClassName(job); wpcName != "" && wl.Spec.PriorityClassSource == constants.WorkloadPriorityClassSource && wpcName != wl.Spec.PriorityClassName {
+		if err := r.updateWorkloadPriorityClass(ctx, wl, wpcName); err != nil {
+			log.Error(err, "Updating the workloadPriorityClass of the workload")
+			return ctrl.Result{}, err
+		}
+		r.record.Eventf(object, corev1.EventTypeNormal, "UpdatedWorkload", "Updated the priority of the workload to the workloadPriorityClass %q", wpcName)
+		return ctrl.Result{}, nil
+	}
+
 	// 4. update reclaimable counts if implemented by the job
 	if jobRecl, implementsReclaimable := job.(JobWithReclaimablePods); implementsReclaimable {
 		if rp := jobRecl.ReclaimablePods(); !workload.ReclaimablePodsAreEqual(rp, wl.Status.ReclaimablePods) {
@@ -275,7 +312,7 @@ func (r *JobReconciler) ReconcileGenericJob(ctx context.Context, req ctrl.Reques
 
 	// 5. handle WaitForPodsReady only for a standalone job.
 	// handle a job when waitForPodsReady is enabled, and it is the main job
-	if r.waitForPodsReady {
+	if r.waitsForPodsReady(ctx, wl) {
 		log.V(5).Info("Handling a job when waitForPodsReady is enabled")
 		condition := generatePodsReadyCondition(job, wl)
 		// optimization to avoid sending the update request if the status didn't change
@@ -485,6 +522,10 @@ func (r *JobReconciler) equivalentToWorkload(job GenericJob, object client.Objec
 	}
 
 	jobPodSets := resetMinCounts(job.PodSets())
+	// The environment variables injected from the flavors are not part of the workload.
+	for i := range jobPodSets {
+		podset.RemoveFlavorEnv(&jobPodSets[i].Template)
+	}
 
 	if !workload.CanBePartiallyAdmitted(wl) || !workload.HasQuotaReservation(wl) {
 		// the two sets should fully match.
@@ -610,8 +651,9 @@ func (r *JobReconciler) constructWorkload(ctx context.Context, job GenericJob, o
 			Finalizers: []string{kueue.ResourceInUseFinalizerName},
 		},
 		Spec: kueue.WorkloadSpec{
-			PodSets:   resetMinCounts(podSets),
-			QueueName: QueueName(job),
+			PodSets:            resetMinCounts(podSets),
+			QueueName:          QueueName(job),
+			PodSetFlavorGroups: podSetFlavorGroups(job, podSets),
 		},
 	}
 
@@ -626,6 +668,10 @@ func (r *JobReconciler) constructWorkload(ctx context.Context, job GenericJob, o
 		)
 	}
 
+	if reservation := object.GetLabels()[controllerconsts.ReservationLabel]; reservation != "" {
+		wl.Labels[controllerconsts.ReservationLabel] = reservation
+	}
+
 	priorityClassName, source, p, err := r.extractPriority(ctx, podSets, job)
 	if err != nil {
 		return nil, err
@@ -634,6 +680,14 @@ func (r *JobReconciler) constructWorkload(ctx context.Context, job GenericJob, o
 	wl.Spec.PriorityClassName = priorityClassName
 	wl.Spec.Priority = &p
 	wl.Spec.PriorityClassSource = source
+	if source == constants.WorkloadPriorityClassSource {
+		preemptionPolicy, protected, err := utilpriority.GetPreemptionFromWorkloadPriorityClass(ctx, r.client, priorityClassName)
+		if err != nil {
+			return nil, err
+		}
+		wl.Spec.PreemptionPolicy = preemptionPolicy
+		wl.Spec.Protected = protected
+	}
 
 	if err := ctrl.SetControllerReference(object, wl, r.client.Scheme()); err != nil {
 		return nil, err
@@ -641,6 +695,19 @@ func (r *JobReconciler) constructWorkload(ctx context.Context, job GenericJob, o
 	return wl, nil
 }
 
+// updateWorkloadPriorityClass sets the priority of the workload from the
+// workloadPriorityClass with the given name.
+func (r *JobReconciler) updateWorkloadPriorityClass(ctx context.Context, wl *kueue.Workload, wpcName string) error {
+	wpc := &kueue.WorkloadPriorityClass{}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: wpcName}, wpc); err != nil {
+		return err
+	}
+	if !utilpriority.SetFromWorkloadPriorityClass(wl, wpc) {
+		return nil
+	}
+	return r.client.Update(ctx, wl)
+}
+
 func (r *JobReconciler) extractPriority(ctx context.Context, podSets []kueue.PodSet, job GenericJob) (string, string, int32, error) {
 	if workloadPriorityClass := workloadPriorityClassName(job); len(workloadPriorityClass) > 0 {
 		return utilpriority.GetPriorityFromWorkloadPriorityClass(ctx, r.client, workloadPriorityClass)
@@ -714,6 +781,20 @@ func (r *JobReconciler) handleJobWithNoWorkload(ctx context.Context, job Generic
 	return nil
 }
 
+// waitsForPodsReady returns whether the PodsReady condition is tracked for the
+// workload, as set by the ClusterQueue that reserved quota for it, or by the
+// configuration of Kueue if the ClusterQueue doesn't override it.
+func (r *JobReconciler) waitsForPodsReady(ctx context.Context, wl *kueue.Workload) bool {
+	if !workload.HasQuotaReservation(wl) {
+		return r.waitForPodsReady
+	}
+	var cq kueue.ClusterQueue
+	if err := r.client.Get(ctx, client.ObjectKey{Name: string(wl.Status.Admission.ClusterQueue)}, &cq); err != nil || cq.Spec.WaitForPodsReady == nil {
+		return r.waitForPodsReady
+	}
+	return cq.Spec.WaitForPodsReady.Enable
+}
+
 func generatePodsReadyCondition(job GenericJob, wl *kueue.Workload) metav1.Condition {
 	conditionStatus := metav1.ConditionFalse
 	message := "Not all pods are ready or succeeded"
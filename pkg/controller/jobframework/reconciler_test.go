@@ -24,8 +24,12 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	kubeflow "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
 	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"sigs.k8s.io/kueue/pkg/controller/constants"
 	_ "sigs.k8s.io/kueue/pkg/controller/jobs"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingjob "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
@@ -34,6 +38,23 @@ import (
 	. "sigs.k8s.io/kueue/pkg/controller/jobframework"
 )
 
+var externalJobGVK = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "FooJob"}
+
+func init() {
+	utilruntime.Must(RegisterExternalJobType("FooJob.v1.example.com"))
+}
+
+func makeExternalJob(name, ns, queue string) *unstructured.Unstructured {
+	job := &unstructured.Unstructured{}
+	job.SetGroupVersionKind(externalJobGVK)
+	job.SetName(name)
+	job.SetNamespace(ns)
+	if queue != "" {
+		job.SetLabels(map[string]string{constants.QueueLabel: queue})
+	}
+	return job
+}
+
 func TestIsParentJobManaged(t *testing.T) {
 	parentJobName := "test-job-parent"
 	childJobName := "test-job-child"
@@ -85,6 +106,25 @@ func TestIsParentJobManaged(t *testing.T) {
 				OwnerReference(parentJobName, kubeflow.SchemeGroupVersionKind).
 				Obj(),
 		},
+		"child job has ownerReference with an existing external workload owner, and the parent job has queue-name label": {
+			parentJob: makeExternalJob(parentJobName, jobNamespace, "test-q"),
+			job: testingjob.MakeJob(childJobName, jobNamespace).
+				OwnerReference(parentJobName, externalJobGVK).
+				Obj(),
+			wantManaged: true,
+		},
+		"child job has ownerReference with an existing external workload owner, and the parent job doesn't have queue-name label": {
+			parentJob: makeExternalJob(parentJobName, jobNamespace, ""),
+			job: testingjob.MakeJob(childJobName, jobNamespace).
+				OwnerReference(parentJobName, externalJobGVK).
+				Obj(),
+		},
+		"child job has ownerReference with a non-existing external workload owner": {
+			job: testingjob.MakeJob(childJobName, jobNamespace).
+				OwnerReference(parentJobName, externalJobGVK).
+				Obj(),
+			wantErr: ErrWorkloadOwnerNotFound,
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -81,7 +81,14 @@ func ValidateUpdateForParentWorkload(oldJob, newJob GenericJob) field.ErrorList
 	return allErrs
 }
 
+// ValidateUpdateForWorkloadPriorityClassName allows changing the
+// workloadPriorityClass of a job to another one, but not adding or removing
+// it, as the priority of the workload would come from a different source.
 func ValidateUpdateForWorkloadPriorityClassName(oldJob, newJob GenericJob) field.ErrorList {
-	allErrs := apivalidation.ValidateImmutableField(workloadPriorityClassName(oldJob), workloadPriorityClassName(newJob), workloadPriorityClassNamePath)
+	oldName, newName := workloadPriorityClassName(oldJob), workloadPriorityClassName(newJob)
+	if oldName != "" && newName != "" {
+		return nil
+	}
+	allErrs := apivalidation.ValidateImmutableField(oldName, newName, workloadPriorityClassNamePath)
 	return allErrs
 }
@@ -0,0 +1,362 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/podset"
+	"sigs.k8s.io/kueue/pkg/util/fieldpath"
+)
+
+// FrameworkName returns the name under which the generic framework is
+// registered, "<group>/<lowercase kind>".
+func FrameworkName(cfg *configapi.GenericFramework) string {
+	return cfg.Group + "/" + strings.ToLower(cfg.Kind)
+}
+
+// Register registers the generic frameworks with the job framework, it
+// needs to be called before the integrations are set up.
+func Register(cfgs []configapi.GenericFramework) error {
+	for i := range cfgs {
+		fw, err := newFramework(&cfgs[i])
+		if err != nil {
+			return fmt.Errorf("generic framework %s: %w", FrameworkName(&cfgs[i]), err)
+		}
+		if err := jobframework.RegisterIntegration(FrameworkName(&cfgs[i]), fw.callbacks()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type podSetPaths struct {
+	name     string
+	template fieldpath.Path
+	// replicas is nil when the podSet always has 1 pod.
+	replicas fieldpath.Path
+}
+
+// framework holds the parsed configuration of a generic framework.
+type framework struct {
+	gvk                    schema.GroupVersionKind
+	suspend                fieldpath.Path
+	podSets                []podSetPaths
+	conditions             fieldpath.Path
+	finishedConditionTypes sets.Set[string]
+	podsReadyConditionType string
+	// active is nil when the job is considered active while it runs.
+	active fieldpath.Path
+}
+
+func newFramework(cfg *configapi.GenericFramework) (*framework, error) {
+	fw := &framework{
+		gvk:                    schema.GroupVersionKind{Group: cfg.Group, Version: cfg.Version, Kind: cfg.Kind},
+		finishedConditionTypes: sets.New(cfg.FinishedConditionTypes...),
+	}
+	if cfg.PodsReadyConditionType != nil {
+		fw.podsReadyConditionType = *cfg.PodsReadyConditionType
+	}
+	var err error
+	if fw.suspend, err = fieldpath.Parse(cfg.SuspendPath); err != nil {
+		return nil, err
+	}
+	conditionsPath := configapi.DefaultGenericFrameworkConditionsPath
+	if cfg.ConditionsPath != nil {
+		conditionsPath = *cfg.ConditionsPath
+	}
+	if fw.conditions, err = fieldpath.Parse(conditionsPath); err != nil {
+		return nil, err
+	}
+	if cfg.ActivePath != nil {
+		if fw.active, err = fieldpath.Parse(*cfg.ActivePath); err != nil {
+			return nil, err
+		}
+	}
+	fw.podSets = make([]podSetPaths, len(cfg.PodSets))
+	for i := range cfg.PodSets {
+		ps := &fw.podSets[i]
+		ps.name = cfg.PodSets[i].Name
+		if ps.template, err = fieldpath.Parse(cfg.PodSets[i].TemplatePath); err != nil {
+			return nil, err
+		}
+		if cfg.PodSets[i].ReplicasPath != nil {
+			if ps.replicas, err = fieldpath.Parse(*cfg.PodSets[i].ReplicasPath); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fw, nil
+}
+
+func (fw *framework) callbacks() jobframework.IntegrationCallbacks {
+	return jobframework.IntegrationCallbacks{
+		SetupIndexes: func(ctx context.Context, indexer client.FieldIndexer) error {
+			return jobframework.SetupWorkloadOwnerIndex(ctx, indexer, fw.gvk)
+		},
+		NewReconciler: jobframework.NewGenericReconciler(func() jobframework.GenericJob {
+			return fw.newJob(fw.newObject())
+		}, nil),
+		SetupWebhook:           fw.setupWebhook,
+		JobType:                fw.newObject(),
+		IsManagingObjectsOwner: fw.isOwner,
+	}
+}
+
+func (fw *framework) newObject() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(fw.gvk)
+	return obj
+}
+
+func (fw *framework) newJob(obj *unstructured.Unstructured) *Job {
+	return &Job{obj: obj, fw: fw}
+}
+
+func (fw *framework) isOwner(owner *metav1.OwnerReference) bool {
+	return owner.Kind == fw.gvk.Kind && strings.HasPrefix(owner.APIVersion, fw.gvk.Group+"/")
+}
+
+// Job implements jobframework.GenericJob for an unstructured object, using
+// the paths of its framework to read and update it.
+type Job struct {
+	obj *unstructured.Unstructured
+	fw  *framework
+}
+
+var _ jobframework.GenericJob = (*Job)(nil)
+
+func (j *Job) Object() client.Object {
+	return j.obj
+}
+
+func (j *Job) GVK() schema.GroupVersionKind {
+	return j.fw.gvk
+}
+
+func (j *Job) IsSuspended() bool {
+	v, _, _ := j.fw.suspend.Get(j.obj.Object)
+	suspended, _ := v.(bool)
+	return suspended
+}
+
+func (j *Job) Suspend() {
+	// The webhook rejects the objects for which the path can't be set.
+	_ = j.fw.suspend.Set(j.obj.Object, true)
+}
+
+func (j *Job) IsActive() bool {
+	if j.fw.active == nil {
+		return !j.IsSuspended()
+	}
+	v, _, _ := j.fw.active.Get(j.obj.Object)
+	active, _ := toInt64(v)
+	return active > 0
+}
+
+func (j *Job) PodSets() []kueue.PodSet {
+	podSets, _ := j.podSets()
+	return podSets
+}
+
+// podSets returns the podSets of the job, along with the first error found
+// reading them. The podSets are returned with empty templates or a count of
+// 1 when their fields can't be read.
+func (j *Job) podSets() ([]kueue.PodSet, error) {
+	var firstErr error
+	podSets := make([]kueue.PodSet, len(j.fw.podSets))
+	for i := range j.fw.podSets {
+		paths := &j.fw.podSets[i]
+		podSets[i].Name = paths.name
+		template, err := j.template(paths)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		podSets[i].Template = template
+		count, err := j.replicas(paths)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		podSets[i].Count = count
+	}
+	return podSets, firstErr
+}
+
+func (j *Job) template(paths *podSetPaths) (corev1.PodTemplateSpec, error) {
+	var template corev1.PodTemplateSpec
+	v, found, err := paths.template.Get(j.obj.Object)
+	if err != nil {
+		return template, err
+	}
+	if !found {
+		return template, fmt.Errorf("pod template %s not found", paths.template)
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return template, fmt.Errorf("pod template %s is %T, not an object", paths.template, v)
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &template); err != nil {
+		return template, fmt.Errorf("pod template %s: %w", paths.template, err)
+	}
+	return template, nil
+}
+
+func (j *Job) replicas(paths *podSetPaths) (int32, error) {
+	if paths.replicas == nil {
+		return 1, nil
+	}
+	v, found, err := paths.replicas.Get(j.obj.Object)
+	if err != nil || !found {
+		return 1, err
+	}
+	count, ok := toInt64(v)
+	if !ok || count < 0 || count > math.MaxInt32 {
+		return 1, fmt.Errorf("replicas %s is %v, not a valid count", paths.replicas, v)
+	}
+	return int32(count), nil
+}
+
+func (j *Job) setTemplate(paths *podSetPaths, template *corev1.PodTemplateSpec) error {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(template)
+	if err != nil {
+		return err
+	}
+	return paths.template.Set(j.obj.Object, m)
+}
+
+func (j *Job) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error {
+	if len(podSetsInfo) != len(j.fw.podSets) {
+		return podset.BadPodSetsInfoLenError(len(j.fw.podSets), len(podSetsInfo))
+	}
+	for i := range j.fw.podSets {
+		paths := &j.fw.podSets[i]
+		template, err := j.template(paths)
+		if err != nil {
+			return err
+		}
+		if err := podset.Merge(&template.ObjectMeta, &template.Spec, podSetsInfo[i]); err != nil {
+			return err
+		}
+		if err := j.setTemplate(paths, &template); err != nil {
+			return err
+		}
+	}
+	return j.fw.suspend.Set(j.obj.Object, false)
+}
+
+func (j *Job) RestorePodSetsInfo(podSetsInfo []podset.PodSetInfo) bool {
+	if len(podSetsInfo) != len(j.fw.podSets) {
+		return false
+	}
+	changed := false
+	for i := range j.fw.podSets {
+		paths := &j.fw.podSets[i]
+		template, err := j.template(paths)
+		if err != nil {
+			continue
+		}
+		if podset.RestorePodSpec(&template.ObjectMeta, &template.Spec, podSetsInfo[i]) {
+			changed = j.setTemplate(paths, &template) == nil || changed
+		}
+	}
+	return changed
+}
+
+func (j *Job) Finished() (metav1.Condition, bool) {
+	for _, c := range j.conditions() {
+		if c.Status == metav1.ConditionTrue && j.fw.finishedConditionTypes.Has(c.Type) {
+			reason := c.Reason
+			if reason == "" {
+				reason = c.Type
+			}
+			return metav1.Condition{
+				Type:    kueue.WorkloadFinished,
+				Status:  metav1.ConditionTrue,
+				Reason:  reason,
+				Message: c.Message,
+			}, true
+		}
+	}
+	return metav1.Condition{}, false
+}
+
+func (j *Job) PodsReady() bool {
+	if j.fw.podsReadyConditionType == "" {
+		return !j.IsSuspended()
+	}
+	for _, c := range j.conditions() {
+		if c.Type == j.fw.podsReadyConditionType {
+			return c.Status == metav1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// conditions returns the conditions in the status of the job, skipping the
+// items that can't be read as conditions.
+func (j *Job) conditions() []metav1.Condition {
+	v, _, _ := j.fw.conditions.Get(j.obj.Object)
+	items, _ := v.([]interface{})
+	conditions := make([]metav1.Condition, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var c metav1.Condition
+		c.Type, _, _ = unstructured.NestedString(m, "type")
+		status, _, _ := unstructured.NestedString(m, "status")
+		c.Status = metav1.ConditionStatus(status)
+		c.Reason, _, _ = unstructured.NestedString(m, "reason")
+		c.Message, _, _ = unstructured.NestedString(m, "message")
+		conditions = append(conditions, c)
+	}
+	return conditions
+}
+
+// toInt64 converts the numbers decoded from JSON, which are float64 when
+// decoded by encoding/json, to int64.
+func toInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case int32:
+		return int64(n), true
+	case int:
+		return int64(n), true
+	case float64:
+		if n != math.Trunc(n) {
+			return 0, false
+		}
+		return int64(n), true
+	}
+	return 0, false
+}
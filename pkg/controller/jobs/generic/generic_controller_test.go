@@ -0,0 +1,362 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/podset"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+var trainerConfig = configapi.GenericFramework{
+	Group:       "example.com",
+	Version:     "v1",
+	Kind:        "Trainer",
+	SuspendPath: ".spec.suspend",
+	PodSets: []configapi.GenericPodSet{
+		{Name: "launcher", TemplatePath: ".spec.launcher.template"},
+		{Name: "worker", TemplatePath: ".spec.workers[0].template", ReplicasPath: ptr.To(".spec.workers[0].replicas")},
+	},
+	ConditionsPath:         ptr.To(configapi.DefaultGenericFrameworkConditionsPath),
+	FinishedConditionTypes: []string{"Succeeded", "Failed"},
+	PodsReadyConditionType: ptr.To("Running"),
+	ActivePath:             ptr.To(".status.active"),
+}
+
+func newTrainerFramework(t *testing.T) *framework {
+	t.Helper()
+	fw, err := newFramework(&trainerConfig)
+	if err != nil {
+		t.Fatalf("Could not parse the framework: %v", err)
+	}
+	return fw
+}
+
+func podTemplate(image string) map[string]interface{} {
+	return map[string]interface{}{
+		"spec": map[string]interface{}{
+			"restartPolicy": "Never",
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name":  "c",
+					"image": image,
+				},
+			},
+		},
+	}
+}
+
+// trainerWrapper wraps an unstructured Trainer.
+type trainerWrapper struct{ unstructured.Unstructured }
+
+func makeTrainer(name, ns string) *trainerWrapper {
+	var w trainerWrapper
+	w.SetAPIVersion("example.com/v1")
+	w.SetKind("Trainer")
+	w.SetName(name)
+	w.SetNamespace(ns)
+	w.Object["spec"] = map[string]interface{}{
+		"suspend": true,
+		"launcher": map[string]interface{}{
+			"template": podTemplate("launcher"),
+		},
+		"workers": []interface{}{
+			map[string]interface{}{
+				"replicas": int64(3),
+				"template": podTemplate("worker"),
+			},
+		},
+	}
+	return &w
+}
+
+func (w *trainerWrapper) Obj() *unstructured.Unstructured {
+	return &w.Unstructured
+}
+
+func (w *trainerWrapper) Queue(queue string) *trainerWrapper {
+	w.SetLabels(map[string]string{constants.QueueLabel: queue})
+	return w
+}
+
+func (w *trainerWrapper) Field(value interface{}, fields ...string) *trainerWrapper {
+	if err := unstructured.SetNestedField(w.Object, value, fields...); err != nil {
+		panic(err)
+	}
+	return w
+}
+
+func (w *trainerWrapper) RemoveField(fields ...string) *trainerWrapper {
+	unstructured.RemoveNestedField(w.Object, fields...)
+	return w
+}
+
+func (w *trainerWrapper) Replicas(replicas interface{}) *trainerWrapper {
+	workers, _, _ := unstructured.NestedSlice(w.Object, "spec", "workers")
+	workers[0].(map[string]interface{})["replicas"] = replicas
+	return w.Field(workers, "spec", "workers")
+}
+
+func (w *trainerWrapper) Condition(condType string, status metav1.ConditionStatus, reason string) *trainerWrapper {
+	conditions, _, _ := unstructured.NestedSlice(w.Object, "status", "conditions")
+	conditions = append(conditions, map[string]interface{}{
+		"type":    condType,
+		"status":  string(status),
+		"reason":  reason,
+		"message": reason + " message",
+	})
+	return w.Field(conditions, "status", "conditions")
+}
+
+func TestPodSets(t *testing.T) {
+	fw := newTrainerFramework(t)
+	cases := map[string]struct {
+		job          *unstructured.Unstructured
+		wantPodSets  []kueue.PodSet
+		wantErrorMsg string
+	}{
+		"replicas set": {
+			job: makeTrainer("job", "ns").Obj(),
+			wantPodSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("launcher", 1).Image("launcher").Obj(),
+				*utiltesting.MakePodSet("worker", 3).Image("worker").Obj(),
+			},
+		},
+		"replicas decoded as float": {
+			job: makeTrainer("job", "ns").Replicas(float64(2)).Obj(),
+			wantPodSets: []kueue.PodSet{
+				*utiltesting.MakePodSet("launcher", 1).Image("launcher").Obj(),
+				*utiltesting.MakePodSet("worker", 2).Image("worker").Obj(),
+			},
+		},
+		"missing launcher template": {
+			job: makeTrainer("job", "ns").Field(map[string]interface{}{}, "spec", "launcher").Obj(),
+			wantPodSets: []kueue.PodSet{
+				{Name: "launcher", Count: 1},
+				*utiltesting.MakePodSet("worker", 3).Image("worker").Obj(),
+			},
+			wantErrorMsg: "pod template .spec.launcher.template not found",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			gotPodSets, err := fw.newJob(tc.job).podSets()
+			gotErrorMsg := ""
+			if err != nil {
+				gotErrorMsg = err.Error()
+			}
+			if gotErrorMsg != tc.wantErrorMsg {
+				t.Errorf("Unexpected error: %q, want %q", gotErrorMsg, tc.wantErrorMsg)
+			}
+			if diff := cmp.Diff(tc.wantPodSets, gotPodSets, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(corev1.Container{}, "Resources")); diff != "" {
+				t.Errorf("Unexpected podSets (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRunWithPodSetsInfo(t *testing.T) {
+	fw := newTrainerFramework(t)
+	job := fw.newJob(makeTrainer("job", "ns").Obj())
+	podSetsInfo := []podset.PodSetInfo{
+		{Name: "launcher", Count: 1, NodeSelector: map[string]string{"flavor": "on-demand"}},
+		{Name: "worker", Count: 3, NodeSelector: map[string]string{"flavor": "spot"}},
+	}
+
+	if err := job.RunWithPodSetsInfo(podSetsInfo[:1]); err == nil {
+		t.Errorf("Expected an error for a wrong number of podSets")
+	}
+	if err := job.RunWithPodSetsInfo(podSetsInfo); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if job.IsSuspended() {
+		t.Errorf("The job is still suspended")
+	}
+	podSets, err := job.podSets()
+	if err != nil {
+		t.Fatalf("Unexpected error reading the podSets: %v", err)
+	}
+	for i, ps := range podSets {
+		if diff := cmp.Diff(podSetsInfo[i].NodeSelector, ps.Template.Spec.NodeSelector); diff != "" {
+			t.Errorf("Unexpected nodeSelector for podSet %s (-want,+got):\n%s", ps.Name, diff)
+		}
+	}
+
+	job.Suspend()
+	originalPodSetsInfo := []podset.PodSetInfo{{Name: "launcher", Count: 1}, {Name: "worker", Count: 3}}
+	if !job.RestorePodSetsInfo(originalPodSetsInfo) {
+		t.Errorf("RestorePodSetsInfo didn't report a change")
+	}
+	if job.RestorePodSetsInfo(originalPodSetsInfo) {
+		t.Errorf("RestorePodSetsInfo reported a change on a restored job")
+	}
+	if !job.IsSuspended() {
+		t.Errorf("The job isn't suspended")
+	}
+	podSets, err = job.podSets()
+	if err != nil {
+		t.Fatalf("Unexpected error reading the podSets: %v", err)
+	}
+	for _, ps := range podSets {
+		if len(ps.Template.Spec.NodeSelector) != 0 {
+			t.Errorf("Unexpected nodeSelector for podSet %s after the restore: %v", ps.Name, ps.Template.Spec.NodeSelector)
+		}
+	}
+}
+
+func TestStatus(t *testing.T) {
+	fw := newTrainerFramework(t)
+	cases := map[string]struct {
+		job              *unstructured.Unstructured
+		wantActive       bool
+		wantPodsReady    bool
+		wantFinished     bool
+		wantFinishedCond metav1.Condition
+	}{
+		"suspended": {
+			job: makeTrainer("job", "ns").Obj(),
+		},
+		"running with active pods": {
+			job: makeTrainer("job", "ns").
+				Field(false, "spec", "suspend").
+				Field(int64(4), "status", "active").
+				Condition("Running", metav1.ConditionTrue, "PodsRunning").
+				Obj(),
+			wantActive:    true,
+			wantPodsReady: true,
+		},
+		"suspended with active pods": {
+			job: makeTrainer("job", "ns").
+				Field(float64(1), "status", "active").
+				Condition("Running", metav1.ConditionFalse, "Suspended").
+				Obj(),
+			wantActive: true,
+		},
+		"failed": {
+			job: makeTrainer("job", "ns").
+				Field(false, "spec", "suspend").
+				Field(int64(0), "status", "active").
+				Condition("Running", metav1.ConditionFalse, "Finished").
+				Condition("Succeeded", metav1.ConditionFalse, "").
+				Condition("Failed", metav1.ConditionTrue, "BackoffLimitExceeded").
+				Obj(),
+			wantFinished: true,
+			wantFinishedCond: metav1.Condition{
+				Type:    kueue.WorkloadFinished,
+				Status:  metav1.ConditionTrue,
+				Reason:  "BackoffLimitExceeded",
+				Message: "BackoffLimitExceeded message",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			job := fw.newJob(tc.job)
+			if got := job.IsActive(); got != tc.wantActive {
+				t.Errorf("IsActive() = %t, want %t", got, tc.wantActive)
+			}
+			if got := job.PodsReady(); got != tc.wantPodsReady {
+				t.Errorf("PodsReady() = %t, want %t", got, tc.wantPodsReady)
+			}
+			gotCond, gotFinished := job.Finished()
+			if gotFinished != tc.wantFinished {
+				t.Errorf("Finished() = %t, want %t", gotFinished, tc.wantFinished)
+			}
+			if diff := cmp.Diff(tc.wantFinishedCond, gotCond); diff != "" {
+				t.Errorf("Unexpected finished condition (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	fw := newTrainerFramework(t)
+	cb := fw.callbacks()
+	workloadCmpOpts := []cmp.Option{
+		cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(kueue.Workload{}, "TypeMeta", "ObjectMeta"),
+		cmpopts.IgnoreFields(kueue.WorkloadSpec{}, "Priority"),
+		cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"),
+		cmpopts.IgnoreFields(kueue.PodSet{}, "Template"),
+	}
+	cases := map[string]struct {
+		reconcilerOptions []jobframework.Option
+		job               *unstructured.Unstructured
+		wantWorkloads     []kueue.Workload
+	}{
+		"workload is created with podsets": {
+			job: makeTrainer("job", "ns").Queue("queue").Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").
+					Queue("queue").
+					PodSets(
+						*utiltesting.MakePodSet("launcher", 1).Obj(),
+						*utiltesting.MakePodSet("worker", 3).Obj(),
+					).
+					Obj(),
+			},
+		},
+		"job without a queue is ignored": {
+			job: makeTrainer("job", "ns").Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			clientBuilder := utiltesting.NewClientBuilder()
+			if err := cb.SetupIndexes(ctx, utiltesting.AsIndexer(clientBuilder)); err != nil {
+				t.Fatalf("Could not setup indexes: %v", err)
+			}
+			kClient := clientBuilder.WithObjects(tc.job).Build()
+			recorder := record.NewBroadcaster().NewRecorder(kClient.Scheme(), corev1.EventSource{Component: "test"})
+			reconciler := cb.NewReconciler(kClient, recorder, tc.reconcilerOptions...)
+
+			if _, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tc.job)}); err != nil {
+				t.Errorf("Reconcile returned error: %v", err)
+			}
+
+			var gotWorkloads kueue.WorkloadList
+			if err := kClient.List(ctx, &gotWorkloads); err != nil {
+				t.Fatalf("Could not get Workloads after reconcile: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWorkloads, gotWorkloads.Items, workloadCmpOpts...); diff != "" {
+				t.Errorf("Workloads after reconcile (-want,+got):\n%s", diff)
+			}
+			for _, wl := range gotWorkloads.Items {
+				owner := metav1.GetControllerOf(&wl)
+				if owner == nil || !cb.IsManagingObjectsOwner(owner) || owner.Name != tc.job.GetName() {
+					t.Errorf("Unexpected owner of the workload: %v", owner)
+				}
+			}
+		})
+	}
+}
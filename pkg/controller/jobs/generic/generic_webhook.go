@@ -0,0 +1,136 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+)
+
+// Webhook defaults and validates the objects of a generic framework. As the
+// resources aren't known in advance, it's served at the paths generated by
+// controller-runtime, "/mutate-<group>-<version>-<lowercase kind>" and
+// "/validate-<group>-<version>-<lowercase kind>" with the dots of the group
+// replaced by dashes, and the webhook configurations have to be added by the
+// administrator.
+type Webhook struct {
+	fw                         *framework
+	manageJobsWithoutQueueName bool
+	capacityCheck              *jobframework.CapacityCheck
+}
+
+func (fw *framework) setupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
+	options := jobframework.DefaultOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	wh := &Webhook{
+		fw:                         fw,
+		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
+		capacityCheck:              options.CapacityCheck,
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(fw.newObject()).
+		WithDefaulter(wh).
+		WithValidator(wh).
+		Complete()
+}
+
+func (w *Webhook) fromObject(obj runtime.Object) *Job {
+	return w.fw.newJob(obj.(*unstructured.Unstructured))
+}
+
+var _ webhook.CustomDefaulter = &Webhook{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (w *Webhook) Default(ctx context.Context, obj runtime.Object) error {
+	job := w.fromObject(obj)
+	log := ctrl.LoggerFrom(ctx).WithName("generic-job-webhook")
+	log.V(5).Info("Applying defaults", "job", klog.KObj(job.obj), "gvk", w.fw.gvk)
+	jobframework.ApplyDefaultForSuspend(job, w.manageJobsWithoutQueueName)
+	return nil
+}
+
+var _ webhook.CustomValidator = &Webhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *Webhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	job := w.fromObject(obj)
+	log := ctrl.LoggerFrom(ctx).WithName("generic-job-webhook")
+	log.V(5).Info("Validating create", "job", klog.KObj(job.obj), "gvk", w.fw.gvk)
+	warnings, capacityErrs := jobframework.ValidateCreateForCapacity(job, w.capacityCheck)
+	return warnings, append(w.validateCreate(job), capacityErrs...).ToAggregate()
+}
+
+func (w *Webhook) validateCreate(job *Job) field.ErrorList {
+	var allErrors field.ErrorList
+	if w.manageJobsWithoutQueueName || jobframework.QueueName(job) != "" {
+		allErrors = append(allErrors, w.validatePaths(job)...)
+	}
+	allErrors = append(allErrors, jobframework.ValidateCreateForQueueName(job)...)
+	return allErrors
+}
+
+// validatePaths checks that Kueue can suspend the job and read its podSets,
+// the content of the fields is left to the validation of the resource.
+func (w *Webhook) validatePaths(job *Job) field.ErrorList {
+	var allErrors field.ErrorList
+	if v, found, err := w.fw.suspend.Get(job.obj.Object); err != nil {
+		allErrors = append(allErrors, field.Invalid(field.NewPath(w.fw.suspend.String()), nil, err.Error()))
+	} else if _, isBool := v.(bool); found && !isBool {
+		allErrors = append(allErrors, field.TypeInvalid(field.NewPath(w.fw.suspend.String()), v, "must be a boolean"))
+	}
+	for i := range w.fw.podSets {
+		paths := &w.fw.podSets[i]
+		if _, err := job.template(paths); err != nil {
+			allErrors = append(allErrors, field.Invalid(field.NewPath(paths.template.String()), nil, err.Error()))
+		}
+		if _, err := job.replicas(paths); err != nil {
+			allErrors = append(allErrors, field.Invalid(field.NewPath(paths.replicas.String()), nil, err.Error()))
+		}
+	}
+	return allErrors
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *Webhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldJob := w.fromObject(oldObj)
+	newJob := w.fromObject(newObj)
+	log := ctrl.LoggerFrom(ctx).WithName("generic-job-webhook")
+	if w.manageJobsWithoutQueueName || jobframework.QueueName(newJob) != "" {
+		log.V(5).Info("Validating update", "job", klog.KObj(newJob.obj), "gvk", w.fw.gvk)
+		allErrors := jobframework.ValidateUpdateForQueueName(oldJob, newJob)
+		allErrors = append(allErrors, w.validateCreate(newJob)...)
+		allErrors = append(allErrors, jobframework.ValidateUpdateForWorkloadPriorityClassName(oldJob, newJob)...)
+		return nil, allErrors.ToAggregate()
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *Webhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func TestDefault(t *testing.T) {
+	testcases := map[string]struct {
+		job       *unstructured.Unstructured
+		manageAll bool
+		want      *unstructured.Unstructured
+	}{
+		"unmanaged": {
+			job:  makeTrainer("job", "ns").Field(false, "spec", "suspend").Obj(),
+			want: makeTrainer("job", "ns").Field(false, "spec", "suspend").Obj(),
+		},
+		"managed - by config": {
+			job:       makeTrainer("job", "ns").Field(false, "spec", "suspend").Obj(),
+			manageAll: true,
+			want:      makeTrainer("job", "ns").Obj(),
+		},
+		"managed - by queue, suspend field missing": {
+			job:  makeTrainer("job", "ns").Queue("queue").RemoveField("spec", "suspend").Obj(),
+			want: makeTrainer("job", "ns").Queue("queue").Obj(),
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			wh := &Webhook{
+				fw:                         newTrainerFramework(t),
+				manageJobsWithoutQueueName: tc.manageAll,
+			}
+			if err := wh.Default(context.Background(), tc.job); err != nil {
+				t.Errorf("unexpected Default() error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, tc.job); diff != "" {
+				t.Errorf("Default() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateCreate(t *testing.T) {
+	testcases := map[string]struct {
+		job       *unstructured.Unstructured
+		manageAll bool
+		wantErr   field.ErrorList
+	}{
+		"valid managed": {
+			job: makeTrainer("job", "ns").Queue("queue").Obj(),
+		},
+		"invalid unmanaged": {
+			job: makeTrainer("job", "ns").Field("yes", "spec", "suspend").Obj(),
+		},
+		"invalid managed - by queue": {
+			job: makeTrainer("job", "ns").
+				Queue("queue").
+				Field("yes", "spec", "suspend").
+				Field(map[string]interface{}{}, "spec", "launcher").
+				Replicas("3").
+				Obj(),
+			wantErr: field.ErrorList{
+				field.TypeInvalid(field.NewPath(".spec.suspend"), nil, ""),
+				field.Invalid(field.NewPath(".spec.launcher.template"), nil, ""),
+				field.Invalid(field.NewPath(".spec.workers[0].replicas"), nil, ""),
+			},
+		},
+		"invalid managed - by config": {
+			job:       makeTrainer("job", "ns").Field("invalid", "spec", "workers").Obj(),
+			manageAll: true,
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath(".spec.workers[0].template"), nil, ""),
+				field.Invalid(field.NewPath(".spec.workers[0].replicas"), nil, ""),
+			},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			wh := &Webhook{
+				fw:                         newTrainerFramework(t),
+				manageJobsWithoutQueueName: tc.manageAll,
+			}
+			gotErr := wh.validateCreate(wh.fromObject(tc.job))
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "BadValue", "Detail")); diff != "" {
+				t.Errorf("ValidateCreate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -859,6 +859,32 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"running job with env from the flavor matches its admitted workload": {
+			job: *baseJobWrapper.Clone().
+				Suspend(false).
+				PodAnnotation(controllerconsts.FlavorEnvAnnotation, `{"c":["KUEUE_FLAVOR"]}`).
+				Env("KUEUE_FLAVOR", "default").
+				Obj(),
+			wantJob: *baseJobWrapper.Clone().
+				Suspend(false).
+				PodAnnotation(controllerconsts.FlavorEnvAnnotation, `{"c":["KUEUE_FLAVOR"]}`).
+				Env("KUEUE_FLAVOR", "default").
+				Obj(),
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(10).Obj()).
+					Admitted(true).
+					Obj(),
+			},
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					ReserveQuota(utiltesting.MakeAdmission("cq").AssignmentPodCount(10).Obj()).
+					Admitted(true).
+					Obj(),
+			},
+		},
 		"non-matching admitted workload is deleted": {
 			reconcilerOptions: []jobframework.Option{
 				jobframework.WithManageJobsWithoutQueueName(true),
@@ -1398,6 +1424,106 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"the workload is created when queue name is set, with the reservation label": {
+			job: *baseJobWrapper.
+				Clone().
+				Suspend(false).
+				Queue("test-queue").
+				UID("test-uid").
+				Label(controllerconsts.ReservationLabel, "test-reservation").
+				Obj(),
+			wantJob: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				UID("test-uid").
+				Label(controllerconsts.ReservationLabel, "test-reservation").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("test-queue").
+					Priority(0).
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel:      "test-uid",
+						controllerconsts.ReservationLabel: "test-reservation",
+					}).
+					Obj(),
+			},
+		},
+		"the workload is created when queue name is set, with workloadPriorityClass with preemption settings": {
+			job: *baseJobWrapper.
+				Clone().
+				Suspend(false).
+				Queue("test-queue").
+				UID("test-uid").
+				WorkloadPriorityClass("test-wpc").
+				Obj(),
+			priorityClasses: []client.Object{
+				utiltesting.MakeWorkloadPriorityClass("test-wpc").
+					PriorityValue(100).
+					PreemptionPolicy(corev1.PreemptNever).
+					Protected().
+					Obj(),
+			},
+			wantJob: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				UID("test-uid").
+				WorkloadPriorityClass("test-wpc").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("job", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("test-queue").
+					PriorityClass("test-wpc").
+					Priority(100).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					PreemptionPolicy(corev1.PreemptNever).
+					Protected().
+					Labels(map[string]string{
+						controllerconsts.JobUIDLabel: "test-uid",
+					}).
+					Obj(),
+			},
+		},
+		"the workload priority is updated when the workloadPriorityClass of the job changes": {
+			job: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				WorkloadPriorityClass("test-wpc-2").
+				Obj(),
+			priorityClasses: []client.Object{
+				baseWPCWrapper.Obj(),
+				utiltesting.MakeWorkloadPriorityClass("test-wpc-2").
+					PriorityValue(300).
+					Protected().
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("test-queue").
+					PriorityClass("test-wpc").
+					Priority(100).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Obj(),
+			},
+			wantJob: *baseJobWrapper.
+				Clone().
+				Queue("test-queue").
+				WorkloadPriorityClass("test-wpc-2").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "ns").Finalizers(kueue.ResourceInUseFinalizerName).
+					PodSets(*utiltesting.MakePodSet(kueue.DefaultPodSetName, 10).Request(corev1.ResourceCPU, "1").Obj()).
+					Queue("test-queue").
+					PriorityClass("test-wpc-2").
+					Priority(300).
+					PriorityClassSource(constants.WorkloadPriorityClassSource).
+					Protected().
+					Obj(),
+			},
+		},
 		"the workload is created when queue name is set, with PriorityClass": {
 			job: *baseJobWrapper.
 				Clone().
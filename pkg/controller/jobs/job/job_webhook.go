@@ -45,6 +45,7 @@ var (
 type JobWebhook struct {
 	manageJobsWithoutQueueName bool
 	kubeServerVersion          *kubeversion.ServerVersionFetcher
+	capacityCheck              *jobframework.CapacityCheck
 }
 
 // SetupWebhook configures the webhook for batchJob.
@@ -56,6 +57,7 @@ func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	wh := &JobWebhook{
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
 		kubeServerVersion:          options.KubeServerVersion,
+		capacityCheck:              options.CapacityCheck,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&batchv1.Job{}).
@@ -99,7 +101,8 @@ func (w *JobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (ad
 	job := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("job-webhook")
 	log.V(5).Info("Validating create", "job", klog.KObj(job))
-	return nil, w.validateCreate(job).ToAggregate()
+	warnings, capacityErrs := jobframework.ValidateCreateForCapacity(job, w.capacityCheck)
+	return warnings, append(w.validateCreate(job), capacityErrs...).ToAggregate()
 }
 
 func (w *JobWebhook) validateCreate(job *Job) field.ErrorList {
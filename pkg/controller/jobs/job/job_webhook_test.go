@@ -25,16 +25,22 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	kubeflow "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/version"
 	fakediscovery "k8s.io/client-go/discovery/fake"
 	fakeclient "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	"sigs.k8s.io/kueue/pkg/util/kubeversion"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingutil "sigs.k8s.io/kueue/pkg/util/testingjobs/job"
 
 	// without this only the job framework is registered
@@ -52,8 +58,13 @@ var (
 	queueNameLabelPath            = labelsPath.Key(constants.QueueLabel)
 	queueNameAnnotationsPath      = annotationsPath.Key(constants.QueueAnnotation)
 	workloadPriorityClassNamePath = labelsPath.Key(constants.WorkloadPriorityClassLabel)
+	externalJobGVK                = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "FooJob"}
 )
 
+func init() {
+	utilruntime.Must(jobframework.RegisterExternalJobType("FooJob.v1.example.com"))
+}
+
 func TestValidateCreate(t *testing.T) {
 	testcases := []struct {
 		name          string
@@ -400,13 +411,26 @@ func TestValidateUpdate(t *testing.T) {
 			wantErr: nil,
 		},
 		{
-			name:   "workloadPriorityClassName is immutable",
+			name:   "workloadPriorityClassName can be changed",
 			oldJob: testingutil.MakeJob("job", "default").WorkloadPriorityClass("test-1").Obj(),
 			newJob: testingutil.MakeJob("job", "default").WorkloadPriorityClass("test-2").Obj(),
+		},
+		{
+			name:   "workloadPriorityClassName cannot be removed",
+			oldJob: testingutil.MakeJob("job", "default").WorkloadPriorityClass("test-1").Obj(),
+			newJob: testingutil.MakeJob("job", "default").Obj(),
 			wantErr: field.ErrorList{
 				field.Invalid(workloadPriorityClassNamePath, "test-1", apivalidation.FieldImmutableErrorMsg),
 			},
 		},
+		{
+			name:   "workloadPriorityClassName cannot be added",
+			oldJob: testingutil.MakeJob("job", "default").Obj(),
+			newJob: testingutil.MakeJob("job", "default").WorkloadPriorityClass("test-2").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(workloadPriorityClassNamePath, "", apivalidation.FieldImmutableErrorMsg),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -434,6 +458,23 @@ func TestDefault(t *testing.T) {
 				ParentWorkload(jobframework.GetWorkloadNameForOwnerWithGVK("parent-job", kubeflow.SchemeGroupVersionKind)).
 				Obj(),
 		},
+		"add a parent job name to annotations for an external framework owner": {
+			job: testingutil.MakeJob("child-job", "default").
+				OwnerReference("parent-job", externalJobGVK).
+				Obj(),
+			want: testingutil.MakeJob("child-job", "default").
+				OwnerReference("parent-job", externalJobGVK).
+				ParentWorkload(jobframework.GetWorkloadNameForOwnerWithGVK("parent-job", externalJobGVK)).
+				Obj(),
+		},
+		"don't add a parent job name to annotations for an unknown owner": {
+			job: testingutil.MakeJob("child-job", "default").
+				OwnerReference("parent-job", schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "BarJob"}).
+				Obj(),
+			want: testingutil.MakeJob("child-job", "default").
+				OwnerReference("parent-job", schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "BarJob"}).
+				Obj(),
+		},
 		"update the suspend field with 'manageJobsWithoutQueueName=false'": {
 			job:  testingutil.MakeJob("job", "default").Queue("queue").Suspend(false).Obj(),
 			want: testingutil.MakeJob("job", "default").Queue("queue").Obj(),
@@ -456,3 +497,57 @@ func TestDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCreateCapacity(t *testing.T) {
+	cq := utiltesting.MakeClusterQueue("cq").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()
+	lq := utiltesting.MakeLocalQueue("queue", "default").ClusterQueue("cq").Obj()
+	reason := "the job can never be admitted: podSet main requests cpu=10, which exceed the maximum quota of every flavor in ClusterQueue cq"
+
+	cases := map[string]struct {
+		job          *batchv1.Job
+		reject       bool
+		wantWarnings admission.Warnings
+		wantErr      field.ErrorList
+	}{
+		"fits": {
+			job:    testingutil.MakeJob("job", "default").Queue("queue").Parallelism(2).Request(corev1.ResourceCPU, "1").Obj(),
+			reject: true,
+		},
+		"warn": {
+			job:          testingutil.MakeJob("job", "default").Queue("queue").Parallelism(10).Request(corev1.ResourceCPU, "1").Obj(),
+			wantWarnings: admission.Warnings{reason},
+		},
+		"reject": {
+			job:    testingutil.MakeJob("job", "default").Queue("queue").Parallelism(10).Request(corev1.ResourceCPU, "1").Obj(),
+			reject: true,
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec"), reason),
+			},
+		},
+		"without queue name": {
+			job:    testingutil.MakeJob("job", "default").Parallelism(10).Request(corev1.ResourceCPU, "1").Obj(),
+			reject: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cqCache := cache.New(utiltesting.NewFakeClient())
+			if err := cqCache.AddClusterQueue(context.Background(), cq); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			if err := cqCache.AddLocalQueue(lq); err != nil {
+				t.Fatalf("Adding LocalQueue: %v", err)
+			}
+			wh := &JobWebhook{capacityCheck: &jobframework.CapacityCheck{Checker: cqCache, Reject: tc.reject}}
+			warnings, err := wh.ValidateCreate(context.Background(), tc.job)
+			if diff := cmp.Diff(tc.wantWarnings, warnings); diff != "" {
+				t.Errorf("Unexpected warnings (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantErr.ToAggregate(), err); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 
 type JobSetWebhook struct {
 	manageJobsWithoutQueueName bool
+	capacityCheck              *jobframework.CapacityCheck
 }
 
 // SetupJobSetWebhook configures the webhook for kubeflow JobSet.
@@ -41,6 +42,7 @@ func SetupJobSetWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	}
 	wh := &JobSetWebhook{
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
+		capacityCheck:              options.CapacityCheck,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&jobsetapi.JobSet{}).
@@ -71,7 +73,8 @@ func (w *JobSetWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 	jobSet := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("jobset-webhook")
 	log.Info("Validating create", "jobset", klog.KObj(jobSet))
-	return nil, jobframework.ValidateCreateForQueueName(jobSet).ToAggregate()
+	warnings, capacityErrs := jobframework.ValidateCreateForCapacity(jobSet, w.capacityCheck)
+	return warnings, append(jobframework.ValidateCreateForQueueName(jobSet), capacityErrs...).ToAggregate()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
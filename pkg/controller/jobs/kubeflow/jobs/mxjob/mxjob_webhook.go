@@ -32,6 +32,7 @@ import (
 
 type MXJobWebhook struct {
 	manageJobsWithoutQueueName bool
+	capacityCheck              *jobframework.CapacityCheck
 }
 
 // SetupMXJobWebhook configures the webhook for kubeflow MXJob.
@@ -42,6 +43,7 @@ func SetupMXJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	}
 	wh := &MXJobWebhook{
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
+		capacityCheck:              options.CapacityCheck,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kftraining.MXJob{}).
@@ -72,7 +74,8 @@ func (w *MXJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (
 	job := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("mxjob-webhook")
 	log.V(5).Info("Validating create", "mxjob", klog.KObj(job.Object()))
-	return nil, validateCreate(job).ToAggregate()
+	warnings, capacityErrs := jobframework.ValidateCreateForCapacity(job, w.capacityCheck)
+	return warnings, append(validateCreate(job), capacityErrs...).ToAggregate()
 }
 
 func validateCreate(job jobframework.GenericJob) field.ErrorList {
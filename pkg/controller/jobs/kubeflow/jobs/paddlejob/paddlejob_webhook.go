@@ -32,6 +32,7 @@ import (
 
 type PaddleJobWebhook struct {
 	manageJobsWithoutQueueName bool
+	capacityCheck              *jobframework.CapacityCheck
 }
 
 // SetupPaddleJobWebhook configures the webhook for kubeflow PaddleJob.
@@ -42,6 +43,7 @@ func SetupPaddleJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error
 	}
 	wh := &PaddleJobWebhook{
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
+		capacityCheck:              options.CapacityCheck,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kftraining.PaddleJob{}).
@@ -72,7 +74,8 @@ func (w *PaddleJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Objec
 	job := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("paddlejob-webhook")
 	log.Info("Validating create", "paddlejob", klog.KObj(job.Object()))
-	return nil, validateCreate(job).ToAggregate()
+	warnings, capacityErrs := jobframework.ValidateCreateForCapacity(job, w.capacityCheck)
+	return warnings, append(validateCreate(job), capacityErrs...).ToAggregate()
 }
 
 func validateCreate(job jobframework.GenericJob) field.ErrorList {
@@ -68,6 +68,7 @@ func isPyTorchJob(owner *metav1.OwnerReference) bool {
 type JobControl kftraining.PyTorchJob
 
 var _ kubeflowjob.KFJobControl = (*JobControl)(nil)
+var _ kubeflowjob.KFJobControlWithMinReplicas = (*JobControl)(nil)
 
 func (j *JobControl) Object() client.Object {
 	return (*kftraining.PyTorchJob)(j)
@@ -97,6 +98,15 @@ func (j *JobControl) OrderedReplicaTypes() []kftraining.ReplicaType {
 	return []kftraining.ReplicaType{kftraining.PyTorchJobReplicaTypeMaster, kftraining.PyTorchJobReplicaTypeWorker}
 }
 
+// MinReplicas returns the minReplicas of the elastic policy for the workers,
+// as the elastic training can run with any number of workers in its range.
+func (j *JobControl) MinReplicas(replicaType kftraining.ReplicaType) *int32 {
+	if replicaType != kftraining.PyTorchJobReplicaTypeWorker || j.Spec.ElasticPolicy == nil {
+		return nil
+	}
+	return j.Spec.ElasticPolicy.MinReplicas
+}
+
 func SetupIndexes(ctx context.Context, indexer client.FieldIndexer) error {
 	return jobframework.SetupWorkloadOwnerIndex(ctx, indexer, gvk)
 }
@@ -20,8 +20,14 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	kftraining "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	v1 "k8s.io/api/core/v1"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/podset"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	testingutil "sigs.k8s.io/kueue/pkg/util/testingjobs/pytorchjob"
 )
 
 func TestPriorityClass(t *testing.T) {
@@ -215,3 +221,108 @@ func TestOrderedReplicaTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestPodSets(t *testing.T) {
+	testcases := map[string]struct {
+		job  *kftraining.PyTorchJob
+		want []kueue.PodSet
+	}{
+		"without elastic policy": {
+			job: testingutil.MakePyTorchJob("job", "ns").Parallelism(4).Obj(),
+			want: []kueue.PodSet{
+				*utiltesting.MakePodSet("master", 1).Obj(),
+				*utiltesting.MakePodSet("worker", 4).Obj(),
+			},
+		},
+		"with elastic policy": {
+			job: testingutil.MakePyTorchJob("job", "ns").Parallelism(4).MinReplicas(2).Obj(),
+			want: []kueue.PodSet{
+				*utiltesting.MakePodSet("master", 1).Obj(),
+				*utiltesting.MakePodSet("worker", 4).SetMinimumCount(2).Obj(),
+			},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			got := fromObject(tc.job).PodSets()
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(kueue.PodSet{}, "Template")); diff != "" {
+				t.Errorf("PodSets() mismatch (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReclaimablePods(t *testing.T) {
+	testcases := map[string]struct {
+		job  *kftraining.PyTorchJob
+		want []kueue.ReclaimablePod
+	}{
+		"no succeeded pods": {
+			job: testingutil.MakePyTorchJob("job", "ns").Parallelism(4).Obj(),
+		},
+		"succeeded workers": {
+			job: testingutil.MakePyTorchJob("job", "ns").Parallelism(4).
+				StatusSucceeded(kftraining.PyTorchJobReplicaTypeMaster, 0).
+				StatusSucceeded(kftraining.PyTorchJobReplicaTypeWorker, 3).
+				Obj(),
+			want: []kueue.ReclaimablePod{{Name: "worker", Count: 3}},
+		},
+		"succeeded pods are capped by the replicas": {
+			job: testingutil.MakePyTorchJob("job", "ns").Parallelism(2).
+				StatusSucceeded(kftraining.PyTorchJobReplicaTypeMaster, 1).
+				StatusSucceeded(kftraining.PyTorchJobReplicaTypeWorker, 3).
+				Obj(),
+			want: []kueue.ReclaimablePod{
+				{Name: "master", Count: 1},
+				{Name: "worker", Count: 2},
+			},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			got := fromObject(tc.job).ReclaimablePods()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ReclaimablePods() mismatch (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPartialAdmission(t *testing.T) {
+	testcases := map[string]struct {
+		job         *kftraining.PyTorchJob
+		podSetsInfo []podset.PodSetInfo
+		wantRunJob  *kftraining.PyTorchJob
+	}{
+		"workers are reduced": {
+			job:         testingutil.MakePyTorchJob("job", "ns").Parallelism(4).MinReplicas(2).Obj(),
+			podSetsInfo: []podset.PodSetInfo{{Name: "master", Count: 1}, {Name: "worker", Count: 2}},
+			wantRunJob:  testingutil.MakePyTorchJob("job", "ns").Parallelism(2).MinReplicas(2).Suspend(false).Obj(),
+		},
+		"workers are kept without elastic policy": {
+			job:         testingutil.MakePyTorchJob("job", "ns").Parallelism(4).Obj(),
+			podSetsInfo: []podset.PodSetInfo{{Name: "master", Count: 1}, {Name: "worker", Count: 2}},
+			wantRunJob:  testingutil.MakePyTorchJob("job", "ns").Parallelism(4).Suspend(false).Obj(),
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			job := fromObject(tc.job.DeepCopy())
+			originalPodSetsInfo := make([]podset.PodSetInfo, len(tc.podSetsInfo))
+			for i, ps := range job.PodSets() {
+				originalPodSetsInfo[i] = podset.FromPodSet(&ps)
+			}
+			if err := job.RunWithPodSetsInfo(tc.podSetsInfo); err != nil {
+				t.Fatalf("Unexpected RunWithPodSetsInfo() error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantRunJob, job.Object(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Job after RunWithPodSetsInfo() (-want,+got):\n%s", diff)
+			}
+			job.Suspend()
+			job.RestorePodSetsInfo(originalPodSetsInfo)
+			if diff := cmp.Diff(tc.job, job.Object(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Job after RestorePodSetsInfo() (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,20 +18,24 @@ package pytorchjob
 
 import (
 	"context"
+	"fmt"
 
 	kftraining "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/controller/jobs/kubeflow/kubeflowjob"
 )
 
 type PyTorchJobWebhook struct {
 	manageJobsWithoutQueueName bool
+	capacityCheck              *jobframework.CapacityCheck
 }
 
 // SetupPyTorchJobWebhook configures the webhook for kubeflow PyTorchJob.
@@ -42,6 +46,7 @@ func SetupPyTorchJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error
 	}
 	wh := &PyTorchJobWebhook{
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
+		capacityCheck:              options.CapacityCheck,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kftraining.PyTorchJob{}).
@@ -72,11 +77,31 @@ func (w *PyTorchJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Obje
 	job := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("pytorchjob-webhook")
 	log.Info("Validating create", "pytorchjob", klog.KObj(job.Object()))
-	return nil, validateCreate(job).ToAggregate()
+	warnings, capacityErrs := jobframework.ValidateCreateForCapacity(job, w.capacityCheck)
+	return warnings, append(validateCreate(job), capacityErrs...).ToAggregate()
 }
 
-func validateCreate(job jobframework.GenericJob) field.ErrorList {
-	return jobframework.ValidateCreateForQueueName(job)
+var workerReplicasPath = field.NewPath("spec", "pytorchReplicaSpecs").Key(string(kftraining.PyTorchJobReplicaTypeWorker)).Child("replicas")
+
+func validateCreate(job *kubeflowjob.KubeflowJob) field.ErrorList {
+	allErrs := jobframework.ValidateCreateForQueueName(job)
+	allErrs = append(allErrs, validatePartialAdmission(job)...)
+	return allErrs
+}
+
+// validatePartialAdmission checks that the workers of an elastic job can be
+// admitted in full, as the workload can't have less pods than its minimum.
+func validatePartialAdmission(job *kubeflowjob.KubeflowJob) field.ErrorList {
+	var allErrs field.ErrorList
+	pytorchJob := job.Object().(*kftraining.PyTorchJob)
+	worker := pytorchJob.Spec.PyTorchReplicaSpecs[kftraining.PyTorchJobReplicaTypeWorker]
+	if worker == nil || pytorchJob.Spec.ElasticPolicy == nil || pytorchJob.Spec.ElasticPolicy.MinReplicas == nil {
+		return nil
+	}
+	if replicas := ptr.Deref(worker.Replicas, 1); *pytorchJob.Spec.ElasticPolicy.MinReplicas > replicas {
+		allErrs = append(allErrs, field.Invalid(workerReplicasPath, replicas, fmt.Sprintf("should be at least the elastic policy minReplicas, %d", *pytorchJob.Spec.ElasticPolicy.MinReplicas)))
+	}
+	return allErrs
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -86,6 +111,7 @@ func (w *PyTorchJobWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj r
 	log := ctrl.LoggerFrom(ctx).WithName("pytorchjob-webhook")
 	log.Info("Validating update", "pytorchjob", klog.KObj(newJob.Object()))
 	allErrs := jobframework.ValidateUpdateForQueueName(oldJob, newJob)
+	allErrs = append(allErrs, validatePartialAdmission(newJob)...)
 	allErrs = append(allErrs, jobframework.ValidateUpdateForWorkloadPriorityClassName(oldJob, newJob)...)
 	return nil, allErrs.ToAggregate()
 }
@@ -21,7 +21,9 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	kftraining "github.com/kubeflow/training-operator/pkg/apis/kubeflow.org/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	testingutil "sigs.k8s.io/kueue/pkg/util/testingjobs/pytorchjob"
 )
@@ -54,3 +56,28 @@ func TestDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCreate(t *testing.T) {
+	testcases := map[string]struct {
+		job     *kftraining.PyTorchJob
+		wantErr field.ErrorList
+	}{
+		"valid elastic policy": {
+			job: testingutil.MakePyTorchJob("job", "default").Parallelism(4).MinReplicas(4).Obj(),
+		},
+		"minReplicas above the workers": {
+			job: testingutil.MakePyTorchJob("job", "default").Parallelism(4).MinReplicas(5).Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(workerReplicasPath, int32(4), ""),
+			},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateCreate(fromObject(tc.job))
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("validateCreate() mismatch (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
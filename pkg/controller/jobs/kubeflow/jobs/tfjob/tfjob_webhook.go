@@ -32,6 +32,7 @@ import (
 
 type TFJobWebhook struct {
 	manageJobsWithoutQueueName bool
+	capacityCheck              *jobframework.CapacityCheck
 }
 
 // SetupTFJobWebhook configures the webhook for kubeflow TFJob.
@@ -42,6 +43,7 @@ func SetupTFJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	}
 	wh := &TFJobWebhook{
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
+		capacityCheck:              options.CapacityCheck,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kftraining.TFJob{}).
@@ -72,7 +74,8 @@ func (w *TFJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (
 	job := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("tfjob-webhook")
 	log.V(5).Info("Validating create", "tfjob", klog.KObj(job.Object()))
-	return nil, validateCreate(job).ToAggregate()
+	warnings, capacityErrs := jobframework.ValidateCreateForCapacity(job, w.capacityCheck)
+	return warnings, append(validateCreate(job), capacityErrs...).ToAggregate()
 }
 
 func validateCreate(job jobframework.GenericJob) field.ErrorList {
@@ -32,6 +32,7 @@ import (
 
 type XGBoostJobWebhook struct {
 	manageJobsWithoutQueueName bool
+	capacityCheck              *jobframework.CapacityCheck
 }
 
 func SetupXGBoostJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
@@ -41,6 +42,7 @@ func SetupXGBoostJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error
 	}
 	wh := &XGBoostJobWebhook{
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
+		capacityCheck:              options.CapacityCheck,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kftraining.XGBoostJob{}).
@@ -71,7 +73,8 @@ func (w *XGBoostJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Obje
 	job := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("xgboostjob-webhook")
 	log.Info("Validating create", "xgboostjob", klog.KObj(job.Object()))
-	return nil, validateCreate(job).ToAggregate()
+	warnings, capacityErrs := jobframework.ValidateCreateForCapacity(job, w.capacityCheck)
+	return warnings, append(validateCreate(job), capacityErrs...).ToAggregate()
 }
 
 func validateCreate(job jobframework.GenericJob) field.ErrorList {
@@ -36,3 +36,11 @@ type KFJobControl interface {
 	// OrderedReplicaTypes returns the ordered list of ReplicaTypes for the KFJob.
 	OrderedReplicaTypes() []kftraining.ReplicaType
 }
+
+// KFJobControlWithMinReplicas is implemented by the KFJobs that can run with
+// fewer replicas than requested, enabling partial admission for them.
+type KFJobControlWithMinReplicas interface {
+	// MinReplicas returns the minimum number of replicas of the ReplicaType,
+	// or nil if the number of replicas can't be reduced.
+	MinReplicas(replicaType kftraining.ReplicaType) *int32
+}
@@ -37,6 +37,7 @@ type KubeflowJob struct {
 
 var _ jobframework.GenericJob = (*KubeflowJob)(nil)
 var _ jobframework.JobWithPriorityClass = (*KubeflowJob)(nil)
+var _ jobframework.JobWithReclaimablePods = (*KubeflowJob)(nil)
 
 func (j *KubeflowJob) Object() client.Object {
 	return j.KFJobControl.Object()
@@ -62,11 +63,14 @@ func (j *KubeflowJob) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error
 	for index := range podSetsInfo {
 		replicaType := orderedReplicaTypes[index]
 		info := podSetsInfo[index]
-		replica := &j.KFJobControl.ReplicaSpecs()[replicaType].Template
+		replicaSpec := j.KFJobControl.ReplicaSpecs()[replicaType]
+		if j.minPodsCount(replicaType) != nil {
+			replicaSpec.Replicas = ptr.To(info.Count)
+		}
+		replica := &replicaSpec.Template
 		if err := podset.Merge(&replica.ObjectMeta, &replica.Spec, info); err != nil {
 			return err
 		}
-
 	}
 	return nil
 }
@@ -76,7 +80,13 @@ func (j *KubeflowJob) RestorePodSetsInfo(podSetsInfo []podset.PodSetInfo) bool {
 	changed := false
 	for index, info := range podSetsInfo {
 		replicaType := orderedReplicaTypes[index]
-		replica := &j.KFJobControl.ReplicaSpecs()[replicaType].Template
+		replicaSpec := j.KFJobControl.ReplicaSpecs()[replicaType]
+		// restore the number of replicas reduced by a partial admission
+		if j.minPodsCount(replicaType) != nil && ptr.Deref(replicaSpec.Replicas, 1) != info.Count {
+			replicaSpec.Replicas = ptr.To(info.Count)
+			changed = true
+		}
+		replica := &replicaSpec.Template
 		changed = podset.RestorePodSpec(&replica.ObjectMeta, &replica.Spec, info) || changed
 	}
 	return changed
@@ -116,11 +126,32 @@ func (j *KubeflowJob) PodSets() []kueue.PodSet {
 			Name:     strings.ToLower(string(replicaType)),
 			Template: *j.KFJobControl.ReplicaSpecs()[replicaType].Template.DeepCopy(),
 			Count:    podsCount(j.KFJobControl.ReplicaSpecs(), replicaType),
+			MinCount: j.minPodsCount(replicaType),
 		}
 	}
 	return podSets
 }
 
+// ReclaimablePods returns the pods of the replicas that succeeded, as the
+// training operator doesn't recreate them.
+func (j *KubeflowJob) ReclaimablePods() []kueue.ReclaimablePod {
+	if j.KFJobControl.JobStatus() == nil {
+		return nil
+	}
+	var reclaimablePods []kueue.ReclaimablePod
+	for _, replicaType := range j.OrderedReplicaTypes() {
+		status := j.KFJobControl.JobStatus().ReplicaStatuses[replicaType]
+		if status == nil || status.Succeeded == 0 {
+			continue
+		}
+		reclaimablePods = append(reclaimablePods, kueue.ReclaimablePod{
+			Name:  strings.ToLower(string(replicaType)),
+			Count: min(status.Succeeded, podsCount(j.KFJobControl.ReplicaSpecs(), replicaType)),
+		})
+	}
+	return reclaimablePods
+}
+
 func (j *KubeflowJob) IsActive() bool {
 	for _, replicaStatus := range j.KFJobControl.JobStatus().ReplicaStatuses {
 		if replicaStatus.Active != 0 {
@@ -175,6 +206,13 @@ func (j *KubeflowJob) OrderedReplicaTypes() []kftraining.ReplicaType {
 	return result
 }
 
+func (j *KubeflowJob) minPodsCount(replicaType kftraining.ReplicaType) *int32 {
+	if withMinReplicas, ok := j.KFJobControl.(KFJobControlWithMinReplicas); ok {
+		return withMinReplicas.MinReplicas(replicaType)
+	}
+	return nil
+}
+
 func podsCount(replicaSpecs map[kftraining.ReplicaType]*kftraining.ReplicaSpec, replicaType kftraining.ReplicaType) int32 {
 	return ptr.Deref(replicaSpecs[replicaType].Replicas, 1)
 }
@@ -18,6 +18,7 @@ package mpijob
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	kubeflow "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
@@ -40,6 +41,10 @@ var (
 	FrameworkName = "kubeflow.org/mpijob"
 )
 
+// MPIJobMinWorkersAnnotation allows the partial admission of an MPIJob, with
+// at least the given number of workers.
+const MPIJobMinWorkersAnnotation = "kueue.x-k8s.io/mpijob-min-workers"
+
 func init() {
 	utilruntime.Must(jobframework.RegisterIntegration(FrameworkName, jobframework.IntegrationCallbacks{
 		SetupIndexes:           SetupIndexes,
@@ -71,6 +76,7 @@ type MPIJob kubeflow.MPIJob
 
 var _ jobframework.GenericJob = (*MPIJob)(nil)
 var _ jobframework.JobWithPriorityClass = (*MPIJob)(nil)
+var _ jobframework.JobWithReclaimablePods = (*MPIJob)(nil)
 
 func (j *MPIJob) Object() client.Object {
 	return (*kubeflow.MPIJob)(j)
@@ -109,11 +115,29 @@ func (j *MPIJob) PodSets() []kueue.PodSet {
 			Name:     strings.ToLower(string(mpiReplicaType)),
 			Template: *j.Spec.MPIReplicaSpecs[mpiReplicaType].Template.DeepCopy(),
 			Count:    podsCount(&j.Spec, mpiReplicaType),
+			MinCount: j.minPodsCount(mpiReplicaType),
 		}
 	}
 	return podSets
 }
 
+// ReclaimablePods returns the pods of the replicas that succeeded, as the
+// mpi-operator doesn't recreate them.
+func (j *MPIJob) ReclaimablePods() []kueue.ReclaimablePod {
+	var reclaimablePods []kueue.ReclaimablePod
+	for _, mpiReplicaType := range orderedReplicaTypes(&j.Spec) {
+		status := j.Status.ReplicaStatuses[mpiReplicaType]
+		if status == nil || status.Succeeded == 0 {
+			continue
+		}
+		reclaimablePods = append(reclaimablePods, kueue.ReclaimablePod{
+			Name:  strings.ToLower(string(mpiReplicaType)),
+			Count: min(status.Succeeded, podsCount(&j.Spec, mpiReplicaType)),
+		})
+	}
+	return reclaimablePods
+}
+
 func (j *MPIJob) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error {
 	j.Spec.RunPolicy.Suspend = ptr.To(false)
 	orderedReplicaTypes := orderedReplicaTypes(&j.Spec)
@@ -127,7 +151,11 @@ func (j *MPIJob) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error {
 	for index := range podSetsInfo {
 		replicaType := orderedReplicaTypes[index]
 		info := podSetsInfo[index]
-		replica := &j.Spec.MPIReplicaSpecs[replicaType].Template
+		replicaSpec := j.Spec.MPIReplicaSpecs[replicaType]
+		if j.minPodsCount(replicaType) != nil {
+			replicaSpec.Replicas = ptr.To(info.Count)
+		}
+		replica := &replicaSpec.Template
 		if err := podset.Merge(&replica.ObjectMeta, &replica.Spec, info); err != nil {
 			return err
 		}
@@ -140,7 +168,13 @@ func (j *MPIJob) RestorePodSetsInfo(podSetsInfo []podset.PodSetInfo) bool {
 	changed := false
 	for index, info := range podSetsInfo {
 		replicaType := orderedReplicaTypes[index]
-		replica := &j.Spec.MPIReplicaSpecs[replicaType].Template
+		replicaSpec := j.Spec.MPIReplicaSpecs[replicaType]
+		// restore the number of workers reduced by a partial admission
+		if j.minPodsCount(replicaType) != nil && ptr.Deref(replicaSpec.Replicas, 1) != info.Count {
+			replicaSpec.Replicas = ptr.To(info.Count)
+			changed = true
+		}
+		replica := &replicaSpec.Template
 		changed = podset.RestorePodSpec(&replica.ObjectMeta, &replica.Spec, info) || changed
 	}
 	return changed
@@ -216,6 +250,24 @@ func podsCount(jobSpec *kubeflow.MPIJobSpec, mpiReplicaType kubeflow.MPIReplicaT
 	return ptr.Deref(jobSpec.MPIReplicaSpecs[mpiReplicaType].Replicas, 1)
 }
 
+// minPodsCount returns the minimum number of workers, set in the
+// kueue.x-k8s.io/mpijob-min-workers annotation. The jobs without the
+// annotation can't be partially admitted.
+func (j *MPIJob) minPodsCount(mpiReplicaType kubeflow.MPIReplicaType) *int32 {
+	if mpiReplicaType != kubeflow.MPIReplicaTypeWorker {
+		return nil
+	}
+	strVal, found := j.Annotations[MPIJobMinWorkersAnnotation]
+	if !found {
+		return nil
+	}
+	v, err := strconv.Atoi(strVal)
+	if err != nil {
+		return nil
+	}
+	return ptr.To(int32(v))
+}
+
 func GetWorkloadNameForMPIJob(jobName string) string {
 	return jobframework.GetWorkloadNameForOwnerWithGVK(jobName, gvk)
 }
@@ -31,7 +31,9 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/controller/jobframework"
+	"sigs.k8s.io/kueue/pkg/podset"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingmpijob "sigs.k8s.io/kueue/pkg/util/testingjobs/mpijob"
 )
@@ -195,6 +197,86 @@ var (
 	}
 )
 
+func TestReclaimablePods(t *testing.T) {
+	testcases := map[string]struct {
+		job  *kubeflow.MPIJob
+		want []kueue.ReclaimablePod
+	}{
+		"no succeeded pods": {
+			job: testingmpijob.MakeMPIJob("job", "ns").Parallelism(4).Obj(),
+		},
+		"succeeded workers": {
+			job: testingmpijob.MakeMPIJob("job", "ns").Parallelism(4).
+				StatusSucceeded(kubeflow.MPIReplicaTypeLauncher, 0).
+				StatusSucceeded(kubeflow.MPIReplicaTypeWorker, 3).
+				Obj(),
+			want: []kueue.ReclaimablePod{{Name: "worker", Count: 3}},
+		},
+		"succeeded pods are capped by the replicas": {
+			job: testingmpijob.MakeMPIJob("job", "ns").Parallelism(2).
+				StatusSucceeded(kubeflow.MPIReplicaTypeLauncher, 1).
+				StatusSucceeded(kubeflow.MPIReplicaTypeWorker, 3).
+				Obj(),
+			want: []kueue.ReclaimablePod{
+				{Name: "launcher", Count: 1},
+				{Name: "worker", Count: 2},
+			},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			got := fromObject(tc.job).ReclaimablePods()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ReclaimablePods() mismatch (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPartialAdmission(t *testing.T) {
+	testcases := map[string]struct {
+		job         *kubeflow.MPIJob
+		podSetsInfo []podset.PodSetInfo
+		wantRunJob  *kubeflow.MPIJob
+	}{
+		"workers are reduced": {
+			job:         testingmpijob.MakeMPIJob("job", "ns").Parallelism(4).Annotation(MPIJobMinWorkersAnnotation, "2").Obj(),
+			podSetsInfo: []podset.PodSetInfo{{Name: "launcher", Count: 1}, {Name: "worker", Count: 2}},
+			wantRunJob:  testingmpijob.MakeMPIJob("job", "ns").Parallelism(2).Annotation(MPIJobMinWorkersAnnotation, "2").Suspend(false).Obj(),
+		},
+		"workers are kept without the min workers annotation": {
+			job:         testingmpijob.MakeMPIJob("job", "ns").Parallelism(4).Obj(),
+			podSetsInfo: []podset.PodSetInfo{{Name: "launcher", Count: 1}, {Name: "worker", Count: 2}},
+			wantRunJob:  testingmpijob.MakeMPIJob("job", "ns").Parallelism(4).Suspend(false).Obj(),
+		},
+		"workers are kept with only minAvailable": {
+			job:         testingmpijob.MakeMPIJob("job", "ns").Parallelism(4).MinAvailable(3).Obj(),
+			podSetsInfo: []podset.PodSetInfo{{Name: "launcher", Count: 1}, {Name: "worker", Count: 2}},
+			wantRunJob:  testingmpijob.MakeMPIJob("job", "ns").Parallelism(4).MinAvailable(3).Suspend(false).Obj(),
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			job := fromObject(tc.job.DeepCopy())
+			originalPodSetsInfo := make([]podset.PodSetInfo, len(tc.podSetsInfo))
+			for i, ps := range job.PodSets() {
+				originalPodSetsInfo[i] = podset.FromPodSet(&ps)
+			}
+			if err := job.RunWithPodSetsInfo(tc.podSetsInfo); err != nil {
+				t.Fatalf("Unexpected RunWithPodSetsInfo() error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantRunJob, job.Object(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Job after RunWithPodSetsInfo() (-want,+got):\n%s", diff)
+			}
+			job.Suspend()
+			job.RestorePodSetsInfo(originalPodSetsInfo)
+			if diff := cmp.Diff(tc.job, job.Object(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Job after RestorePodSetsInfo() (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestReconciler(t *testing.T) {
 	baseWPCWrapper := utiltesting.MakeWorkloadPriorityClass("test-wpc").
 		PriorityValue(100)
@@ -223,6 +305,61 @@ func TestReconciler(t *testing.T) {
 					Obj(),
 			},
 		},
+		"workload is created with podsets and minCount for the workers": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithManageJobsWithoutQueueName(true),
+			},
+			job:     testingmpijob.MakeMPIJob("mpijob", "ns").Parallelism(4).Annotation(MPIJobMinWorkersAnnotation, "2").Obj(),
+			wantJob: testingmpijob.MakeMPIJob("mpijob", "ns").Parallelism(4).Annotation(MPIJobMinWorkersAnnotation, "2").Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("mpijob", "ns").
+					PodSets(
+						*utiltesting.MakePodSet("launcher", 1).Obj(),
+						*utiltesting.MakePodSet("worker", 4).SetMinimumCount(2).Obj(),
+					).
+					Obj(),
+			},
+		},
+		"workload is created without minCount when the job only sets minAvailable": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithManageJobsWithoutQueueName(true),
+			},
+			job:     testingmpijob.MakeMPIJob("mpijob", "ns").Parallelism(4).MinAvailable(3).Obj(),
+			wantJob: testingmpijob.MakeMPIJob("mpijob", "ns").Parallelism(4).MinAvailable(3).Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("mpijob", "ns").
+					PodSets(
+						*utiltesting.MakePodSet("launcher", 1).Obj(),
+						*utiltesting.MakePodSet("worker", 4).Obj(),
+					).
+					Obj(),
+			},
+		},
+		"workload is created with podsets and podSetFlavorGroups": {
+			reconcilerOptions: []jobframework.Option{
+				jobframework.WithManageJobsWithoutQueueName(true),
+			},
+			job: testingmpijob.MakeMPIJob("mpijob", "ns").Parallelism(2).
+				Annotation(controllerconsts.FlavorConsistentPodSetsAnnotation, "launcher, worker, unknown").
+				Annotation(controllerconsts.FlavorConsistencyNodeLabelAnnotation, "zone").
+				Obj(),
+			wantJob: testingmpijob.MakeMPIJob("mpijob", "ns").Parallelism(2).
+				Annotation(controllerconsts.FlavorConsistentPodSetsAnnotation, "launcher, worker, unknown").
+				Annotation(controllerconsts.FlavorConsistencyNodeLabelAnnotation, "zone").
+				Obj(),
+			wantWorkloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("mpijob", "ns").
+					PodSets(
+						*utiltesting.MakePodSet("launcher", 1).Obj(),
+						*utiltesting.MakePodSet("worker", 2).Obj(),
+					).
+					PodSetFlavorGroups(kueue.PodSetFlavorGroup{
+						PodSets:   []string{"launcher", "worker"},
+						NodeLabel: "zone",
+					}).
+					Obj(),
+			},
+		},
 		"workload is created with podsets and workloadPriorityClass": {
 			reconcilerOptions: []jobframework.Option{
 				jobframework.WithManageJobsWithoutQueueName(true),
@@ -18,6 +18,8 @@ package mpijob
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	kubeflow "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -32,6 +34,7 @@ import (
 
 type MPIJobWebhook struct {
 	manageJobsWithoutQueueName bool
+	capacityCheck              *jobframework.CapacityCheck
 }
 
 // SetupMPIJobWebhook configures the webhook for kubeflow MPIJob.
@@ -42,6 +45,7 @@ func SetupMPIJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	}
 	wh := &MPIJobWebhook{
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
+		capacityCheck:              options.CapacityCheck,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kubeflow.MPIJob{}).
@@ -73,11 +77,37 @@ func (w *MPIJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 	job := fromObject(obj)
 	log := ctrl.LoggerFrom(ctx).WithName("mpijob-webhook")
 	log.Info("Validating create", "job", klog.KObj(job))
-	return nil, validateCreate(job).ToAggregate()
+	warnings, capacityErrs := jobframework.ValidateCreateForCapacity(job, w.capacityCheck)
+	return warnings, append(validateCreate(job), capacityErrs...).ToAggregate()
 }
 
-func validateCreate(job jobframework.GenericJob) field.ErrorList {
-	return jobframework.ValidateCreateForQueueName(job)
+var minWorkersAnnotationPath = field.NewPath("metadata", "annotations").Key(MPIJobMinWorkersAnnotation)
+
+func validateCreate(job *MPIJob) field.ErrorList {
+	allErrs := jobframework.ValidateCreateForQueueName(job)
+	allErrs = append(allErrs, validatePartialAdmission(job)...)
+	return allErrs
+}
+
+// validatePartialAdmission checks that the minimum number of workers is lower
+// than the workers of the job.
+func validatePartialAdmission(job *MPIJob) field.ErrorList {
+	var allErrs field.ErrorList
+	strVal, found := job.Annotations[MPIJobMinWorkersAnnotation]
+	if !found {
+		return nil
+	}
+	v, err := strconv.Atoi(strVal)
+	if err != nil {
+		return append(allErrs, field.Invalid(minWorkersAnnotationPath, strVal, err.Error()))
+	}
+	if _, ok := job.Spec.MPIReplicaSpecs[kubeflow.MPIReplicaTypeWorker]; !ok {
+		return append(allErrs, field.Invalid(minWorkersAnnotationPath, v, "the job has no workers"))
+	}
+	if workers := podsCount(&job.Spec, kubeflow.MPIReplicaTypeWorker); int32(v) >= workers || v <= 0 {
+		allErrs = append(allErrs, field.Invalid(minWorkersAnnotationPath, v, fmt.Sprintf("should be between 0 and %d", workers-1)))
+	}
+	return allErrs
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -87,6 +117,7 @@ func (w *MPIJobWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runti
 	log := ctrl.LoggerFrom(ctx).WithName("mpijob-webhook")
 	log.Info("Validating update", "job", klog.KObj(newJob))
 	allErrs := jobframework.ValidateUpdateForQueueName(oldJob, newJob)
+	allErrs = append(allErrs, validatePartialAdmission(newJob)...)
 	allErrs = append(allErrs, jobframework.ValidateUpdateForWorkloadPriorityClassName(oldJob, newJob)...)
 	return nil, allErrs.ToAggregate()
 }
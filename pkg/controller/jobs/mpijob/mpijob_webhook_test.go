@@ -21,7 +21,9 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	kubeflow "github.com/kubeflow/mpi-operator/pkg/apis/kubeflow/v2beta1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	testingutil "sigs.k8s.io/kueue/pkg/util/testingjobs/mpijob"
 )
@@ -54,3 +56,43 @@ func TestDefault(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCreate(t *testing.T) {
+	testcases := map[string]struct {
+		job     *kubeflow.MPIJob
+		wantErr field.ErrorList
+	}{
+		"valid min workers": {
+			job: testingutil.MakeMPIJob("job", "default").Parallelism(4).Annotation(MPIJobMinWorkersAnnotation, "3").Obj(),
+		},
+		"minAvailable is ignored": {
+			job: testingutil.MakeMPIJob("job", "default").Parallelism(4).MinAvailable(6).Obj(),
+		},
+		"min workers isn't a number": {
+			job: testingutil.MakeMPIJob("job", "default").Parallelism(4).Annotation(MPIJobMinWorkersAnnotation, "NaN").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(minWorkersAnnotationPath, "NaN", ""),
+			},
+		},
+		"min workers equal to the workers of the job": {
+			job: testingutil.MakeMPIJob("job", "default").Parallelism(4).Annotation(MPIJobMinWorkersAnnotation, "4").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(minWorkersAnnotationPath, 4, ""),
+			},
+		},
+		"min workers is zero": {
+			job: testingutil.MakeMPIJob("job", "default").Parallelism(4).Annotation(MPIJobMinWorkersAnnotation, "0").Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(minWorkersAnnotationPath, 0, ""),
+			},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := validateCreate(fromObject(tc.job))
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("validateCreate() mismatch (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
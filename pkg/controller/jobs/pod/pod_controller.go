@@ -114,7 +114,40 @@ func (p *Pod) RunWithPodSetsInfo(podSetsInfo []podset.PodSetInfo) error {
 	if idx != gateNotFound {
 		p.Spec.SchedulingGates = append(p.Spec.SchedulingGates[:idx], p.Spec.SchedulingGates[idx+1:]...)
 	}
-	return podset.Merge(&p.ObjectMeta, &p.Spec, podSetsInfo[0])
+	// Only the metadata and the scheduling directives of a gated pod can be
+	// updated, so the rest of the flavor pod template overrides are rejected.
+	info := podSetsInfo[0]
+	if fields := unsupportedOverrides(&info); len(fields) > 0 {
+		return podset.BadPodSetsUpdateError("podTemplateOverrides", fmt.Errorf("cannot set %s in a Pod", strings.Join(fields, ", ")))
+	}
+	return podset.Merge(&p.ObjectMeta, &p.Spec, podset.PodSetInfo{
+		Annotations:  info.Annotations,
+		Labels:       info.Labels,
+		NodeSelector: info.NodeSelector,
+		Tolerations:  info.Tolerations,
+	})
+}
+
+// unsupportedOverrides returns the fields of the info that can't be applied
+// to a pod once it is created.
+func unsupportedOverrides(info *podset.PodSetInfo) []string {
+	var fields []string
+	if len(info.Env) > 0 {
+		fields = append(fields, "env")
+	}
+	if info.RuntimeClassName != nil {
+		fields = append(fields, "runtimeClassName")
+	}
+	if info.SchedulerName != "" {
+		fields = append(fields, "schedulerName")
+	}
+	if info.PodAffinity != nil || info.PodAntiAffinity != nil {
+		fields = append(fields, "affinity")
+	}
+	if len(info.TopologySpreadConstraints) > 0 {
+		fields = append(fields, "topologySpreadConstraints")
+	}
+	return fields
 }
 
 // RestorePodSetsInfo will restore the original node affinity and podSet counts of the job.
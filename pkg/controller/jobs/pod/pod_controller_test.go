@@ -92,6 +92,23 @@ func TestRunWithPodSetsInfo(t *testing.T) {
 			runInfo: make([]podset.PodSetInfo, 2),
 			wantErr: podset.ErrInvalidPodsetInfo,
 		},
+		"flavor overrides that can't be applied to a pod": {
+			pod: testingpod.MakePod("test-pod", "test-namespace").Obj(),
+			runInfo: []podset.PodSetInfo{{
+				Env:           []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "f1"}},
+				SchedulerName: "custom-scheduler",
+			}},
+			wantErr: podset.ErrInvalidPodSetUpdate,
+		},
+		"flavor node selector": {
+			pod: testingpod.MakePod("test-pod", "test-namespace").KueueSchedulingGate().Obj(),
+			runInfo: []podset.PodSetInfo{{
+				NodeSelector: map[string]string{"kubernetes.io/arch": "arm64"},
+			}},
+			wantPod: testingpod.MakePod("test-pod", "test-namespace").
+				NodeSelector("kubernetes.io/arch", "arm64").
+				Obj(),
+		},
 	}
 
 	for name, tc := range testCases {
@@ -51,6 +51,7 @@ type PodWebhook struct {
 	manageJobsWithoutQueueName bool
 	namespaceSelector          *metav1.LabelSelector
 	podSelector                *metav1.LabelSelector
+	capacityCheck              *jobframework.CapacityCheck
 }
 
 // SetupWebhook configures the webhook for pods.
@@ -64,6 +65,7 @@ func SetupWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
 		namespaceSelector:          options.PodNamespaceSelector,
 		podSelector:                options.PodSelector,
+		capacityCheck:              options.CapacityCheck,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&corev1.Pod{}).
@@ -149,6 +151,12 @@ func (w *PodWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (ad
 		warnings = append(warnings, warn)
 	}
 
+	if pod.Labels[ManagedLabelKey] == ManagedLabelValue {
+		capacityWarnings, capacityErrs := jobframework.ValidateCreateForCapacity(pod, w.capacityCheck)
+		warnings = append(warnings, capacityWarnings...)
+		allErrs = append(allErrs, capacityErrs...)
+	}
+
 	return warnings, allErrs.ToAggregate()
 }
 
@@ -25,15 +25,21 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	"sigs.k8s.io/kueue/pkg/controller/jobframework"
 	_ "sigs.k8s.io/kueue/pkg/controller/jobs/kubeflow/jobs"
 	_ "sigs.k8s.io/kueue/pkg/controller/jobs/mpijob"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	testingpod "sigs.k8s.io/kueue/pkg/util/testingjobs/pod"
 )
 
+func init() {
+	utilruntime.Must(jobframework.RegisterExternalJobType("FooJob.v1.example.com"))
+}
+
 func TestDefault(t *testing.T) {
 	defaultNamespace := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -125,6 +131,19 @@ func TestDefault(t *testing.T) {
 				OwnerReference("parent-ray-cluster", rayjobapi.GroupVersion.WithKind("RayCluster")).
 				Obj(),
 		},
+		"pod with owner managed by kueue (external framework)": {
+			initObjects:       []client.Object{defaultNamespace},
+			podSelector:       &metav1.LabelSelector{},
+			namespaceSelector: defaultNamespaceSelector,
+			pod: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				OwnerReference("parent-foo-job", schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "FooJob"}).
+				Obj(),
+			want: testingpod.MakePod("test-pod", defaultNamespace.Name).
+				Queue("test-queue").
+				OwnerReference("parent-foo-job", schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "FooJob"}).
+				Obj(),
+		},
 		"pod with owner managed by kueue (MPIJob)": {
 			initObjects:       []client.Object{defaultNamespace},
 			podSelector:       &metav1.LabelSelector{},
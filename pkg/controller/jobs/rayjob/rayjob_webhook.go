@@ -35,6 +35,7 @@ import (
 
 type RayJobWebhook struct {
 	manageJobsWithoutQueueName bool
+	capacityCheck              *jobframework.CapacityCheck
 }
 
 // SetupRayJobWebhook configures the webhook for rayjobapi RayJob.
@@ -45,6 +46,7 @@ func SetupRayJobWebhook(mgr ctrl.Manager, opts ...jobframework.Option) error {
 	}
 	wh := &RayJobWebhook{
 		manageJobsWithoutQueueName: options.ManageJobsWithoutQueueName,
+		capacityCheck:              options.CapacityCheck,
 	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&rayjobapi.RayJob{}).
@@ -75,7 +77,8 @@ func (w *RayJobWebhook) ValidateCreate(ctx context.Context, obj runtime.Object)
 	job := obj.(*rayjobapi.RayJob)
 	log := ctrl.LoggerFrom(ctx).WithName("rayjob-webhook")
 	log.Info("Validating create", "job", klog.KObj(job))
-	return nil, w.validateCreate(job).ToAggregate()
+	warnings, capacityErrs := jobframework.ValidateCreateForCapacity((*RayJob)(job), w.capacityCheck)
+	return warnings, append(w.validateCreate(job), capacityErrs...).ToAggregate()
 }
 
 func (w *RayJobWebhook) validateCreate(job *rayjobapi.RayJob) field.ErrorList {
@@ -239,7 +239,7 @@ func TestValidateUpdate(t *testing.T) {
 				Obj(),
 			wantErr: nil,
 		},
-		"priorityClassName is immutable": {
+		"priorityClassName can be changed": {
 			oldJob: testingrayutil.MakeJob("job", "ns").
 				Queue("queue").
 				WorkloadPriorityClass("test-1").
@@ -248,6 +248,15 @@ func TestValidateUpdate(t *testing.T) {
 				Queue("queue").
 				WorkloadPriorityClass("test-2").
 				Obj(),
+		},
+		"priorityClassName cannot be removed": {
+			oldJob: testingrayutil.MakeJob("job", "ns").
+				Queue("queue").
+				WorkloadPriorityClass("test-1").
+				Obj(),
+			newJob: testingrayutil.MakeJob("job", "ns").
+				Queue("queue").
+				Obj(),
 			wantErr: field.ErrorList{
 				field.Invalid(workloadPriorityClassNamePath, "test-1", apivalidation.FieldImmutableErrorMsg),
 			}.ToAggregate(),
@@ -0,0 +1,304 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/wait"
+	certutil "k8s.io/client-go/util/cert"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+// setupState returns a cache and a queue manager with:
+// - ClusterQueues cq-a and cq-b in the cohort team.
+// - Workload admitted in cq-a, and assumed in cq-b.
+// - Workloads first and second pending in cq-a, with first inadmissible.
+func setupState(t *testing.T) (*cache.Cache, *queue.Manager) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now()
+	cqA := utiltesting.MakeClusterQueue("cq-a").
+		Cohort("team").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10", "5").Obj()).
+		Obj()
+	cqB := utiltesting.MakeClusterQueue("cq-b").
+		Cohort("team").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "4").Obj()).
+		Obj()
+	lqA := utiltesting.MakeLocalQueue("lq-a", "ns").ClusterQueue("cq-a").Obj()
+	admitted := utiltesting.MakeWorkload("admitted", "ns").
+		Queue("lq-a").
+		Request(corev1.ResourceCPU, "3").
+		ReserveQuota(utiltesting.MakeAdmission("cq-a").Assignment(corev1.ResourceCPU, "default", "3").Obj()).
+		Obj()
+	assumed := utiltesting.MakeWorkload("assumed", "ns").
+		Request(corev1.ResourceCPU, "1").
+		ReserveQuota(utiltesting.MakeAdmission("cq-b").Assignment(corev1.ResourceCPU, "default", "1").Obj()).
+		Obj()
+	first := utiltesting.MakeWorkload("first", "ns").Queue("lq-a").Creation(now).Request(corev1.ResourceCPU, "20").Obj()
+	second := utiltesting.MakeWorkload("second", "ns").Queue("lq-a").Creation(now.Add(time.Second)).Request(corev1.ResourceCPU, "1").Obj()
+
+	cl := utiltesting.NewClientBuilder().WithObjects(lqA, first, second).Build()
+	cCache := cache.New(cl)
+	queues := queue.NewManager(cl, cCache)
+	cCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cq := range []*kueue.ClusterQueue{cqA, cqB} {
+		if err := cCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Adding ClusterQueue to the cache: %v", err)
+		}
+		if err := queues.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Adding ClusterQueue to the queues: %v", err)
+		}
+	}
+	if err := queues.AddLocalQueue(ctx, lqA); err != nil {
+		t.Fatalf("Adding LocalQueue to the queues: %v", err)
+	}
+	cCache.AddOrUpdateWorkload(admitted)
+	if err := cCache.AssumeWorkload(assumed); err != nil {
+		t.Fatalf("Assuming workload: %v", err)
+	}
+	queues.AddOrUpdateWorkload(first)
+	queues.AddOrUpdateWorkload(second)
+	heads := queues.Heads(ctx)
+	if len(heads) != 1 || heads[0].Obj.Name != "first" {
+		t.Fatalf("Unexpected heads %v", heads)
+	}
+	queues.RequeueWorkload(ctx, &heads[0], queue.RequeueReasonGeneric)
+	return cCache, queues
+}
+
+func TestDumpCache(t *testing.T) {
+	cCache, _ := setupState(t)
+	want := &CacheDump{
+		ClusterQueues: map[string]ClusterQueueState{
+			"cq-a": {
+				Cohort: "team",
+				Active: true,
+				Quotas: Quotas{"default": {corev1.ResourceCPU: {
+					Nominal:        resource.MustParse("10"),
+					BorrowingLimit: ptr.To(resource.MustParse("5")),
+				}}},
+				Usage:     Quantities{"default": {corev1.ResourceCPU: resource.MustParse("3")}},
+				Workloads: []string{"ns/admitted"},
+			},
+			"cq-b": {
+				Cohort:    "team",
+				Active:    true,
+				Quotas:    Quotas{"default": {corev1.ResourceCPU: {Nominal: resource.MustParse("4")}}},
+				Usage:     Quantities{"default": {corev1.ResourceCPU: resource.MustParse("1")}},
+				Workloads: []string{"ns/assumed"},
+			},
+		},
+		Cohorts: map[string]CohortState{
+			"team": {
+				Members:              []string{"cq-a", "cq-b"},
+				RequestableResources: Quantities{"default": {corev1.ResourceCPU: resource.MustParse("14")}},
+				Usage:                Quantities{"default": {corev1.ResourceCPU: resource.MustParse("4")}},
+			},
+		},
+		AssumedWorkloads: map[string]string{"ns/assumed": "cq-b"},
+	}
+	got := DumpCache(cCache)
+	if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b resource.Quantity) bool { return a.Cmp(b) == 0 }),
+		cmpopts.IgnoreFields(ClusterQueueState{}, "AllocatableResourceGeneration")); diff != "" {
+		t.Errorf("Unexpected cache dump (-want,+got):\n%s", diff)
+	}
+}
+
+func TestDumpQueues(t *testing.T) {
+	_, queues := setupState(t)
+	want := &QueuesDump{
+		ClusterQueues: map[string]queue.ClusterQueueDump{
+			"cq-a": {
+				Active:       []string{"ns/second"},
+				Inadmissible: []string{"ns/first"},
+			},
+			"cq-b": {},
+		},
+	}
+	if diff := cmp.Diff(want, DumpQueues(queues)); diff != "" {
+		t.Errorf("Unexpected queues dump (-want,+got):\n%s", diff)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	cases := map[string]struct {
+		path       string
+		token      string
+		wantStatus int
+		wantKeys   []string
+	}{
+		"no token": {
+			path:       QueuesPath,
+			wantStatus: http.StatusUnauthorized,
+		},
+		"invalid token": {
+			path:       QueuesPath,
+			token:      "invalid",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"user without access": {
+			path:       QueuesPath,
+			token:      "viewer",
+			wantStatus: http.StatusForbidden,
+		},
+		"queues": {
+			path:       QueuesPath,
+			token:      "admin",
+			wantStatus: http.StatusOK,
+			wantKeys:   []string{"clusterQueues"},
+		},
+		"cache": {
+			path:       CachePath,
+			token:      "admin",
+			wantStatus: http.StatusOK,
+			wantKeys:   []string{"assumedWorkloads", "clusterQueues", "cohorts"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cCache, queues := setupState(t)
+			cl := utiltesting.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+					switch o := obj.(type) {
+					case *authenticationv1.TokenReview:
+						if o.Spec.Token == "admin" || o.Spec.Token == "viewer" {
+							o.Status.Authenticated = true
+							o.Status.User.Username = o.Spec.Token
+						}
+					case *authorizationv1.SubjectAccessReview:
+						o.Status.Allowed = o.Spec.User == "admin" && o.Spec.NonResourceAttributes.Verb == "get"
+					}
+					return nil
+				},
+			}).Build()
+			srv := httptest.NewServer(NewHandler(cl, cCache, queues))
+			defer srv.Close()
+
+			req, err := http.NewRequest(http.MethodGet, srv.URL+tc.path, nil)
+			if err != nil {
+				t.Fatalf("Creating request: %v", err)
+			}
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Sending request: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tc.wantStatus {
+				t.Fatalf("Unexpected status %d, want %d", resp.StatusCode, tc.wantStatus)
+			}
+			if tc.wantStatus != http.StatusOK {
+				return
+			}
+			var got map[string]json.RawMessage
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("Decoding response: %v", err)
+			}
+			var gotKeys []string
+			for k := range got {
+				gotKeys = append(gotKeys, k)
+			}
+			if diff := cmp.Diff(tc.wantKeys, gotKeys, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("Unexpected keys in the response (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServer(t *testing.T) {
+	certDir := t.TempDir()
+	certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey("localhost", nil, nil)
+	if err != nil {
+		t.Fatalf("Generating certificate: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(certDir, certName), certPEM, 0o600); err != nil {
+		t.Fatalf("Writing certificate: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(certDir, keyName), keyPEM, 0o600); err != nil {
+		t.Fatalf("Writing key: %v", err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Finding a free port: %v", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	certsReady := make(chan struct{})
+	close(certsReady)
+	srv := NewServer(addr, certDir, certsReady, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- srv.Start(ctx)
+	}()
+	defer func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Debug server failed: %v", err)
+		}
+	}()
+
+	tlsClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	err = wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, 5*time.Second, true, func(context.Context) (bool, error) {
+		resp, err := tlsClient.Get("https://" + addr + QueuesPath)
+		if err != nil {
+			return false, nil
+		}
+		resp.Body.Close()
+		return resp.StatusCode == http.StatusNoContent, nil
+	})
+	if err != nil {
+		t.Fatalf("The debug server didn't serve TLS: %v", err)
+	}
+
+	resp, err := http.Get("http://" + addr + QueuesPath)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNoContent {
+			t.Errorf("The debug server served plain HTTP")
+		}
+	}
+}
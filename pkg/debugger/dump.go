@@ -0,0 +1,166 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// Quantities are the amounts of each resource of each flavor.
+type Quantities map[kueue.ResourceFlavorReference]map[corev1.ResourceName]resource.Quantity
+
+// Quota is the quota of a resource in a flavor.
+type Quota struct {
+	Nominal        resource.Quantity  `json:"nominal"`
+	BorrowingLimit *resource.Quantity `json:"borrowingLimit,omitempty"`
+}
+
+// Quotas are the quotas of each resource of each flavor.
+type Quotas map[kueue.ResourceFlavorReference]map[corev1.ResourceName]Quota
+
+// ClusterQueueState is the state of a ClusterQueue in the cache.
+type ClusterQueueState struct {
+	Cohort        string     `json:"cohort,omitempty"`
+	Active        bool       `json:"active"`
+	Quotas        Quotas     `json:"quotas,omitempty"`
+	Usage         Quantities `json:"usage,omitempty"`
+	AdmittedUsage Quantities `json:"admittedUsage,omitempty"`
+	// Workloads are the keys of the workloads that reserve quota in the
+	// ClusterQueue, sorted.
+	Workloads                     []string `json:"workloads,omitempty"`
+	AllocatableResourceGeneration int64    `json:"allocatableResourceGeneration"`
+}
+
+// CohortState is the state of a cohort in the cache.
+type CohortState struct {
+	Members              []string   `json:"members"`
+	RequestableResources Quantities `json:"requestableResources,omitempty"`
+	Usage                Quantities `json:"usage,omitempty"`
+}
+
+// CacheDump is the state of the cache, as seen by the scheduler.
+type CacheDump struct {
+	ClusterQueues map[string]ClusterQueueState `json:"clusterQueues"`
+	Cohorts       map[string]CohortState       `json:"cohorts"`
+	// AssumedWorkloads are the keys of the workloads that the scheduler
+	// assumed to be admitted, with their ClusterQueue, while the API server
+	// doesn't confirm the admission.
+	AssumedWorkloads map[string]string `json:"assumedWorkloads"`
+}
+
+// QueuesDump is the state of the pending workloads, by ClusterQueue.
+type QueuesDump struct {
+	ClusterQueues map[string]queue.ClusterQueueDump `json:"clusterQueues"`
+}
+
+// DumpCache returns the state of the snapshot of the cache used by the
+// scheduler and of the assumed workloads.
+func DumpCache(c *cache.Cache) *CacheDump {
+	snapshot := c.Snapshot()
+	dump := &CacheDump{
+		ClusterQueues:    make(map[string]ClusterQueueState, len(snapshot.ClusterQueues)),
+		Cohorts:          make(map[string]CohortState),
+		AssumedWorkloads: c.AssumedWorkloads(),
+	}
+	for name, cq := range snapshot.ClusterQueues {
+		state := ClusterQueueState{
+			Active:                        !snapshot.InactiveClusterQueueSets.Has(name),
+			Quotas:                        quotas(cq.ResourceGroups),
+			Usage:                         quantities(cq.Usage),
+			AdmittedUsage:                 quantities(cq.AdmittedUsage),
+			Workloads:                     sets.List(sets.KeySet(cq.Workloads)),
+			AllocatableResourceGeneration: cq.AllocatableResourceGeneration,
+		}
+		if cq.Cohort != nil {
+			state.Cohort = cq.Cohort.Name
+			if _, found := dump.Cohorts[cq.Cohort.Name]; !found {
+				dump.Cohorts[cq.Cohort.Name] = cohortState(cq.Cohort)
+			}
+		}
+		dump.ClusterQueues[name] = state
+	}
+	return dump
+}
+
+// DumpQueues returns the pending workloads of every ClusterQueue.
+func DumpQueues(m *queue.Manager) *QueuesDump {
+	return &QueuesDump{ClusterQueues: m.DumpOrdered()}
+}
+
+func cohortState(c *cache.Cohort) CohortState {
+	members := make([]string, 0, c.Members.Len())
+	for cq := range c.Members {
+		members = append(members, cq.Name)
+	}
+	sort.Strings(members)
+	return CohortState{
+		Members:              members,
+		RequestableResources: quantities(c.RequestableResources),
+		Usage:                quantities(c.Usage),
+	}
+}
+
+func quotas(rgs []cache.ResourceGroup) Quotas {
+	if len(rgs) == 0 {
+		return nil
+	}
+	ret := make(Quotas)
+	for _, rg := range rgs {
+		for _, fq := range rg.Flavors {
+			// A flavor can be in more than one resource group, for different
+			// resources.
+			fQuotas := ret[fq.Name]
+			if fQuotas == nil {
+				fQuotas = make(map[corev1.ResourceName]Quota, len(fq.Resources))
+				ret[fq.Name] = fQuotas
+			}
+			for rName, rq := range fq.Resources {
+				q := Quota{Nominal: workload.ResourceQuantity(rName, rq.Nominal)}
+				if rq.BorrowingLimit != nil {
+					q.BorrowingLimit = ptr.To(workload.ResourceQuantity(rName, *rq.BorrowingLimit))
+				}
+				fQuotas[rName] = q
+			}
+		}
+	}
+	return ret
+}
+
+func quantities(frq cache.FlavorResourceQuantities) Quantities {
+	if len(frq) == 0 {
+		return nil
+	}
+	ret := make(Quantities, len(frq))
+	for fName, resources := range frq {
+		rq := make(map[corev1.ResourceName]resource.Quantity, len(resources))
+		for rName, v := range resources {
+			rq[rName] = workload.ResourceQuantity(rName, v)
+		}
+		ret[fName] = rq
+	}
+	return ret
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+)
+
+const (
+	// CachePath serves the CacheDump.
+	CachePath = "/debug/kueue/cache"
+	// QueuesPath serves the QueuesDump.
+	QueuesPath = "/debug/kueue/queues"
+
+	certName = "tls.crt"
+	keyName  = "tls.key"
+)
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// NewHandler returns the handler of the debug endpoints.
+// The requests need a bearer token that the API server authenticates, and
+// the user needs permission to get the non-resource URL of the endpoint.
+func NewHandler(c client.Client, cCache *cache.Cache, queues *queue.Manager) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(CachePath, jsonHandler(func() any { return DumpCache(cCache) }))
+	mux.Handle(QueuesPath, jsonHandler(func() any { return DumpQueues(queues) }))
+	return &authHandler{client: c, next: mux}
+}
+
+func jsonHandler(dump func() any) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(dump()); err != nil {
+			ctrl.LoggerFrom(r.Context()).Error(err, "Encoding debug dump")
+		}
+	})
+}
+
+// authHandler authenticates the requests with a TokenReview and authorizes
+// them with a SubjectAccessReview, as the kube-rbac-proxy does for the
+// metrics.
+type authHandler struct {
+	client client.Client
+	next   http.Handler
+}
+
+func (h *authHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := ctrl.Log.WithName("debugger").WithValues("path", r.URL.Path)
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	tr := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := h.client.Create(r.Context(), tr); err != nil {
+		log.Error(err, "Reviewing token")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if !tr.Status.Authenticated {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	user := tr.Status.User
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			NonResourceAttributes: &authorizationv1.NonResourceAttributes{
+				Path: r.URL.Path,
+				Verb: strings.ToLower(r.Method),
+			},
+		},
+	}
+	if len(user.Extra) > 0 {
+		sar.Spec.Extra = make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+		for k, v := range user.Extra {
+			sar.Spec.Extra[k] = authorizationv1.ExtraValue(v)
+		}
+	}
+	if err := h.client.Create(r.Context(), sar); err != nil {
+		log.Error(err, "Reviewing access")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	if !sar.Status.Allowed {
+		log.V(2).Info("Forbidden debug request", "user", user.Username)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	log.V(2).Info("Serving debug request", "user", user.Username)
+	h.next.ServeHTTP(w, r)
+}
+
+// Server serves the debug endpoints over TLS. It implements manager.Runnable.
+type Server struct {
+	addr       string
+	certDir    string
+	certsReady <-chan struct{}
+	handler    http.Handler
+}
+
+// NewServer returns a Server of the handler that listens on addr. It serves
+// TLS with the tls.crt and tls.key files in certDir, which are the webhook
+// serving certificates, once certsReady is closed.
+func NewServer(addr, certDir string, certsReady <-chan struct{}, handler http.Handler) *Server {
+	return &Server{addr: addr, certDir: certDir, certsReady: certsReady, handler: handler}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("debugger").WithValues("addr", s.addr)
+	select {
+	case <-s.certsReady:
+	case <-ctx.Done():
+		return nil
+	}
+	watcher, err := certwatcher.New(filepath.Join(s.certDir, certName), filepath.Join(s.certDir, keyName))
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			log.Error(err, "Watching the certificates of the debug server")
+		}
+	}()
+	ln, err := tls.Listen("tcp", s.addr, &tls.Config{
+		GetCertificate: watcher.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	})
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Handler:           s.handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		log.Info("Shutting down the debug server")
+		if err := srv.Shutdown(context.Background()); err != nil {
+			log.Error(err, "Shutting down the debug server")
+		}
+	}()
+	log.Info("Starting the debug server")
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so that the
+// followers can also be inspected.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package debugger
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/queue"
+)
+
+// DumpSignal is the signal that makes the Dumper log the state.
+const DumpSignal = syscall.SIGUSR2
+
+// Dumper logs the state of the cache and of the queues when the process
+// receives DumpSignal, so that it can be inspected without network access to
+// the manager. It implements manager.Runnable.
+type Dumper struct {
+	cache  *cache.Cache
+	queues *queue.Manager
+}
+
+func NewDumper(cCache *cache.Cache, queues *queue.Manager) *Dumper {
+	return &Dumper{cache: cCache, queues: queues}
+}
+
+func (d *Dumper) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithName("debugger")
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, DumpSignal)
+	defer signal.Stop(ch)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ch:
+			d.LogDump(log)
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+func (d *Dumper) NeedLeaderElection() bool {
+	return false
+}
+
+// LogDump logs the CacheDump and the QueuesDump as JSON.
+func (d *Dumper) LogDump(log logr.Logger) {
+	logJSON(log, "Cache dump", DumpCache(d.cache))
+	logJSON(log, "Queues dump", DumpQueues(d.queues))
+}
+
+func logJSON(log logr.Logger, msg string, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Error(err, "Encoding debug dump")
+		return
+	}
+	log.Info(msg, "dump", json.RawMessage(data))
+}
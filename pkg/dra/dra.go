@@ -0,0 +1,104 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// +kubebuilder:rbac:groups=resource.k8s.io,resources=resourceclaimtemplates,verbs=get;list;watch
+
+// ResourceNamesByClass returns the resources that account for the devices of
+// each ResourceClass in the mappings.
+func ResourceNamesByClass(mappings []configapi.DeviceClassMapping) map[string]corev1.ResourceName {
+	ret := make(map[string]corev1.ResourceName)
+	for _, m := range mappings {
+		for _, className := range m.DeviceClassNames {
+			ret[className] = m.Name
+		}
+	}
+	return ret
+}
+
+// ErrTemplatesNotSynced is returned while the informer of the
+// ResourceClaimTemplates hasn't synced yet.
+var ErrTemplatesNotSynced = errors.New("the ResourceClaimTemplates aren't synced yet")
+
+// templateInformer is the part of the informers of the cache that gives
+// access to their store.
+type templateInformer interface {
+	HasSynced() bool
+	GetIndexer() toolscache.Indexer
+}
+
+// Templates reads the ResourceClaimTemplates from the store of an informer.
+type Templates struct {
+	informer templateInformer
+}
+
+// WatchTemplates registers the informer of the ResourceClaimTemplates in the
+// cache, so that it is started and synced with the manager.
+func WatchTemplates(ctx context.Context, c cache.Informers) (*Templates, error) {
+	informer, err := c.GetInformer(ctx, &resourcev1alpha2.ResourceClaimTemplate{}, cache.BlockUntilSynced(false))
+	if err != nil {
+		return nil, err
+	}
+	indexed, ok := informer.(templateInformer)
+	if !ok {
+		return nil, fmt.Errorf("the informer of the ResourceClaimTemplates doesn't expose its store")
+	}
+	return &Templates{informer: indexed}, nil
+}
+
+// Resolver returns a resolver that maps the ResourceClass of the
+// ResourceClaimTemplates to a resource. The templates with an unmapped
+// ResourceClass resolve to an empty name. A template accounts for one unit of
+// the resource, regardless of the number of devices that its claim allocates.
+//
+// The resolver only reads the store of the informer, so it doesn't block
+// while the cache and the queues hold their locks. It returns
+// ErrTemplatesNotSynced until the informer has synced.
+func (t *Templates) Resolver(resourceByClass map[string]corev1.ResourceName) workload.ResourceClaimTemplateResolver {
+	return func(namespace, name string) (corev1.ResourceName, error) {
+		if !t.informer.HasSynced() {
+			return "", ErrTemplatesNotSynced
+		}
+		obj, exists, err := t.informer.GetIndexer().GetByKey(toolscache.NewObjectName(namespace, name).String())
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return "", apierrors.NewNotFound(resourcev1alpha2.Resource("resourceclaimtemplates"), name)
+		}
+		template, ok := obj.(*resourcev1alpha2.ResourceClaimTemplate)
+		if !ok {
+			return "", fmt.Errorf("unexpected object %T in the store of the ResourceClaimTemplates", obj)
+		}
+		return resourceByClass[template.Spec.Spec.ResourceClassName], nil
+	}
+}
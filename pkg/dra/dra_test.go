@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dra
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	resourcev1alpha2 "k8s.io/api/resource/v1alpha2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+)
+
+func TestTemplateResolver(t *testing.T) {
+	store := toolscache.NewIndexer(toolscache.MetaNamespaceKeyFunc, toolscache.Indexers{})
+	for _, tmpl := range []*resourcev1alpha2.ResourceClaimTemplate{
+		makeTemplate("single-gpu", "ns", "gpu.example.com"),
+		makeTemplate("large-gpu", "ns", "gpu-large.example.com"),
+		makeTemplate("nic", "ns", "nic.example.com"),
+	} {
+		if err := store.Add(tmpl); err != nil {
+			t.Fatalf("Adding template: %v", err)
+		}
+	}
+	resourceByClass := ResourceNamesByClass([]configapi.DeviceClassMapping{
+		{
+			Name:             "example.com/gpu",
+			DeviceClassNames: []string{"gpu.example.com", "gpu-large.example.com"},
+		},
+	})
+
+	cases := map[string]struct {
+		notSynced    bool
+		namespace    string
+		name         string
+		wantResource corev1.ResourceName
+		wantErr      func(error) bool
+	}{
+		"mapped class": {
+			namespace:    "ns",
+			name:         "single-gpu",
+			wantResource: "example.com/gpu",
+		},
+		"another mapped class": {
+			namespace:    "ns",
+			name:         "large-gpu",
+			wantResource: "example.com/gpu",
+		},
+		"unmapped class": {
+			namespace: "ns",
+			name:      "nic",
+		},
+		"template in another namespace": {
+			namespace: "other",
+			name:      "single-gpu",
+			wantErr:   apierrors.IsNotFound,
+		},
+		"informer not synced": {
+			notSynced: true,
+			namespace: "ns",
+			name:      "single-gpu",
+			wantErr: func(err error) bool {
+				return errors.Is(err, ErrTemplatesNotSynced)
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			templates := &Templates{informer: &fakeInformer{synced: !tc.notSynced, store: store}}
+			got, err := templates.Resolver(resourceByClass)(tc.namespace, tc.name)
+			if tc.wantErr == nil && err != nil || tc.wantErr != nil && !tc.wantErr(err) {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantResource, got); diff != "" {
+				t.Errorf("Unexpected resource (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type fakeInformer struct {
+	synced bool
+	store  toolscache.Indexer
+}
+
+func (f *fakeInformer) HasSynced() bool {
+	return f.synced
+}
+
+func (f *fakeInformer) GetIndexer() toolscache.Indexer {
+	return f.store
+}
+
+func makeTemplate(name, namespace, className string) *resourcev1alpha2.ResourceClaimTemplate {
+	return &resourcev1alpha2.ResourceClaimTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: resourcev1alpha2.ResourceClaimTemplateSpec{
+			Spec: resourcev1alpha2.ResourceClaimSpec{
+				ResourceClassName: className,
+			},
+		},
+	}
+}
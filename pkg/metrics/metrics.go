@@ -99,10 +99,47 @@ The label 'result' can have the following values:
 		prometheus.HistogramOpts{
 			Subsystem: constants.KueueName,
 			Name:      "admission_wait_time_seconds",
-			Help:      "The time between a Workload was created until it was admitted, per 'cluster_queue'",
+			Help: `The time between a Workload was created until it got quota reservation, per 'cluster_queue'.
+For the Workloads with admission checks, the time until they are admitted is the sum of this and of admission_checks_wait_time_seconds.`,
 		}, []string{"cluster_queue"},
 	)
 
+	admissionChecksWaitTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "admission_checks_wait_time_seconds",
+			Help:      "The time between a Workload got quota reservation until it was admitted, per 'cluster_queue'. Only Workloads with admission checks are observed",
+		}, []string{"cluster_queue"},
+	)
+
+	readyWaitTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "ready_wait_time_seconds",
+			Help:      "The time between a Workload was admitted until all its pods were ready, per 'cluster_queue'",
+		}, []string{"cluster_queue"},
+	)
+
+	EvictedWorkloadsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "evicted_workloads_total",
+			Help: `The total number of evicted workloads per 'cluster_queue'.
+The label 'reason' is the reason of the Evicted condition of the workload, for example "Preempted" or "PodsReadyTimeout".`,
+		}, []string{"cluster_queue", "reason"},
+	)
+
+	PreemptedWorkloadsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "preempted_workloads_total",
+			Help: `The total number of preempted workloads per 'preempting_cluster_queue' and 'preempted_cluster_queue'.
+The label 'origin' can have the following values:
+- "ClusterQueue" means that the workload was preempted by a workload in the same ClusterQueue.
+- "cohort" means that the workload was preempted by a workload in another ClusterQueue of the cohort.`,
+		}, []string{"preempting_cluster_queue", "preempted_cluster_queue", "origin"},
+	)
+
 	// Metrics tied to the cache.
 
 	ReservingActiveWorkloads = prometheus.NewGaugeVec(
@@ -162,6 +199,102 @@ For a ClusterQueue, the metric only reports a value of 1 for one of the statuses
 			Help:      `Reports the cluster_queue's resource borrowing limit within all the flavors`,
 		}, []string{"cohort", "cluster_queue", "flavor", "resource"},
 	)
+
+	// Optional local queue metrics
+
+	LocalQueuePendingWorkloads = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "local_queue_pending_workloads",
+			Help: `The number of pending workloads, per 'local_queue' and 'status'.
+'status' can have the following values:
+- "active" means that the workloads are in the admission queue.
+- "inadmissible" means there was a failed admission attempt for these workloads and they won't be retried until cluster conditions, which could make this workload admissible, change`,
+		}, []string{"name", "namespace", "status"},
+	)
+
+	LocalQueueReservingActiveWorkloads = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "local_queue_reserving_active_workloads",
+			Help:      "The number of Workloads that are reserving quota, per 'local_queue'",
+		}, []string{"name", "namespace"},
+	)
+
+	LocalQueueAdmittedActiveWorkloads = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "local_queue_admitted_active_workloads",
+			Help:      "The number of admitted Workloads that are active (unsuspended and not finished), per 'local_queue'",
+		}, []string{"name", "namespace"},
+	)
+
+	LocalQueueAdmittedWorkloadsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "local_queue_admitted_workloads_total",
+			Help:      "The total number of admitted workloads per 'local_queue'",
+		}, []string{"name", "namespace"},
+	)
+
+	localQueueAdmissionWaitTime = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "local_queue_admission_wait_time_seconds",
+			Help:      "The time between a Workload was created until it got quota reservation, per 'local_queue'",
+		}, []string{"name", "namespace"},
+	)
+
+	LocalQueueEvictedWorkloadsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "local_queue_evicted_workloads_total",
+			Help:      "The total number of evicted workloads per 'local_queue' and eviction 'reason'",
+		}, []string{"name", "namespace", "reason"},
+	)
+
+	LocalQueueResourceReservations = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "local_queue_resource_reservation",
+			Help:      `Reports the local_queue's total resource reservation within all the flavors`,
+		}, []string{"name", "namespace", "flavor", "resource"},
+	)
+
+	LocalQueueResourceUsage = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: constants.KueueName,
+			Name:      "local_queue_resource_usage",
+			Help:      `Reports the local_queue's total resource usage within all the flavors`,
+		}, []string{"name", "namespace", "flavor", "resource"},
+	)
+
+	// Metrics tied to the ProvisioningRequest admission checks.
+
+	ProvisioningRequestRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "provisioning_request_retries_total",
+			Help:      "The total number of ProvisioningRequests recreated after their booking expired, per 'admission_check'",
+		}, []string{"admission_check"},
+	)
+
+	provisioningRequestRetryBackoff = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: constants.KueueName,
+			Name:      "provisioning_request_retry_backoff_seconds",
+			Help:      "The backoff before recreating a ProvisioningRequest whose booking expired, per 'admission_check'",
+			Buckets:   prometheus.ExponentialBuckets(60, 2, 8),
+		}, []string{"admission_check"},
+	)
+
+	ProvisioningRequestCapacityRevokedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: constants.KueueName,
+			Name:      "provisioning_request_capacity_revoked_total",
+			Help:      "The total number of workloads evicted because the capacity of their ProvisioningRequest was revoked, per 'admission_check'",
+		}, []string{"admission_check"},
+	)
 )
 
 func AdmissionAttempt(result AdmissionResult, duration time.Duration) {
@@ -174,6 +307,22 @@ func AdmittedWorkload(cqName kueue.ClusterQueueReference, waitTime time.Duration
 	admissionWaitTime.WithLabelValues(string(cqName)).Observe(waitTime.Seconds())
 }
 
+func AdmissionChecksWaitTime(cqName kueue.ClusterQueueReference, waitTime time.Duration) {
+	admissionChecksWaitTime.WithLabelValues(string(cqName)).Observe(waitTime.Seconds())
+}
+
+func ReadyWaitTime(cqName kueue.ClusterQueueReference, waitTime time.Duration) {
+	readyWaitTime.WithLabelValues(string(cqName)).Observe(waitTime.Seconds())
+}
+
+func ReportEvictedWorkloads(cqName kueue.ClusterQueueReference, reason string) {
+	EvictedWorkloadsTotal.WithLabelValues(string(cqName), reason).Inc()
+}
+
+func ReportPreemption(preemptingCqName, preemptedCqName, origin string) {
+	PreemptedWorkloadsTotal.WithLabelValues(preemptingCqName, preemptedCqName, origin).Inc()
+}
+
 func ReportPendingWorkloads(cqName string, active, inadmissible int) {
 	PendingWorkloads.WithLabelValues(cqName, PendingStatusActive).Set(float64(active))
 	PendingWorkloads.WithLabelValues(cqName, PendingStatusInadmissible).Set(float64(inadmissible))
@@ -184,6 +333,11 @@ func ClearQueueSystemMetrics(cqName string) {
 	PendingWorkloads.DeleteLabelValues(cqName, PendingStatusInadmissible)
 	AdmittedWorkloadsTotal.DeleteLabelValues(cqName)
 	admissionWaitTime.DeleteLabelValues(cqName)
+	admissionChecksWaitTime.DeleteLabelValues(cqName)
+	readyWaitTime.DeleteLabelValues(cqName)
+	EvictedWorkloadsTotal.DeletePartialMatch(prometheus.Labels{"cluster_queue": cqName})
+	PreemptedWorkloadsTotal.DeletePartialMatch(prometheus.Labels{"preempting_cluster_queue": cqName})
+	PreemptedWorkloadsTotal.DeletePartialMatch(prometheus.Labels{"preempted_cluster_queue": cqName})
 }
 
 func ReportClusterQueueStatus(cqName string, cqStatus ClusterQueueStatus) {
@@ -267,8 +421,77 @@ func ClearClusterQueueResourceReservations(cqName, flavor, resource string) {
 	ClusterQueueResourceReservations.DeletePartialMatch(lbls)
 }
 
-func Register() {
-	metrics.Registry.MustRegister(
+func ReportLocalQueuePendingWorkloads(name, namespace string, active, inadmissible int) {
+	LocalQueuePendingWorkloads.WithLabelValues(name, namespace, PendingStatusActive).Set(float64(active))
+	LocalQueuePendingWorkloads.WithLabelValues(name, namespace, PendingStatusInadmissible).Set(float64(inadmissible))
+}
+
+func ReportLocalQueueActiveWorkloads(name, namespace string, reserving, admitted int) {
+	LocalQueueReservingActiveWorkloads.WithLabelValues(name, namespace).Set(float64(reserving))
+	LocalQueueAdmittedActiveWorkloads.WithLabelValues(name, namespace).Set(float64(admitted))
+}
+
+func LocalQueueAdmittedWorkload(name, namespace string, waitTime time.Duration) {
+	LocalQueueAdmittedWorkloadsTotal.WithLabelValues(name, namespace).Inc()
+	localQueueAdmissionWaitTime.WithLabelValues(name, namespace).Observe(waitTime.Seconds())
+}
+
+func LocalQueueEvictedWorkload(name, namespace, reason string) {
+	LocalQueueEvictedWorkloadsTotal.WithLabelValues(name, namespace, reason).Inc()
+}
+
+func ReportLocalQueueResourceReservations(name, namespace, flavor, resource string, usage float64) {
+	LocalQueueResourceReservations.WithLabelValues(name, namespace, flavor, resource).Set(usage)
+}
+
+func ReportLocalQueueResourceUsage(name, namespace, flavor, resource string, usage float64) {
+	LocalQueueResourceUsage.WithLabelValues(name, namespace, flavor, resource).Set(usage)
+}
+
+func ClearLocalQueueResourceMetrics(name, namespace string) {
+	lbls := prometheus.Labels{
+		"name":      name,
+		"namespace": namespace,
+	}
+	LocalQueueResourceReservations.DeletePartialMatch(lbls)
+	LocalQueueResourceUsage.DeletePartialMatch(lbls)
+}
+
+func ClearLocalQueueMetrics(name, namespace string) {
+	lbls := prometheus.Labels{
+		"name":      name,
+		"namespace": namespace,
+	}
+	LocalQueuePendingWorkloads.DeletePartialMatch(lbls)
+	LocalQueueReservingActiveWorkloads.DeletePartialMatch(lbls)
+	LocalQueueAdmittedActiveWorkloads.DeletePartialMatch(lbls)
+	LocalQueueAdmittedWorkloadsTotal.DeletePartialMatch(lbls)
+	localQueueAdmissionWaitTime.DeletePartialMatch(lbls)
+	LocalQueueEvictedWorkloadsTotal.DeletePartialMatch(lbls)
+	ClearLocalQueueResourceMetrics(name, namespace)
+}
+
+func ProvisioningRequestRetry(checkName string, backoff time.Duration) {
+	ProvisioningRequestRetriesTotal.WithLabelValues(checkName).Inc()
+	provisioningRequestRetryBackoff.WithLabelValues(checkName).Observe(backoff.Seconds())
+}
+
+func ProvisioningRequestCapacityRevoked(checkName string) {
+	ProvisioningRequestCapacityRevokedTotal.WithLabelValues(checkName).Inc()
+}
+
+func ClearProvisioningRequestMetrics(checkName string) {
+	ProvisioningRequestRetriesTotal.DeleteLabelValues(checkName)
+	provisioningRequestRetryBackoff.DeleteLabelValues(checkName)
+	ProvisioningRequestCapacityRevokedTotal.DeleteLabelValues(checkName)
+}
+
+// Register registers the metrics in the registry of the controller manager.
+// The metrics are only collected once elected is closed, so that only the
+// leader replica exposes them, while the other replicas keep their values up
+// to date to take over.
+func Register(elected <-chan struct{}) {
+	metrics.Registry.MustRegister(newLeaderCollector(elected,
 		admissionAttemptsTotal,
 		admissionAttemptDuration,
 		PendingWorkloads,
@@ -276,9 +499,52 @@ func Register() {
 		AdmittedActiveWorkloads,
 		AdmittedWorkloadsTotal,
 		admissionWaitTime,
+		admissionChecksWaitTime,
+		readyWaitTime,
+		EvictedWorkloadsTotal,
+		PreemptedWorkloadsTotal,
 		ClusterQueueResourceUsage,
 		ClusterQueueResourceReservations,
 		ClusterQueueResourceNominalQuota,
 		ClusterQueueResourceBorrowingLimit,
-	)
+		LocalQueuePendingWorkloads,
+		LocalQueueReservingActiveWorkloads,
+		LocalQueueAdmittedActiveWorkloads,
+		LocalQueueAdmittedWorkloadsTotal,
+		localQueueAdmissionWaitTime,
+		LocalQueueEvictedWorkloadsTotal,
+		LocalQueueResourceReservations,
+		LocalQueueResourceUsage,
+		ProvisioningRequestRetriesTotal,
+		provisioningRequestRetryBackoff,
+		ProvisioningRequestCapacityRevokedTotal,
+	))
+}
+
+// leaderCollector collects the metrics of its collectors only once elected
+// is closed.
+type leaderCollector struct {
+	elected    <-chan struct{}
+	collectors []prometheus.Collector
+}
+
+func newLeaderCollector(elected <-chan struct{}, collectors ...prometheus.Collector) *leaderCollector {
+	return &leaderCollector{elected: elected, collectors: collectors}
+}
+
+func (c *leaderCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors {
+		collector.Describe(ch)
+	}
+}
+
+func (c *leaderCollector) Collect(ch chan<- prometheus.Metric) {
+	select {
+	case <-c.elected:
+	default:
+		return
+	}
+	for _, collector := range c.collectors {
+		collector.Collect(ch)
+	}
 }
@@ -18,8 +18,10 @@ package metrics
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"sigs.k8s.io/kueue/pkg/util/testing/metrics"
 )
@@ -127,3 +129,95 @@ func TestReportAndCleanupClusterQueueUsage(t *testing.T) {
 	expectFilteredMetricsCount(t, ClusterQueueResourceUsage, 1, "cluster_queue", "queue")
 	expectFilteredMetricsCount(t, ClusterQueueResourceUsage, 0, "cluster_queue", "queue", "flavor", "flavor", "resource", "res2")
 }
+
+func TestReportAndCleanupLocalQueueMetrics(t *testing.T) {
+	ReportLocalQueuePendingWorkloads("lq", "ns", 3, 1)
+	ReportLocalQueueActiveWorkloads("lq", "ns", 2, 1)
+	ReportLocalQueueResourceReservations("lq", "ns", "flavor", "res", 7)
+	ReportLocalQueueResourceReservations("lq", "ns", "flavor2", "res", 3)
+	ReportLocalQueueResourceUsage("lq", "ns", "flavor", "res", 5)
+	ReportLocalQueuePendingWorkloads("lq", "other-ns", 1, 0)
+
+	expectFilteredMetricsCount(t, LocalQueuePendingWorkloads, 2, "name", "lq", "namespace", "ns")
+	expectFilteredMetricsCount(t, LocalQueueReservingActiveWorkloads, 1, "name", "lq", "namespace", "ns")
+	expectFilteredMetricsCount(t, LocalQueueAdmittedActiveWorkloads, 1, "name", "lq", "namespace", "ns")
+	expectFilteredMetricsCount(t, LocalQueueResourceReservations, 2, "name", "lq", "namespace", "ns")
+	expectFilteredMetricsCount(t, LocalQueueResourceUsage, 1, "name", "lq", "namespace", "ns")
+
+	ClearLocalQueueResourceMetrics("lq", "ns")
+
+	expectFilteredMetricsCount(t, LocalQueueResourceReservations, 0, "name", "lq", "namespace", "ns")
+	expectFilteredMetricsCount(t, LocalQueueResourceUsage, 0, "name", "lq", "namespace", "ns")
+	expectFilteredMetricsCount(t, LocalQueuePendingWorkloads, 2, "name", "lq", "namespace", "ns")
+
+	ClearLocalQueueMetrics("lq", "ns")
+
+	expectFilteredMetricsCount(t, LocalQueuePendingWorkloads, 0, "name", "lq", "namespace", "ns")
+	expectFilteredMetricsCount(t, LocalQueueReservingActiveWorkloads, 0, "name", "lq", "namespace", "ns")
+	expectFilteredMetricsCount(t, LocalQueueAdmittedActiveWorkloads, 0, "name", "lq", "namespace", "ns")
+	expectFilteredMetricsCount(t, LocalQueuePendingWorkloads, 2, "name", "lq", "namespace", "other-ns")
+
+	ClearLocalQueueMetrics("lq", "other-ns")
+}
+
+func TestCleanupWorkloadLifecycleMetrics(t *testing.T) {
+	ReportEvictedWorkloads("cq", "Preempted")
+	ReportPreemption("cq", "other-cq", "cohort")
+	ReportPreemption("other-cq", "cq", "cohort")
+	ReportPreemption("other-cq", "other-cq", "ClusterQueue")
+
+	if got := testutil.CollectAndCount(EvictedWorkloadsTotal); got != 1 {
+		t.Errorf("Got %d evicted workloads series, want 1", got)
+	}
+	if got := testutil.CollectAndCount(PreemptedWorkloadsTotal); got != 3 {
+		t.Errorf("Got %d preempted workloads series, want 3", got)
+	}
+
+	ClearQueueSystemMetrics("cq")
+
+	if got := testutil.CollectAndCount(EvictedWorkloadsTotal); got != 0 {
+		t.Errorf("Got %d evicted workloads series after cleanup, want 0", got)
+	}
+	if got := testutil.CollectAndCount(PreemptedWorkloadsTotal); got != 1 {
+		t.Errorf("Got %d preempted workloads series after cleanup, want 1", got)
+	}
+	ClearQueueSystemMetrics("other-cq")
+}
+
+func TestCleanupProvisioningRequestMetrics(t *testing.T) {
+	ProvisioningRequestRetry("check", time.Minute)
+	ProvisioningRequestCapacityRevoked("check")
+	ProvisioningRequestRetry("other-check", 2*time.Minute)
+
+	if got := testutil.CollectAndCount(ProvisioningRequestRetriesTotal); got != 2 {
+		t.Errorf("Got %d provisioning request retries series, want 2", got)
+	}
+	if got := testutil.CollectAndCount(ProvisioningRequestCapacityRevokedTotal); got != 1 {
+		t.Errorf("Got %d capacity revoked series, want 1", got)
+	}
+
+	ClearProvisioningRequestMetrics("check")
+
+	if got := testutil.CollectAndCount(ProvisioningRequestRetriesTotal); got != 1 {
+		t.Errorf("Got %d provisioning request retries series after cleanup, want 1", got)
+	}
+	if got := testutil.CollectAndCount(ProvisioningRequestCapacityRevokedTotal); got != 0 {
+		t.Errorf("Got %d capacity revoked series after cleanup, want 0", got)
+	}
+	ClearProvisioningRequestMetrics("other-check")
+}
+
+func TestLeaderCollector(t *testing.T) {
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_gauge"}, []string{"cluster_queue"})
+	gauge.WithLabelValues("cq").Set(1)
+	elected := make(chan struct{})
+	collector := newLeaderCollector(elected, gauge)
+
+	if got := testutil.CollectAndCount(collector); got != 0 {
+		t.Errorf("Got %d series before the election, want 0", got)
+	}
+	close(elected)
+	if got := testutil.CollectAndCount(collector); got != 1 {
+		t.Errorf("Got %d series after the election, want 1", got)
+	}
+}
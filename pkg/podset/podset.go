@@ -15,12 +15,14 @@ package podset
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"maps"
 	"slices"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -28,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
 )
 
@@ -43,6 +46,16 @@ type PodSetInfo struct {
 	Labels       map[string]string
 	NodeSelector map[string]string
 	Tolerations  []corev1.Toleration
+	// Env holds the environment variables added to all the containers.
+	Env []corev1.EnvVar
+	// ContainersEnv holds the environment variables of each container, by
+	// container name. It is only used to restore the pod template.
+	ContainersEnv             map[string][]corev1.EnvVar
+	RuntimeClassName          *string
+	SchedulerName             string
+	PodAffinity               *corev1.PodAffinity
+	PodAntiAffinity           *corev1.PodAntiAffinity
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint
 }
 
 // FromAssignment returns a PodSetInfo based on the provided assignment and an error if unable
@@ -67,12 +80,29 @@ func FromAssignment(ctx context.Context, client client.Client, assignment *kueue
 		}
 		info.NodeSelector = utilmaps.MergeKeepFirst(info.NodeSelector, flv.Spec.NodeLabels)
 		info.Tolerations = append(info.Tolerations, flv.Spec.Tolerations...)
+		if flv.Spec.PodTemplateOverrides != nil {
+			if err := info.Merge(fromOverrides(flv.Spec.PodTemplateOverrides)); err != nil {
+				return info, fmt.Errorf("in flavor %q: %w", flvRef, err)
+			}
+		}
 
 		processedFlvs.Insert(flvRef)
 	}
 	return info, nil
 }
 
+func fromOverrides(overrides *kueue.PodTemplateOverrides) PodSetInfo {
+	return PodSetInfo{
+		Annotations:               overrides.Annotations,
+		Env:                       overrides.Env,
+		RuntimeClassName:          overrides.RuntimeClassName,
+		SchedulerName:             overrides.SchedulerName,
+		PodAffinity:               overrides.PodAffinity,
+		PodAntiAffinity:           overrides.PodAntiAffinity,
+		TopologySpreadConstraints: overrides.TopologySpreadConstraints,
+	}
+}
+
 // FromUpdate returns a PodSetInfo based on the provided PodSetUpdate
 func FromUpdate(update *kueue.PodSetUpdate) PodSetInfo {
 	return PodSetInfo{
@@ -85,14 +115,27 @@ func FromUpdate(update *kueue.PodSetUpdate) PodSetInfo {
 
 // FromPodSet returns a PodSeeInfo based on the provided PodSet
 func FromPodSet(ps *kueue.PodSet) PodSetInfo {
-	return PodSetInfo{
-		Name:         ps.Name,
-		Count:        ps.Count,
-		Annotations:  maps.Clone(ps.Template.Annotations),
-		Labels:       maps.Clone(ps.Template.Labels),
-		NodeSelector: maps.Clone(ps.Template.Spec.NodeSelector),
-		Tolerations:  slices.Clone(ps.Template.Spec.Tolerations),
+	spec := ps.Template.Spec.DeepCopy()
+	info := PodSetInfo{
+		Name:                      ps.Name,
+		Count:                     ps.Count,
+		Annotations:               maps.Clone(ps.Template.Annotations),
+		Labels:                    maps.Clone(ps.Template.Labels),
+		NodeSelector:              spec.NodeSelector,
+		Tolerations:               spec.Tolerations,
+		ContainersEnv:             make(map[string][]corev1.EnvVar, len(spec.Containers)),
+		RuntimeClassName:          spec.RuntimeClassName,
+		SchedulerName:             spec.SchedulerName,
+		TopologySpreadConstraints: spec.TopologySpreadConstraints,
+	}
+	for i := range spec.Containers {
+		info.ContainersEnv[spec.Containers[i].Name] = spec.Containers[i].Env
+	}
+	if spec.Affinity != nil {
+		info.PodAffinity = spec.Affinity.PodAffinity
+		info.PodAntiAffinity = spec.Affinity.PodAntiAffinity
 	}
+	return info
 }
 
 func (podSetInfo *PodSetInfo) Merge(o PodSetInfo) error {
@@ -105,32 +148,203 @@ func (podSetInfo *PodSetInfo) Merge(o PodSetInfo) error {
 	if err := utilmaps.HaveConflict(podSetInfo.NodeSelector, o.NodeSelector); err != nil {
 		return BadPodSetsUpdateError("nodeSelector", err)
 	}
+	env, _, err := mergeEnv(podSetInfo.Env, o.Env)
+	if err != nil {
+		return BadPodSetsUpdateError("env", err)
+	}
+	if a, b := podSetInfo.RuntimeClassName, o.RuntimeClassName; a != nil && b != nil && *a != *b {
+		return BadPodSetsUpdateError("runtimeClassName", fmt.Errorf("value1=%v, value2=%v", *a, *b))
+	}
+	if a, b := podSetInfo.SchedulerName, o.SchedulerName; a != "" && b != "" && a != b {
+		return BadPodSetsUpdateError("schedulerName", fmt.Errorf("value1=%v, value2=%v", a, b))
+	}
+	constraints, err := mergeTopologySpreadConstraints(podSetInfo.TopologySpreadConstraints, o.TopologySpreadConstraints)
+	if err != nil {
+		return BadPodSetsUpdateError("topologySpreadConstraints", err)
+	}
 	podSetInfo.Annotations = utilmaps.MergeKeepFirst(podSetInfo.Annotations, o.Annotations)
 	podSetInfo.Labels = utilmaps.MergeKeepFirst(podSetInfo.Labels, o.Labels)
 	podSetInfo.NodeSelector = utilmaps.MergeKeepFirst(podSetInfo.NodeSelector, o.NodeSelector)
 	podSetInfo.Tolerations = append(podSetInfo.Tolerations, o.Tolerations...)
+	podSetInfo.Env = env
+	if podSetInfo.RuntimeClassName == nil {
+		podSetInfo.RuntimeClassName = o.RuntimeClassName
+	}
+	if podSetInfo.SchedulerName == "" {
+		podSetInfo.SchedulerName = o.SchedulerName
+	}
+	podSetInfo.PodAffinity = mergePodAffinity(podSetInfo.PodAffinity, o.PodAffinity)
+	podSetInfo.PodAntiAffinity = mergePodAntiAffinity(podSetInfo.PodAntiAffinity, o.PodAntiAffinity)
+	podSetInfo.TopologySpreadConstraints = constraints
 	return nil
 }
 
+// mergeEnv appends to a the variables of b that are not in a. It also returns
+// the names of the appended variables.
+// It returns error if a variable is defined in both with different values.
+func mergeEnv(a, b []corev1.EnvVar) ([]corev1.EnvVar, []string, error) {
+	merged := slices.Clone(a)
+	var added []string
+	for _, v := range b {
+		idx := slices.IndexFunc(merged, func(e corev1.EnvVar) bool { return e.Name == v.Name })
+		if idx == -1 {
+			merged = append(merged, v)
+			added = append(added, v.Name)
+		} else if !equality.Semantic.DeepEqual(merged[idx], v) {
+			return nil, nil, fmt.Errorf("conflict for env var %q", v.Name)
+		}
+	}
+	return merged, added, nil
+}
+
+// mergeTopologySpreadConstraints appends to a the constraints of b that are not in a.
+// It returns error if both have a different constraint for the same topologyKey
+// and whenUnsatisfiable.
+func mergeTopologySpreadConstraints(a, b []corev1.TopologySpreadConstraint) ([]corev1.TopologySpreadConstraint, error) {
+	merged := slices.Clone(a)
+	for _, c := range b {
+		idx := slices.IndexFunc(merged, func(e corev1.TopologySpreadConstraint) bool {
+			return e.TopologyKey == c.TopologyKey && e.WhenUnsatisfiable == c.WhenUnsatisfiable
+		})
+		if idx == -1 {
+			merged = append(merged, c)
+		} else if !equality.Semantic.DeepEqual(merged[idx], c) {
+			return nil, fmt.Errorf("conflict for topologyKey=%v, whenUnsatisfiable=%v", c.TopologyKey, c.WhenUnsatisfiable)
+		}
+	}
+	return merged, nil
+}
+
+func mergePodAffinity(a, b *corev1.PodAffinity) *corev1.PodAffinity {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		return b.DeepCopy()
+	}
+	merged := a.DeepCopy()
+	merged.RequiredDuringSchedulingIgnoredDuringExecution = append(merged.RequiredDuringSchedulingIgnoredDuringExecution, b.RequiredDuringSchedulingIgnoredDuringExecution...)
+	merged.PreferredDuringSchedulingIgnoredDuringExecution = append(merged.PreferredDuringSchedulingIgnoredDuringExecution, b.PreferredDuringSchedulingIgnoredDuringExecution...)
+	return merged
+}
+
+func mergePodAntiAffinity(a, b *corev1.PodAntiAffinity) *corev1.PodAntiAffinity {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		return b.DeepCopy()
+	}
+	merged := a.DeepCopy()
+	merged.RequiredDuringSchedulingIgnoredDuringExecution = append(merged.RequiredDuringSchedulingIgnoredDuringExecution, b.RequiredDuringSchedulingIgnoredDuringExecution...)
+	merged.PreferredDuringSchedulingIgnoredDuringExecution = append(merged.PreferredDuringSchedulingIgnoredDuringExecution, b.PreferredDuringSchedulingIgnoredDuringExecution...)
+	return merged
+}
+
 // Merge updates or appends the replica metadata & spec fields based on PodSetInfo.
 // It returns error if there is a conflict.
 func Merge(meta *metav1.ObjectMeta, spec *corev1.PodSpec, info PodSetInfo) error {
 	tmp := PodSetInfo{
-		Annotations:  meta.Annotations,
-		Labels:       meta.Labels,
-		NodeSelector: spec.NodeSelector,
-		Tolerations:  spec.Tolerations,
+		Annotations:               meta.Annotations,
+		Labels:                    meta.Labels,
+		NodeSelector:              spec.NodeSelector,
+		Tolerations:               spec.Tolerations,
+		RuntimeClassName:          spec.RuntimeClassName,
+		SchedulerName:             spec.SchedulerName,
+		TopologySpreadConstraints: spec.TopologySpreadConstraints,
+	}
+	if tmp.SchedulerName == corev1.DefaultSchedulerName {
+		tmp.SchedulerName = ""
+	}
+	if spec.Affinity != nil {
+		tmp.PodAffinity = spec.Affinity.PodAffinity
+		tmp.PodAntiAffinity = spec.Affinity.PodAntiAffinity
 	}
 	if err := tmp.Merge(info); err != nil {
 		return err
 	}
+
+	containersEnv := make([][]corev1.EnvVar, len(spec.Containers))
+	addedEnv := make(map[string][]string)
+	for i := range spec.Containers {
+		env, added, err := mergeEnv(spec.Containers[i].Env, info.Env)
+		if err != nil {
+			return BadPodSetsUpdateError("env", err)
+		}
+		containersEnv[i] = env
+		if len(added) > 0 {
+			addedEnv[spec.Containers[i].Name] = added
+		}
+	}
+	if len(addedEnv) > 0 {
+		// Keep track of the variables added to each container, so that they
+		// can be told apart from the ones in the original containers.
+		for name, recorded := range flavorEnv(tmp.Annotations) {
+			addedEnv[name] = sets.List(sets.New(recorded...).Insert(addedEnv[name]...))
+		}
+		recorded, err := json.Marshal(addedEnv)
+		if err != nil {
+			return err
+		}
+		tmp.Annotations = maps.Clone(tmp.Annotations)
+		if tmp.Annotations == nil {
+			tmp.Annotations = make(map[string]string, 1)
+		}
+		tmp.Annotations[constants.FlavorEnvAnnotation] = string(recorded)
+	}
+
 	meta.Annotations = tmp.Annotations
 	meta.Labels = tmp.Labels
 	spec.NodeSelector = tmp.NodeSelector
 	spec.Tolerations = tmp.Tolerations
+	for i := range spec.Containers {
+		spec.Containers[i].Env = containersEnv[i]
+	}
+	spec.RuntimeClassName = tmp.RuntimeClassName
+	if tmp.SchedulerName != "" {
+		spec.SchedulerName = tmp.SchedulerName
+	}
+	if tmp.PodAffinity != nil || tmp.PodAntiAffinity != nil {
+		if spec.Affinity == nil {
+			spec.Affinity = &corev1.Affinity{}
+		}
+		spec.Affinity.PodAffinity = tmp.PodAffinity
+		spec.Affinity.PodAntiAffinity = tmp.PodAntiAffinity
+	}
+	spec.TopologySpreadConstraints = tmp.TopologySpreadConstraints
 	return nil
 }
 
+// flavorEnv returns the names of the environment variables added by Merge to
+// each container, as recorded in the annotations.
+func flavorEnv(annotations map[string]string) map[string][]string {
+	recorded := annotations[constants.FlavorEnvAnnotation]
+	if recorded == "" {
+		return nil
+	}
+	var added map[string][]string
+	if err := json.Unmarshal([]byte(recorded), &added); err != nil {
+		return nil
+	}
+	return added
+}
+
+// RemoveFlavorEnv removes from the containers of the pod template the
+// environment variables that were added by Merge from the podTemplateOverrides
+// of the ResourceFlavors.
+func RemoveFlavorEnv(template *corev1.PodTemplateSpec) {
+	if _, found := template.Annotations[constants.FlavorEnvAnnotation]; !found {
+		return
+	}
+	added := flavorEnv(template.Annotations)
+	for i := range template.Spec.Containers {
+		c := &template.Spec.Containers[i]
+		names := sets.New(added[c.Name]...)
+		c.Env = slices.DeleteFunc(c.Env, func(e corev1.EnvVar) bool { return names.Has(e.Name) })
+	}
+	delete(template.Annotations, constants.FlavorEnvAnnotation)
+}
+
 // RestorePodSpec sets replica metadata and spec fields based on PodSetInfo.
 // It returns true if there is any change.
 func RestorePodSpec(meta *metav1.ObjectMeta, spec *corev1.PodSpec, info PodSetInfo) bool {
@@ -151,6 +365,45 @@ func RestorePodSpec(meta *metav1.ObjectMeta, spec *corev1.PodSpec, info PodSetIn
 		spec.Tolerations = slices.Clone(info.Tolerations)
 		changed = true
 	}
+	for i := range spec.Containers {
+		c := &spec.Containers[i]
+		if env, found := info.ContainersEnv[c.Name]; found && !equality.Semantic.DeepEqual(c.Env, env) {
+			c.Env = slices.Clone(env)
+			changed = true
+		}
+	}
+	if !equality.Semantic.DeepEqual(spec.RuntimeClassName, info.RuntimeClassName) {
+		spec.RuntimeClassName = nil
+		if info.RuntimeClassName != nil {
+			spec.RuntimeClassName = ptr.To(*info.RuntimeClassName)
+		}
+		changed = true
+	}
+	if spec.SchedulerName != info.SchedulerName {
+		spec.SchedulerName = info.SchedulerName
+		changed = true
+	}
+	var podAffinity *corev1.PodAffinity
+	var podAntiAffinity *corev1.PodAntiAffinity
+	if spec.Affinity != nil {
+		podAffinity = spec.Affinity.PodAffinity
+		podAntiAffinity = spec.Affinity.PodAntiAffinity
+	}
+	if !equality.Semantic.DeepEqual(podAffinity, info.PodAffinity) || !equality.Semantic.DeepEqual(podAntiAffinity, info.PodAntiAffinity) {
+		if spec.Affinity == nil {
+			spec.Affinity = &corev1.Affinity{}
+		}
+		spec.Affinity.PodAffinity = info.PodAffinity.DeepCopy()
+		spec.Affinity.PodAntiAffinity = info.PodAntiAffinity.DeepCopy()
+		if spec.Affinity.NodeAffinity == nil && spec.Affinity.PodAffinity == nil && spec.Affinity.PodAntiAffinity == nil {
+			spec.Affinity = nil
+		}
+		changed = true
+	}
+	if !equality.Semantic.DeepEqual(spec.TopologySpreadConstraints, info.TopologySpreadConstraints) {
+		spec.TopologySpreadConstraints = slices.Clone(info.TopologySpreadConstraints)
+		changed = true
+	}
 	return changed
 }
 
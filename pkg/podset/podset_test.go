@@ -17,6 +17,7 @@ package podset
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -27,6 +28,7 @@ import (
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/controller/constants"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
@@ -62,11 +64,27 @@ func TestFromAssignment(t *testing.T) {
 		Toleration(*toleration3.DeepCopy()).
 		Obj()
 
+	flavor3 := utiltesting.MakeResourceFlavor("flavor3").
+		Label("f3l1", "f3v1").
+		PodTemplateOverrides(&kueue.PodTemplateOverrides{
+			Annotations:      map[string]string{"f3a1": "f3v1"},
+			Env:              []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "flavor3"}},
+			RuntimeClassName: ptr.To("nvidia"),
+		}).
+		Obj()
+
+	flavor4 := utiltesting.MakeResourceFlavor("flavor4").
+		PodTemplateOverrides(&kueue.PodTemplateOverrides{
+			Env: []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "flavor4"}},
+		}).
+		Obj()
+
 	cases := map[string]struct {
 		assignment   *kueue.PodSetAssignment
 		defaultCount int32
 		flavors      []kueue.ResourceFlavor
 		wantError    error
+		wantErrorIs  error
 		wantInfo     PodSetInfo
 	}{
 		"single flavor": {
@@ -133,6 +151,41 @@ func TestFromAssignment(t *testing.T) {
 				Tolerations: []corev1.Toleration{*toleration1.DeepCopy(), *toleration2.DeepCopy()},
 			},
 		},
+		"flavor with pod template overrides": {
+			assignment: &kueue.PodSetAssignment{
+				Name: "name",
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+					corev1.ResourceCPU: kueue.ResourceFlavorReference(flavor3.Name),
+				},
+				Count: ptr.To[int32](2),
+			},
+			defaultCount: 4,
+			flavors:      []kueue.ResourceFlavor{*flavor3.DeepCopy()},
+			wantInfo: PodSetInfo{
+				Name:  "name",
+				Count: 2,
+				NodeSelector: map[string]string{
+					"f3l1": "f3v1",
+				},
+				Annotations: map[string]string{
+					"f3a1": "f3v1",
+				},
+				Env:              []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "flavor3"}},
+				RuntimeClassName: ptr.To("nvidia"),
+			},
+		},
+		"flavors with conflicting pod template overrides": {
+			assignment: &kueue.PodSetAssignment{
+				Name: "name",
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+					corev1.ResourceCPU:    kueue.ResourceFlavorReference(flavor3.Name),
+					corev1.ResourceMemory: kueue.ResourceFlavorReference(flavor4.Name),
+				},
+			},
+			defaultCount: 4,
+			flavors:      []kueue.ResourceFlavor{*flavor3.DeepCopy(), *flavor4.DeepCopy()},
+			wantErrorIs:  ErrInvalidPodSetUpdate,
+		},
 		"flavor not found": {
 			assignment: &kueue.PodSetAssignment{
 				Name: "name",
@@ -171,11 +224,15 @@ func TestFromAssignment(t *testing.T) {
 
 			gotInfo, gotError := FromAssignment(ctx, client, tc.assignment, tc.defaultCount)
 
-			if diff := cmp.Diff(tc.wantError, gotError); diff != "" {
+			if tc.wantErrorIs != nil {
+				if !errors.Is(gotError, tc.wantErrorIs) {
+					t.Errorf("Unexpected error %v, want %v", gotError, tc.wantErrorIs)
+				}
+			} else if diff := cmp.Diff(tc.wantError, gotError); diff != "" {
 				t.Errorf("Unexpected error (-want/+got):\n%s", diff)
 			}
 
-			if tc.wantError == nil {
+			if tc.wantError == nil && tc.wantErrorIs == nil {
 				if diff := cmp.Diff(tc.wantInfo, gotInfo, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b corev1.Toleration) bool { return a.Key < b.Key })); diff != "" {
 					t.Errorf("Unexpected info (-want/+got):\n%s", diff)
 				}
@@ -198,6 +255,35 @@ func TestMergeRestore(t *testing.T) {
 		}).
 		Obj()
 
+	podAffinity := &corev1.PodAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{{
+			TopologyKey: "zone",
+		}},
+	}
+	spreadConstraint := corev1.TopologySpreadConstraint{
+		MaxSkew:           1,
+		TopologyKey:       "zone",
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+	}
+	overridesInfo := PodSetInfo{
+		Env:                       []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "f1"}},
+		RuntimeClassName:          ptr.To("nvidia"),
+		SchedulerName:             "gpu-scheduler",
+		PodAffinity:               podAffinity,
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{spreadConstraint},
+	}
+
+	podSetWithOverrides := basePodSet.DeepCopy()
+	podSetWithOverrides.Template.Annotations[constants.FlavorEnvAnnotation] = `{"c":["KUEUE_FLAVOR"]}`
+	podSetWithOverrides.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "f1"}}
+	podSetWithOverrides.Template.Spec.RuntimeClassName = ptr.To("nvidia")
+	podSetWithOverrides.Template.Spec.SchedulerName = "gpu-scheduler"
+	podSetWithOverrides.Template.Spec.Affinity = &corev1.Affinity{PodAffinity: podAffinity}
+	podSetWithOverrides.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{spreadConstraint}
+
+	podSetWithEnv := basePodSet.DeepCopy()
+	podSetWithEnv.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "f1"}}
+
 	cases := map[string]struct {
 		podSet             *kueue.PodSet
 		info               PodSetInfo
@@ -249,6 +335,44 @@ func TestMergeRestore(t *testing.T) {
 				Obj(),
 			wantRestoreChanges: true,
 		},
+		"pod template overrides": {
+			podSet:             basePodSet.DeepCopy(),
+			info:               overridesInfo,
+			wantPodSet:         podSetWithOverrides,
+			wantRestoreChanges: true,
+		},
+		"env var already defined with the same value": {
+			podSet: podSetWithEnv.DeepCopy(),
+			info: PodSetInfo{
+				Env: []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "f1"}},
+			},
+			wantPodSet: podSetWithEnv.DeepCopy(),
+		},
+		"conflicting env var": {
+			podSet: podSetWithEnv.DeepCopy(),
+			info: PodSetInfo{
+				Env: []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "f2"}},
+			},
+			wantError: true,
+		},
+		"conflicting runtime class": {
+			podSet: podSetWithOverrides.DeepCopy(),
+			info: PodSetInfo{
+				RuntimeClassName: ptr.To("gvisor"),
+			},
+			wantError: true,
+		},
+		"conflicting topology spread constraint": {
+			podSet: podSetWithOverrides.DeepCopy(),
+			info: PodSetInfo{
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           2,
+					TopologyKey:       "zone",
+					WhenUnsatisfiable: corev1.DoNotSchedule,
+				}},
+			},
+			wantError: true,
+		},
 		"conflicting label": {
 			podSet: basePodSet.DeepCopy(),
 			info: PodSetInfo{
@@ -306,3 +430,28 @@ func TestMergeRestore(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveFlavorEnv(t *testing.T) {
+	podSet := utiltesting.MakePodSet("", 1).
+		Annotations(map[string]string{"a0": "a0v"}).
+		Obj()
+	podSet.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "FOO", Value: "foo"}}
+	podSet.Template.Spec.Containers = append(podSet.Template.Spec.Containers, corev1.Container{
+		Name: "sidecar",
+		Env:  []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "f1"}},
+	})
+	want := podSet.Template.DeepCopy()
+
+	if err := Merge(&podSet.Template.ObjectMeta, &podSet.Template.Spec, PodSetInfo{
+		Env: []corev1.EnvVar{{Name: "FOO", Value: "foo"}, {Name: "KUEUE_FLAVOR", Value: "f1"}},
+	}); err != nil {
+		t.Fatalf("Unexpected merge error: %v", err)
+	}
+	if got, want := podSet.Template.Annotations[constants.FlavorEnvAnnotation], `{"c":["KUEUE_FLAVOR"],"sidecar":["FOO"]}`; got != want {
+		t.Errorf("Unexpected %s annotation %q, want %q", constants.FlavorEnvAnnotation, got, want)
+	}
+	RemoveFlavorEnv(&podSet.Template)
+	if diff := cmp.Diff(*want, podSet.Template, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Unexpected template (-want/+got):\n%s", diff)
+	}
+}
@@ -34,6 +34,10 @@ const (
 	RequeueReasonNamespaceMismatch     RequeueReason = "NamespaceMismatch"
 	RequeueReasonGeneric               RequeueReason = ""
 	RequeueReasonPendingPreemption     RequeueReason = "PendingPreemption"
+	// RequeueReasonWaitingForPodsReady is used when the admission is blocked
+	// until the admitted workloads are in the PodsReady condition. The
+	// workloads are requeued once they are.
+	RequeueReasonWaitingForPodsReady RequeueReason = "WaitingForPodsReady"
 )
 
 // ClusterQueue is an interface for a cluster queue to store workloads waiting
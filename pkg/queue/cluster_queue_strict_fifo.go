@@ -61,7 +61,8 @@ func queueOrdering(a, b interface{}) bool {
 
 // RequeueIfNotPresent requeues if the workload is not present.
 // If the reason for requeue is that the workload doesn't match the CQ's
-// namespace selector, then the requeue is not immediate.
+// namespace selector, or that the admission waits for the pods of the admitted
+// workloads to be ready, then the requeue is not immediate.
 func (cq *ClusterQueueStrictFIFO) RequeueIfNotPresent(wInfo *workload.Info, reason RequeueReason) bool {
-	return cq.requeueIfNotPresent(wInfo, reason != RequeueReasonNamespaceMismatch)
+	return cq.requeueIfNotPresent(wInfo, reason != RequeueReasonNamespaceMismatch && reason != RequeueReasonWaitingForPodsReady)
 }
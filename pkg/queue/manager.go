@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"sync"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -31,6 +33,7 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	utilindexer "sigs.k8s.io/kueue/pkg/controller/core/indexer"
 	"sigs.k8s.io/kueue/pkg/metrics"
+	"sigs.k8s.io/kueue/pkg/tracing"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
@@ -54,17 +57,52 @@ type Manager struct {
 
 	// Key is cohort's name. Value is a set of associated ClusterQueue names.
 	cohorts map[string]sets.Set[string]
+
+	workloadInfoOptions []workload.InfoOption
+	// maxHeadsPerClusterQueue is the number of workloads that Heads pops
+	// from each ClusterQueue.
+	maxHeadsPerClusterQueue int
+}
+
+type options struct {
+	workloadInfoOptions     []workload.InfoOption
+	maxHeadsPerClusterQueue int
 }
 
-func NewManager(client client.Client, checker StatusChecker) *Manager {
+// Option configures the manager.
+type Option func(*options)
+
+// WithWorkloadInfoOptions sets the options to compute the requests of the
+// pending workloads, such as the resource transformations and exclusions.
+func WithWorkloadInfoOptions(opts ...workload.InfoOption) Option {
+	return func(o *options) {
+		o.workloadInfoOptions = opts
+	}
+}
+
+// WithMaxHeadsPerClusterQueue sets the number of workloads that Heads pops
+// from each ClusterQueue. Defaults to 1.
+func WithMaxHeadsPerClusterQueue(n int) Option {
+	return func(o *options) {
+		o.maxHeadsPerClusterQueue = n
+	}
+}
+
+func NewManager(client client.Client, checker StatusChecker, opts ...Option) *Manager {
+	options := options{maxHeadsPerClusterQueue: 1}
+	for _, opt := range opts {
+		opt(&options)
+	}
 	m := &Manager{
-		client:         client,
-		statusChecker:  checker,
-		localQueues:    make(map[string]*LocalQueue),
-		clusterQueues:  make(map[string]ClusterQueue),
-		cohorts:        make(map[string]sets.Set[string]),
-		snapshotsMutex: sync.RWMutex{},
-		snapshots:      make(map[string][]kueue.ClusterQueuePendingWorkload, 0),
+		client:                  client,
+		statusChecker:           checker,
+		localQueues:             make(map[string]*LocalQueue),
+		clusterQueues:           make(map[string]ClusterQueue),
+		cohorts:                 make(map[string]sets.Set[string]),
+		snapshotsMutex:          sync.RWMutex{},
+		snapshots:               make(map[string][]kueue.ClusterQueuePendingWorkload, 0),
+		workloadInfoOptions:     options.workloadInfoOptions,
+		maxHeadsPerClusterQueue: options.maxHeadsPerClusterQueue,
 	}
 	m.cond.L = &m.RWMutex
 	return m
@@ -175,7 +213,7 @@ func (m *Manager) AddLocalQueue(ctx context.Context, q *kueue.LocalQueue) error
 			continue
 		}
 		workload.AdjustResources(ctx, m.client, &w)
-		qImpl.AddOrUpdate(workload.NewInfo(&w))
+		qImpl.AddOrUpdate(workload.NewInfo(&w, m.workloadInfoOptions...))
 	}
 	cq := m.clusterQueues[qImpl.ClusterQueue]
 	if cq != nil && cq.AddFromLocalQueue(qImpl) {
@@ -232,6 +270,31 @@ func (m *Manager) PendingWorkloads(q *kueue.LocalQueue) (int32, error) {
 	return int32(len(qImpl.items)), nil
 }
 
+// PendingWorkloadsByStatus returns the number of active and inadmissible
+// pending workloads in the LocalQueue. All the pending workloads are
+// inadmissible when the ClusterQueue is not active.
+func (m *Manager) PendingWorkloadsByStatus(q *kueue.LocalQueue) (int, int, error) {
+	m.RLock()
+	defer m.RUnlock()
+
+	qImpl, ok := m.localQueues[Key(q)]
+	if !ok {
+		return 0, 0, errQueueDoesNotExist
+	}
+	cq := m.clusterQueues[qImpl.ClusterQueue]
+	if cq == nil || (m.statusChecker != nil && !m.statusChecker.ClusterQueueActive(qImpl.ClusterQueue)) {
+		return 0, len(qImpl.items), nil
+	}
+	inadmissibleKeys, _ := cq.DumpInadmissible()
+	inadmissible := 0
+	for key := range qImpl.items {
+		if inadmissibleKeys.Has(key) {
+			inadmissible++
+		}
+	}
+	return len(qImpl.items) - inadmissible, inadmissible, nil
+}
+
 func (m *Manager) Pending(cq *kueue.ClusterQueue) int {
 	m.RLock()
 	defer m.RUnlock()
@@ -273,7 +336,7 @@ func (m *Manager) addOrUpdateWorkload(w *kueue.Workload) bool {
 	if q == nil {
 		return false
 	}
-	wInfo := workload.NewInfo(w)
+	wInfo := workload.NewInfo(w, m.workloadInfoOptions...)
 	q.AddOrUpdate(wInfo)
 	cq := m.clusterQueues[q.ClusterQueue]
 	if cq == nil {
@@ -289,6 +352,10 @@ func (m *Manager) addOrUpdateWorkload(w *kueue.Workload) bool {
 // workload still exist in the client cache and not admitted. It won't
 // requeue if the workload is already in the queue (possible if the workload was updated).
 func (m *Manager) RequeueWorkload(ctx context.Context, info *workload.Info, reason RequeueReason) bool {
+	ctx, span := tracing.Tracer().Start(ctx, "Manager.RequeueWorkload", tracing.WithWorkload(info.Obj, info.ClusterQueue)...)
+	defer span.End()
+	span.SetAttributes(attribute.String("kueue.requeue_reason", string(reason)))
+
 	m.Lock()
 	defer m.Unlock()
 
@@ -304,7 +371,16 @@ func (m *Manager) RequeueWorkload(ctx context.Context, info *workload.Info, reas
 	if q == nil {
 		return false
 	}
-	info.Update(&w)
+	if info.ResourceClaimsErr != nil {
+		// The ResourceClaimTemplates might have been created since the
+		// requests were computed.
+		newInfo := workload.NewInfo(&w, m.workloadInfoOptions...)
+		newInfo.ClusterQueue = info.ClusterQueue
+		newInfo.LastAssignment = info.LastAssignment
+		info = newInfo
+	} else {
+		info.Update(&w)
+	}
 	q.AddOrUpdate(info)
 	cq := m.clusterQueues[q.ClusterQueue]
 	if cq == nil {
@@ -369,11 +445,15 @@ func (m *Manager) QueueAssociatedInadmissibleWorkloadsAfter(ctx context.Context,
 // corresponding ClusterQueues to heap. If at least one workload queued,
 // we will broadcast the event.
 func (m *Manager) QueueInadmissibleWorkloads(ctx context.Context, cqNames sets.Set[string]) {
-	m.Lock()
-	defer m.Unlock()
 	if len(cqNames) == 0 {
 		return
 	}
+	ctx, span := tracing.Tracer().Start(ctx, "Manager.QueueInadmissibleWorkloads",
+		trace.WithAttributes(tracing.ClusterQueueKey.StringSlice(sets.List(cqNames))))
+	defer span.End()
+
+	m.Lock()
+	defer m.Unlock()
 
 	var queued bool
 	for name := range cqNames {
@@ -386,6 +466,7 @@ func (m *Manager) QueueInadmissibleWorkloads(ctx context.Context, cqNames sets.S
 		}
 	}
 
+	span.SetAttributes(attribute.Bool("kueue.queued", queued))
 	if queued {
 		m.Broadcast()
 	}
@@ -436,8 +517,13 @@ func (m *Manager) CleanUpOnContext(ctx context.Context) {
 }
 
 // Heads returns the heads of the queues, along with their associated ClusterQueue.
+// Up to maxHeadsPerClusterQueue workloads are returned for each ClusterQueue,
+// in queueing order.
 // It blocks if the queues empty until they have elements or the context terminates.
 func (m *Manager) Heads(ctx context.Context) []workload.Info {
+	_, span := tracing.Tracer().Start(ctx, "Manager.Heads")
+	defer span.End()
+
 	m.Lock()
 	defer m.Unlock()
 	log := ctrl.LoggerFrom(ctx)
@@ -445,17 +531,51 @@ func (m *Manager) Heads(ctx context.Context) []workload.Info {
 		workloads := m.heads()
 		log.V(3).Info("Obtained ClusterQueue heads", "count", len(workloads))
 		if len(workloads) != 0 {
+			span.SetAttributes(tracing.CountKey.Int(len(workloads)))
 			return workloads
 		}
 		select {
 		case <-ctx.Done():
 			return nil
 		default:
+			span.AddEvent("Waiting for workloads")
 			m.cond.Wait()
 		}
 	}
 }
 
+// ClusterQueueDump is the state of the pending workloads of a ClusterQueue.
+type ClusterQueueDump struct {
+	// Active are the keys of the workloads in the heap, in the order they
+	// would be popped.
+	Active []string `json:"active,omitempty"`
+	// Inadmissible are the keys of the workloads waiting for a change in the
+	// cluster to be retried, in queueing order.
+	Inadmissible []string `json:"inadmissible,omitempty"`
+}
+
+// DumpOrdered returns the pending workloads of every ClusterQueue, split
+// between the heap and the inadmissible set.
+func (m *Manager) DumpOrdered() map[string]ClusterQueueDump {
+	m.RLock()
+	defer m.RUnlock()
+	dump := make(map[string]ClusterQueueDump, len(m.clusterQueues))
+	for name, cq := range m.clusterQueues {
+		inadmissible, _ := cq.DumpInadmissible()
+		var cqDump ClusterQueueDump
+		for _, info := range cq.Snapshot() {
+			key := workload.Key(info.Obj)
+			if inadmissible.Has(key) {
+				cqDump.Inadmissible = append(cqDump.Inadmissible, key)
+			} else {
+				cqDump.Active = append(cqDump.Active, key)
+			}
+		}
+		dump[name] = cqDump
+	}
+	return dump
+}
+
 // Dump is a dump of the queues and it's elements (unordered).
 // Only use for testing purposes.
 func (m *Manager) Dump() map[string]sets.Set[string] {
@@ -503,16 +623,21 @@ func (m *Manager) heads() []workload.Info {
 		if m.statusChecker != nil && !m.statusChecker.ClusterQueueActive(cqName) {
 			continue
 		}
-		wl := cq.Pop()
-		if wl == nil {
-			continue
+		popped := 0
+		for ; popped < m.maxHeadsPerClusterQueue; popped++ {
+			wl := cq.Pop()
+			if wl == nil {
+				break
+			}
+			wlCopy := *wl
+			wlCopy.ClusterQueue = cqName
+			workloads = append(workloads, wlCopy)
+			q := m.localQueues[workload.QueueKey(wl.Obj)]
+			delete(q.items, workload.Key(wl.Obj))
+		}
+		if popped > 0 {
+			m.reportPendingWorkloads(cqName, cq)
 		}
-		m.reportPendingWorkloads(cqName, cq)
-		wlCopy := *wl
-		wlCopy.ClusterQueue = cqName
-		workloads = append(workloads, wlCopy)
-		q := m.localQueues[workload.QueueKey(wl.Obj)]
-		delete(q.items, workload.Key(wl.Obj))
 	}
 	return workloads
 }
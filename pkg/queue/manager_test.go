@@ -19,6 +19,7 @@ package queue
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -26,9 +27,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -435,6 +438,71 @@ func TestStatus(t *testing.T) {
 	}
 }
 
+func TestPendingWorkloadsByStatus(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	queues := []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("foo", "").ClusterQueue("cq").Obj(),
+		utiltesting.MakeLocalQueue("bar", "").ClusterQueue("cq").Obj(),
+	}
+	workloads := []*kueue.Workload{
+		utiltesting.MakeWorkload("a", "").Queue("foo").Creation(now).Obj(),
+		utiltesting.MakeWorkload("b", "").Queue("foo").Creation(now.Add(time.Second)).Obj(),
+		utiltesting.MakeWorkload("c", "").Queue("bar").Creation(now.Add(2 * time.Second)).Obj(),
+	}
+	cl := utiltesting.NewFakeClient(workloads[0], workloads[1], workloads[2])
+	manager := NewManager(cl, nil)
+	if err := manager.AddClusterQueue(ctx, utiltesting.MakeClusterQueue("cq").Obj()); err != nil {
+		t.Fatalf("Failed adding clusterQueue: %v", err)
+	}
+	for _, q := range queues {
+		if err := manager.AddLocalQueue(ctx, q); err != nil {
+			t.Fatalf("Failed adding queue %s: %v", q.Name, err)
+		}
+	}
+	for _, wl := range workloads {
+		manager.AddOrUpdateWorkload(wl)
+	}
+	// The head of the ClusterQueue is tried and found inadmissible.
+	heads := manager.Heads(ctx)
+	if len(heads) != 1 {
+		t.Fatalf("Got %d heads, want 1", len(heads))
+	}
+	manager.RequeueWorkload(ctx, &heads[0], RequeueReasonGeneric)
+
+	cases := map[string]struct {
+		queue            *kueue.LocalQueue
+		wantActive       int
+		wantInadmissible int
+		wantErr          error
+	}{
+		"foo": {
+			queue:            queues[0],
+			wantActive:       1,
+			wantInadmissible: 1,
+		},
+		"bar": {
+			queue:      queues[1],
+			wantActive: 1,
+		},
+		"fake": {
+			queue:   utiltesting.MakeLocalQueue("fake", "").ClusterQueue("cq").Obj(),
+			wantErr: errQueueDoesNotExist,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			active, inadmissible, err := manager.PendingWorkloadsByStatus(tc.queue)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("Unexpected error: %v, want %v", err, tc.wantErr)
+			}
+			if active != tc.wantActive || inadmissible != tc.wantInadmissible {
+				t.Errorf("Got %d active and %d inadmissible workloads, want %d and %d", active, inadmissible, tc.wantActive, tc.wantInadmissible)
+			}
+		})
+	}
+}
+
 func TestRequeueWorkloadStrictFIFO(t *testing.T) {
 	cq := utiltesting.MakeClusterQueue("cq").Obj()
 	queues := []*kueue.LocalQueue{
@@ -565,6 +633,28 @@ func TestUpdateWorkload(t *testing.T) {
 				"/foo": sets.New("/a", "/b"),
 			},
 		},
+		"priority increased": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("cq").Obj(),
+			},
+			queues: []*kueue.LocalQueue{
+				utiltesting.MakeLocalQueue("foo", "").ClusterQueue("cq").Obj(),
+			},
+			workloads: []*kueue.Workload{
+				utiltesting.MakeWorkload("a", "").Queue("foo").Creation(now.Add(time.Second)).Obj(),
+				utiltesting.MakeWorkload("b", "").Queue("foo").Creation(now).Obj(),
+			},
+			update: func(w *kueue.Workload) {
+				w.Spec.Priority = ptr.To[int32](100)
+			},
+			wantUpdated: true,
+			wantQueueOrder: map[string][]string{
+				"cq": {"/a", "/b"},
+			},
+			wantQueueMembers: map[string]sets.Set[string]{
+				"/foo": sets.New("/a", "/b"),
+			},
+		},
 		"between queues": {
 			clusterQueues: []*kueue.ClusterQueue{
 				utiltesting.MakeClusterQueue("cq").Obj(),
@@ -802,6 +892,165 @@ func TestHeads(t *testing.T) {
 	}
 }
 
+// TestHeadsWithWorkloadInfoOptions ensures that the requests of the pending
+// workloads are computed with the options of the manager.
+func TestHeadsWithWorkloadInfoOptions(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), headsTimeout)
+	defer cancel()
+	cq := utiltesting.MakeClusterQueue("active-cq").Obj()
+	q := utiltesting.MakeLocalQueue("foo", "").ClusterQueue("active-cq").Obj()
+	listed := utiltesting.MakeWorkload("a", "").Queue("foo").
+		Request(corev1.ResourceCPU, "1").
+		Request("networking.example.com/vpc", "1").
+		Obj()
+	added := utiltesting.MakeWorkload("b", "").Queue("foo").
+		Request("example.com/mig", "2").
+		Obj()
+	cl := utiltesting.NewClientBuilder().WithObjects(listed).Build()
+	manager := NewManager(cl, &fakeStatusChecker{}, WithWorkloadInfoOptions(
+		workload.WithExcludedResourcePrefixes([]string{"networking.example.com/"}),
+		workload.WithResourceTransformations(map[corev1.ResourceName]workload.ResourceTransformation{
+			"example.com/mig": {
+				Outputs: corev1.ResourceList{"example.com/gpu-memory": resource.MustParse("5Gi")},
+			},
+		}),
+	))
+	if err := manager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed adding clusterQueue %s to manager: %v", cq.Name, err)
+	}
+	if err := manager.AddLocalQueue(ctx, q); err != nil {
+		t.Fatalf("Failed adding queue %s: %s", q.Name, err)
+	}
+	go manager.CleanUpOnContext(ctx)
+	manager.AddOrUpdateWorkload(added)
+
+	wantRequests := map[string]workload.Requests{
+		"a": {corev1.ResourceCPU: 1000},
+		"b": {"example.com/mig": 2, "example.com/gpu-memory": 10 * 1024 * 1024 * 1024},
+	}
+	gotRequests := make(map[string]workload.Requests)
+	// The ClusterQueue returns one head at a time.
+	for range wantRequests {
+		for _, h := range manager.Heads(ctx) {
+			gotRequests[h.Obj.Name] = h.TotalRequests[0].Requests
+		}
+	}
+	if diff := cmp.Diff(wantRequests, gotRequests); diff != "" {
+		t.Errorf("Unexpected requests of the heads (-want,+got):\n%s", diff)
+	}
+}
+
+// TestHeadsWithMaxHeadsPerClusterQueue ensures that the manager pops several
+// workloads of each ClusterQueue, in queueing order.
+func TestHeadsWithMaxHeadsPerClusterQueue(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), headsTimeout)
+	defer cancel()
+	now := time.Now().Truncate(time.Second)
+	manager := NewManager(utiltesting.NewFakeClient(), &fakeStatusChecker{}, WithMaxHeadsPerClusterQueue(2))
+	for _, cq := range []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("active-fooCq").QueueingStrategy(kueue.StrictFIFO).Obj(),
+		utiltesting.MakeClusterQueue("active-barCq").Obj(),
+	} {
+		if err := manager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Failed adding clusterQueue %s to manager: %v", cq.Name, err)
+		}
+	}
+	for _, q := range []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("foo", "").ClusterQueue("active-fooCq").Obj(),
+		utiltesting.MakeLocalQueue("bar", "").ClusterQueue("active-barCq").Obj(),
+	} {
+		if err := manager.AddLocalQueue(ctx, q); err != nil {
+			t.Fatalf("Failed adding queue %s: %s", q.Name, err)
+		}
+	}
+	go manager.CleanUpOnContext(ctx)
+	for _, wl := range []*kueue.Workload{
+		utiltesting.MakeWorkload("a3", "").Creation(now.Add(2 * time.Hour)).Queue("foo").Obj(),
+		utiltesting.MakeWorkload("a1", "").Creation(now).Queue("foo").Obj(),
+		utiltesting.MakeWorkload("a2", "").Creation(now.Add(time.Hour)).Queue("foo").Obj(),
+		utiltesting.MakeWorkload("b", "").Creation(now).Queue("bar").Obj(),
+	} {
+		manager.AddOrUpdateWorkload(wl)
+	}
+
+	gotHeads := make(map[string][]string)
+	for _, h := range manager.Heads(ctx) {
+		gotHeads[h.ClusterQueue] = append(gotHeads[h.ClusterQueue], h.Obj.Name)
+	}
+	wantHeads := map[string][]string{
+		"active-fooCq": {"a1", "a2"},
+		"active-barCq": {"b"},
+	}
+	if diff := cmp.Diff(wantHeads, gotHeads); diff != "" {
+		t.Errorf("Unexpected heads in the first call (-want,+got):\n%s", diff)
+	}
+
+	gotHeads = make(map[string][]string)
+	for _, h := range manager.Heads(ctx) {
+		gotHeads[h.ClusterQueue] = append(gotHeads[h.ClusterQueue], h.Obj.Name)
+	}
+	wantHeads = map[string][]string{
+		"active-fooCq": {"a3"},
+	}
+	if diff := cmp.Diff(wantHeads, gotHeads); diff != "" {
+		t.Errorf("Unexpected heads in the second call (-want,+got):\n%s", diff)
+	}
+}
+
+func TestRequeueWorkloadResolvesResourceClaims(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), headsTimeout)
+	defer cancel()
+	cq := utiltesting.MakeClusterQueue("active-cq").Obj()
+	q := utiltesting.MakeLocalQueue("foo", "ns").ClusterQueue("active-cq").Obj()
+	wl := utiltesting.MakeWorkload("a", "ns").Queue("foo").
+		PodSets(*utiltesting.MakePodSet("main", 1).
+			Request(corev1.ResourceCPU, "1").
+			ResourceClaimTemplate("gpu", "single-gpu").
+			Obj()).
+		Obj()
+	cl := utiltesting.NewClientBuilder().WithObjects(wl).Build()
+	templateCreated := false
+	manager := NewManager(cl, &fakeStatusChecker{}, WithWorkloadInfoOptions(
+		workload.WithResourceClaimTemplateResolver(func(_, name string) (corev1.ResourceName, error) {
+			if !templateCreated {
+				return "", fmt.Errorf("ResourceClaimTemplate %s not found", name)
+			}
+			return "example.com/gpu", nil
+		}),
+	))
+	if err := manager.AddClusterQueue(ctx, cq); err != nil {
+		t.Fatalf("Failed adding clusterQueue %s to manager: %v", cq.Name, err)
+	}
+	if err := manager.AddLocalQueue(ctx, q); err != nil {
+		t.Fatalf("Failed adding queue %s: %s", q.Name, err)
+	}
+	go manager.CleanUpOnContext(ctx)
+
+	heads := manager.Heads(ctx)
+	if len(heads) != 1 {
+		t.Fatalf("Got %d heads, want 1", len(heads))
+	}
+	if heads[0].ResourceClaimsErr == nil {
+		t.Errorf("Got no error resolving the ResourceClaimTemplates of the head")
+	}
+
+	templateCreated = true
+	if !manager.RequeueWorkload(ctx, &heads[0], RequeueReasonFailedAfterNomination) {
+		t.Fatalf("Failed requeuing the workload")
+	}
+	heads = manager.Heads(ctx)
+	if len(heads) != 1 {
+		t.Fatalf("Got %d heads after requeuing, want 1", len(heads))
+	}
+	if heads[0].ResourceClaimsErr != nil {
+		t.Errorf("Unexpected error resolving the ResourceClaimTemplates after requeuing: %v", heads[0].ResourceClaimsErr)
+	}
+	wantRequests := workload.Requests{corev1.ResourceCPU: 1000, "example.com/gpu": 1}
+	if diff := cmp.Diff(wantRequests, heads[0].TotalRequests[0].Requests); diff != "" {
+		t.Errorf("Unexpected requests after requeuing (-want,+got):\n%s", diff)
+	}
+}
+
 var ignoreTypeMeta = cmpopts.IgnoreTypes(metav1.TypeMeta{})
 
 // TestHeadAsync ensures that Heads call is blocked until the queues are filled
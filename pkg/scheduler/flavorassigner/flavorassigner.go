@@ -240,18 +240,48 @@ func AssignFlavors(log logr.Logger, wl *workload.Info, resourceFlavors map[kueue
 		wl.LastAssignment = nil
 	}
 
-	if len(counts) == 0 {
-		return assignFlavors(log, wl.TotalRequests, wl.Obj.Spec.PodSets, resourceFlavors, cq, wl.LastAssignment)
+	if wl.ResourceClaimsErr != nil {
+		return unresolvedClaimsAssignment(wl)
 	}
 
-	currentResources := make([]workload.PodSetResources, len(wl.TotalRequests))
-	for i := range wl.TotalRequests {
-		currentResources[i] = *wl.TotalRequests[i].ScaledTo(counts[i])
+	currentResources := wl.TotalRequests
+	if len(counts) != 0 {
+		currentResources = make([]workload.PodSetResources, len(wl.TotalRequests))
+		for i := range wl.TotalRequests {
+			currentResources[i] = *wl.TotalRequests[i].ScaledTo(counts[i])
+		}
+	}
+	if len(wl.Obj.Spec.PodSetFlavorGroups) > 0 {
+		return assignFlavorsToGroups(log, currentResources, wl.Obj.Spec.PodSets, wl.Obj.Spec.PodSetFlavorGroups, resourceFlavors, cq, wl.LastAssignment)
 	}
-	return assignFlavors(log, currentResources, wl.Obj.Spec.PodSets, resourceFlavors, cq, wl.LastAssignment)
+	return assignFlavors(log, currentResources, wl.Obj.Spec.PodSets, resourceFlavors, cq, wl.LastAssignment, nil)
 }
 
-func assignFlavors(log logr.Logger, requests []workload.PodSetResources, podSets []kueue.PodSet, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, cq *cache.ClusterQueue, lastAssignment *workload.AssigmentClusterQueueState) Assignment {
+// unresolvedClaimsAssignment is the failed assignment of a workload whose
+// requests are incomplete because its ResourceClaimTemplates couldn't be
+// resolved.
+func unresolvedClaimsAssignment(wl *workload.Info) Assignment {
+	assignment := Assignment{
+		Usage: make(cache.FlavorResourceQuantities),
+	}
+	for _, podSet := range wl.TotalRequests {
+		if podSet.Name != wl.ResourceClaimsErr.PodSet {
+			continue
+		}
+		assignment.PodSets = append(assignment.PodSets, PodSetAssignment{
+			Name:     podSet.Name,
+			Requests: podSet.Requests.ToResourceList(),
+			Count:    podSet.Count,
+			Status:   &Status{err: wl.ResourceClaimsErr},
+		})
+	}
+	return assignment
+}
+
+// assignFlavors assigns flavors to the pod sets in order. If filters is not
+// nil, filters[i] restricts the flavors that can be assigned to the i-th pod
+// set.
+func assignFlavors(log logr.Logger, requests []workload.PodSetResources, podSets []kueue.PodSet, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, cq *cache.ClusterQueue, lastAssignment *workload.AssigmentClusterQueueState, filters []flavorFilter) Assignment {
 	assignment := Assignment{
 		TotalBorrow: make(cache.FlavorResourceQuantities),
 		PodSets:     make([]PodSetAssignment, 0, len(requests)),
@@ -271,9 +301,7 @@ func assignFlavors(log logr.Logger, requests []workload.PodSetResources, podSets
 	}
 
 	for i, podSet := range requests {
-		if _, found := cq.RGByResource[corev1.ResourcePods]; found {
-			podSet.Requests[corev1.ResourcePods] = int64(podSet.Count)
-		}
+		cq.AddCountedResources(i, &podSet)
 
 		psAssignment := PodSetAssignment{
 			Name:     podSet.Name,
@@ -303,7 +331,11 @@ func assignFlavors(log logr.Logger, requests []workload.PodSetResources, podSets
 					lastFlavorAssignment = idx
 				}
 			}
-			flavors, status := assignment.findFlavorForResourceGroup(log, rg, podSet.Requests, resourceFlavors, cq, &podSets[i].Template.Spec, lastFlavorAssignment)
+			var filter flavorFilter
+			if filters != nil {
+				filter = filters[i]
+			}
+			flavors, status := assignment.findFlavorForResourceGroup(log, rg, podSet.Requests, resourceFlavors, cq, &podSets[i].Template.Spec, filter, lastFlavorAssignment)
 			if status.IsError() || len(flavors) == 0 {
 				psAssignment.Flavors = nil
 				psAssignment.Status = status
@@ -368,6 +400,7 @@ func (a *Assignment) findFlavorForResourceGroup(
 	resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor,
 	cq *cache.ClusterQueue,
 	spec *corev1.PodSpec,
+	filter flavorFilter,
 	lastAssignment int) (ResourceAssignment, *Status) {
 	status := &Status{}
 	requests = filterRequestedResources(requests, rg.CoveredResources)
@@ -403,6 +436,12 @@ func (a *Assignment) findFlavorForResourceGroup(
 			status.append(fmt.Sprintf("flavor %s doesn't match node affinity", flvQuotas.Name))
 			continue
 		}
+		if filter != nil {
+			if reason := filter(rg, flavor); reason != "" {
+				status.append(reason)
+				continue
+			}
+		}
 
 		flavorIdx = idx
 		needsBorrowing := false
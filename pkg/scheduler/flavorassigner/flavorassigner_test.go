@@ -48,6 +48,10 @@ func TestAssignFlavors(t *testing.T) {
 		"two":   utiltesting.MakeResourceFlavor("two").Label("type", "two").Obj(),
 		"b_one": utiltesting.MakeResourceFlavor("b_one").Label("b_type", "one").Obj(),
 		"b_two": utiltesting.MakeResourceFlavor("b_two").Label("b_type", "two").Obj(),
+		"a_cpu": utiltesting.MakeResourceFlavor("a_cpu").Label("zone", "a").Obj(),
+		"b_cpu": utiltesting.MakeResourceFlavor("b_cpu").Label("zone", "b").Obj(),
+		"a_gpu": utiltesting.MakeResourceFlavor("a_gpu").Label("zone", "a").Obj(),
+		"b_gpu": utiltesting.MakeResourceFlavor("b_gpu").Label("zone", "b").Obj(),
 		"tainted": utiltesting.MakeResourceFlavor("tainted").
 			Taint(corev1.Taint{
 				Key:    "instance",
@@ -59,6 +63,8 @@ func TestAssignFlavors(t *testing.T) {
 	cases := map[string]struct {
 		wlPods            []kueue.PodSet
 		wlReclaimablePods []kueue.ReclaimablePod
+		wlFlavorGroups    []kueue.PodSetFlavorGroup
+		wlInfoOptions     []workload.InfoOption
 		clusterQueue      cache.ClusterQueue
 		wantRepMode       FlavorAssignmentMode
 		wantAssignment    Assignment
@@ -1847,6 +1853,440 @@ func TestAssignFlavors(t *testing.T) {
 				Usage: cache.FlavorResourceQuantities{"one": {"cpu": 9000, "pods": 1}},
 			},
 		},
+		"flavor group, pod sets get the same flavor": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("launcher", 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakePodSet("worker", 1).
+					Request(corev1.ResourceCPU, "4").
+					Obj(),
+			},
+			wlFlavorGroups: []kueue.PodSetFlavorGroup{{
+				PodSets: []string{"launcher", "worker"},
+			}},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU),
+					Flavors: []cache.FlavorQuotas{
+						{
+							Name: "one",
+							Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+								corev1.ResourceCPU: {Nominal: 2000},
+							},
+						},
+						{
+							Name: "two",
+							Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+								corev1.ResourceCPU: {Nominal: 10000},
+							},
+						},
+					},
+				}},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{
+					{
+						Name: "launcher",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "two", Mode: Fit},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1000m"),
+						},
+						Count: 1,
+					},
+					{
+						Name: "worker",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "two", Mode: Fit},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("4000m"),
+						},
+						Count: 1,
+					},
+				},
+				Usage: cache.FlavorResourceQuantities{
+					"two": {corev1.ResourceCPU: 5000},
+				},
+			},
+		},
+		"flavor group, pod sets get flavors with the same node label value": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("launcher", 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakePodSet("worker", 2).
+					Request(corev1.ResourceCPU, "1").
+					Request("example.com/gpu", "1").
+					Obj(),
+			},
+			wlFlavorGroups: []kueue.PodSetFlavorGroup{{
+				PodSets:   []string{"launcher", "worker"},
+				NodeLabel: "zone",
+			}},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{
+					{
+						CoveredResources: sets.New(corev1.ResourceCPU),
+						Flavors: []cache.FlavorQuotas{
+							{
+								Name: "a_cpu",
+								Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+									corev1.ResourceCPU: {Nominal: 10000},
+								},
+							},
+							{
+								Name: "b_cpu",
+								Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+									corev1.ResourceCPU: {Nominal: 10000},
+								},
+							},
+						},
+					},
+					{
+						CoveredResources: sets.New[corev1.ResourceName]("example.com/gpu"),
+						Flavors: []cache.FlavorQuotas{
+							{
+								Name: "a_gpu",
+								Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+									"example.com/gpu": {Nominal: 1},
+								},
+							},
+							{
+								Name: "b_gpu",
+								Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+									"example.com/gpu": {Nominal: 4},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{
+					{
+						Name: "launcher",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "b_cpu", Mode: Fit},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1000m"),
+						},
+						Count: 1,
+					},
+					{
+						Name: "worker",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "b_cpu", Mode: Fit},
+							"example.com/gpu":  {Name: "b_gpu", Mode: Fit},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("2000m"),
+							"example.com/gpu":  resource.MustParse("2"),
+						},
+						Count: 2,
+					},
+				},
+				Usage: cache.FlavorResourceQuantities{
+					"b_cpu": {corev1.ResourceCPU: 3000},
+					"b_gpu": {"example.com/gpu": 2},
+				},
+			},
+		},
+		"flavor group, no flavor has the node label": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("launcher", 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakePodSet("worker", 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakePodSet("monitor", 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			wlFlavorGroups: []kueue.PodSetFlavorGroup{{
+				PodSets:   []string{"launcher", "worker"},
+				NodeLabel: "rack",
+			}},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "a_cpu",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 10000},
+						},
+					}},
+				}},
+			},
+			wantRepMode: NoFit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{
+					{
+						Name: "launcher",
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1000m"),
+						},
+						Status: &Status{
+							reasons: []string{"no flavor has the node label rack required by the PodSet flavor group [launcher, worker]"},
+						},
+						Count: 1,
+					},
+					{
+						Name: "worker",
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1000m"),
+						},
+						Status: &Status{
+							reasons: []string{"no flavor has the node label rack required by the PodSet flavor group [launcher, worker]"},
+						},
+						Count: 1,
+					},
+					{
+						Name: "monitor",
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("1000m"),
+						},
+						Status: &Status{
+							reasons: []string{"the PodSet flavor group [launcher, worker] can't be satisfied"},
+						},
+						Count: 1,
+					},
+				},
+				Usage: cache.FlavorResourceQuantities{},
+			},
+		},
+		"flavor group, pod sets only fit in different flavors": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("launcher", 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+				*utiltesting.MakePodSet("worker", 1).
+					Request(corev1.ResourceCPU, "3").
+					Obj(),
+			},
+			wlFlavorGroups: []kueue.PodSetFlavorGroup{{
+				PodSets: []string{"launcher", "worker"},
+			}},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New(corev1.ResourceCPU),
+					Flavors: []cache.FlavorQuotas{
+						{
+							Name: "one",
+							Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+								corev1.ResourceCPU: {Nominal: 4000},
+							},
+						},
+						{
+							Name: "two",
+							Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+								corev1.ResourceCPU: {Nominal: 4000},
+							},
+						},
+					},
+				}},
+			},
+			wantRepMode: NoFit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{
+					{
+						Name: "launcher",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU: {Name: "one", Mode: Fit},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("3000m"),
+						},
+						Count: 1,
+					},
+					{
+						Name: "worker",
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("3000m"),
+						},
+						Status: &Status{
+							reasons: []string{
+								"flavor two doesn't match flavor one of its PodSet flavor group",
+								"insufficient quota for cpu in flavor one in ClusterQueue",
+							},
+						},
+						Count: 1,
+					},
+				},
+				Usage: cache.FlavorResourceQuantities{
+					"one": {corev1.ResourceCPU: 3000},
+				},
+			},
+		},
+		"counted resources": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("driver", 1).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+				*utiltesting.MakePodSet("workers", 3).
+					Request(corev1.ResourceCPU, "1").
+					Obj(),
+			},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{
+					{
+						CoveredResources: sets.New(corev1.ResourceCPU),
+						Flavors: []cache.FlavorQuotas{{
+							Name: "default",
+							Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+								corev1.ResourceCPU: {Nominal: 4000},
+							},
+						}},
+					},
+					{
+						CoveredResources: sets.New(corev1.ResourcePods, kueue.ResourceWorkloads),
+						Flavors: []cache.FlavorQuotas{{
+							Name: "default",
+							Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+								corev1.ResourcePods:     {Nominal: 4},
+								kueue.ResourceWorkloads: {Nominal: 1},
+							},
+						}},
+					},
+				},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{
+					{
+						Name: "driver",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU:      {Name: "default", Mode: Fit},
+							corev1.ResourcePods:     {Name: "default", Mode: Fit},
+							kueue.ResourceWorkloads: {Name: "default", Mode: Fit},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:      resource.MustParse("1000m"),
+							corev1.ResourcePods:     resource.MustParse("1"),
+							kueue.ResourceWorkloads: resource.MustParse("1"),
+						},
+						Count: 1,
+					},
+					{
+						Name: "workers",
+						Flavors: ResourceAssignment{
+							corev1.ResourceCPU:  {Name: "default", Mode: Fit},
+							corev1.ResourcePods: {Name: "default", Mode: Fit},
+						},
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:  resource.MustParse("3000m"),
+							corev1.ResourcePods: resource.MustParse("3"),
+						},
+						Count: 3,
+					},
+				},
+				Usage: cache.FlavorResourceQuantities{
+					"default": {
+						corev1.ResourceCPU:      4000,
+						corev1.ResourcePods:     4,
+						kueue.ResourceWorkloads: 1,
+					},
+				},
+			},
+		},
+		"transformed and excluded resources": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 2).
+					Request(corev1.ResourceCPU, "1").
+					Request("example.com/mig", "2").
+					Request("networking.example.com/vpc", "1").
+					Obj(),
+			},
+			wlInfoOptions: []workload.InfoOption{
+				workload.WithExcludedResourcePrefixes([]string{"networking.example.com/"}),
+				workload.WithResourceTransformations(map[corev1.ResourceName]workload.ResourceTransformation{
+					"example.com/mig": {
+						Outputs: corev1.ResourceList{"example.com/gpu-memory": resource.MustParse("5")},
+						Replace: true,
+					},
+				}),
+			},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New[corev1.ResourceName](corev1.ResourceCPU, "example.com/gpu-memory"),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "default",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU:       {Nominal: 2000},
+							"example.com/gpu-memory": {Nominal: 20},
+						},
+					}},
+				}},
+			},
+			wantRepMode: Fit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: "main",
+					Flavors: ResourceAssignment{
+						corev1.ResourceCPU:       {Name: "default", Mode: Fit},
+						"example.com/gpu-memory": {Name: "default", Mode: Fit},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:       resource.MustParse("2000m"),
+						"example.com/gpu-memory": resource.MustParse("20"),
+					},
+					Count: 2,
+				}},
+				Usage: cache.FlavorResourceQuantities{
+					"default": {
+						corev1.ResourceCPU:       2000,
+						"example.com/gpu-memory": 20,
+					},
+				},
+			},
+		},
+		"resource claims": {
+			wlPods: []kueue.PodSet{
+				*utiltesting.MakePodSet("main", 2).
+					Request(corev1.ResourceCPU, "1").
+					ResourceClaimTemplate("gpu-0", "single-gpu").
+					ResourceClaimTemplate("gpu-1", "single-gpu").
+					Obj(),
+			},
+			wlInfoOptions: []workload.InfoOption{
+				workload.WithResourceClaimTemplateResolver(func(_, name string) (corev1.ResourceName, error) {
+					return "example.com/gpu", nil
+				}),
+			},
+			clusterQueue: cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{{
+					CoveredResources: sets.New[corev1.ResourceName](corev1.ResourceCPU, "example.com/gpu"),
+					Flavors: []cache.FlavorQuotas{{
+						Name: "default",
+						Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+							corev1.ResourceCPU: {Nominal: 2000},
+							"example.com/gpu":  {Nominal: 2},
+						},
+					}},
+				}},
+			},
+			wantRepMode: NoFit,
+			wantAssignment: Assignment{
+				PodSets: []PodSetAssignment{{
+					Name: "main",
+					Status: &Status{
+						reasons: []string{"insufficient quota for example.com/gpu in flavor default in ClusterQueue"},
+					},
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU: resource.MustParse("2000m"),
+						"example.com/gpu":  resource.MustParse("4"),
+					},
+					Count: 2,
+				}},
+				Usage: cache.FlavorResourceQuantities{},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -1855,12 +2295,13 @@ func TestAssignFlavors(t *testing.T) {
 			})
 			wlInfo := workload.NewInfo(&kueue.Workload{
 				Spec: kueue.WorkloadSpec{
-					PodSets: tc.wlPods,
+					PodSets:            tc.wlPods,
+					PodSetFlavorGroups: tc.wlFlavorGroups,
 				},
 				Status: kueue.WorkloadStatus{
 					ReclaimablePods: tc.wlReclaimablePods,
 				},
-			})
+			}, tc.wlInfoOptions...)
 			if tc.clusterQueue.FlavorFungibility.WhenCanBorrow == "" {
 				tc.clusterQueue.FlavorFungibility.WhenCanBorrow = kueue.Borrow
 			}
@@ -1881,6 +2322,66 @@ func TestAssignFlavors(t *testing.T) {
 	}
 }
 
+func TestAssignFlavorsUnresolvedResourceClaims(t *testing.T) {
+	log := testr.NewWithOptions(t, testr.Options{
+		Verbosity: 2,
+	})
+	wlInfo := workload.NewInfo(utiltesting.MakeWorkload("wl", "ns").
+		PodSets(
+			*utiltesting.MakePodSet("driver", 1).
+				Request(corev1.ResourceCPU, "1").
+				Obj(),
+			*utiltesting.MakePodSet("workers", 2).
+				Request(corev1.ResourceCPU, "1").
+				ResourceClaimTemplate("gpu", "missing").
+				Obj(),
+		).
+		Obj(),
+		workload.WithResourceClaimTemplateResolver(func(_, name string) (corev1.ResourceName, error) {
+			return "", fmt.Errorf("ResourceClaimTemplate %s not found", name)
+		}),
+	)
+	cq := cache.ClusterQueue{
+		ResourceGroups: []cache.ResourceGroup{{
+			CoveredResources: sets.New[corev1.ResourceName](corev1.ResourceCPU),
+			Flavors: []cache.FlavorQuotas{{
+				Name: "default",
+				Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+					corev1.ResourceCPU: {Nominal: 4000},
+				},
+			}},
+		}},
+	}
+	resourceFlavors := map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor{
+		"default": utiltesting.MakeResourceFlavor("default").Obj(),
+	}
+	cq.UpdateWithFlavors(resourceFlavors)
+	cq.UpdateRGByResource()
+
+	assignment := AssignFlavors(log, wlInfo, resourceFlavors, &cq, nil)
+	if repMode := assignment.RepresentativeMode(); repMode != NoFit {
+		t.Errorf("AssignFlavors(_).RepresentativeMode()=%s, want %s", repMode, NoFit)
+	}
+	wantAssignment := Assignment{
+		PodSets: []PodSetAssignment{{
+			Name:   "workers",
+			Status: &Status{err: wlInfo.ResourceClaimsErr},
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("2000m"),
+			},
+			Count: 2,
+		}},
+		Usage: cache.FlavorResourceQuantities{},
+	}
+	if diff := cmp.Diff(wantAssignment, assignment, cmpopts.IgnoreUnexported(Assignment{})); diff != "" {
+		t.Errorf("Unexpected assignment (-want,+got):\n%s", diff)
+	}
+	wantMessage := `failed to assign flavors to pod set workers: resolving ResourceClaimTemplate "missing": ResourceClaimTemplate missing not found`
+	if msg := assignment.Message(); msg != wantMessage {
+		t.Errorf("AssignFlavors(_).Message()=%q, want %q", msg, wantMessage)
+	}
+}
+
 func TestLastAssignmentOutdated(t *testing.T) {
 	type args struct {
 		wl *workload.Info
@@ -0,0 +1,289 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flavorassigner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+// maxFlavorGroupCandidates is the maximum number of flavor combinations
+// evaluated for the PodSet flavor groups of a workload in a single call to
+// AssignFlavors. When the search is truncated and no combination fits, the
+// status of the PodSets says so.
+const maxFlavorGroupCandidates = 64
+
+// flavorFilter restricts the flavors that can be assigned to a PodSet in a
+// resource group. It returns an empty string if the flavor can be assigned,
+// or the reason why it can't.
+type flavorFilter func(rg *cache.ResourceGroup, flavor *kueue.ResourceFlavor) string
+
+// groupOption is one of the ways in which the PodSets of a group can be
+// assigned consistent flavors.
+type groupOption struct {
+	podSets []int
+	filter  flavorFilter
+}
+
+// assignFlavorsToGroups searches the combinations of flavors that keep the
+// PodSets of each flavor group consistent, in the order of the flavors in the
+// ClusterQueue, and returns the first assignment that fits or, if none fits,
+// the one with the best representative mode.
+func assignFlavorsToGroups(log logr.Logger, requests []workload.PodSetResources, podSets []kueue.PodSet, groups []kueue.PodSetFlavorGroup, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, cq *cache.ClusterQueue, lastAssignment *workload.AssigmentClusterQueueState) Assignment {
+	var optionsPerGroup [][]groupOption
+	truncated := false
+	for _, g := range groups {
+		idx := podSetIndexes(g.PodSets, requests)
+		if len(idx) < 2 {
+			continue
+		}
+		var options []groupOption
+		if g.NodeLabel != "" {
+			options = nodeLabelOptions(g.NodeLabel, idx, requests, resourceFlavors, cq)
+		} else {
+			var optionsTruncated bool
+			options, optionsTruncated = sameFlavorOptions(idx, requests, cq)
+			truncated = truncated || optionsTruncated
+		}
+		if options == nil {
+			// The group doesn't constrain the assignment.
+			continue
+		}
+		if len(options) == 0 {
+			return unsatisfiableGroupAssignment(requests, idx, g)
+		}
+		optionsPerGroup = append(optionsPerGroup, options)
+	}
+	if len(optionsPerGroup) == 0 {
+		return assignFlavors(log, requests, podSets, resourceFlavors, cq, lastAssignment, nil)
+	}
+
+	candidates, candidatesTruncated := candidateFilters(optionsPerGroup, len(requests))
+	truncated = truncated || candidatesTruncated
+	var best Assignment
+	for i, filters := range candidates {
+		var last *workload.AssigmentClusterQueueState
+		if lastAssignment != nil {
+			last = lastAssignment.Clone()
+		}
+		assignment := assignFlavors(log, requests, podSets, resourceFlavors, cq, last, filters)
+		mode := assignment.RepresentativeMode()
+		if i == 0 || mode > best.RepresentativeMode() {
+			best = assignment
+		}
+		if mode == Fit {
+			return best
+		}
+	}
+	if truncated {
+		reason := fmt.Sprintf("only the first %d combinations of flavors of the PodSet flavor groups were evaluated", maxFlavorGroupCandidates)
+		for i := range best.PodSets {
+			if ps := &best.PodSets[i]; ps.Status != nil && !ps.Status.IsError() {
+				ps.Status.append(reason)
+			}
+		}
+	}
+	return best
+}
+
+// candidateFilters returns the per-PodSet filters for the combinations of the
+// options of every group, up to maxFlavorGroupCandidates, and whether some
+// combinations were left out.
+func candidateFilters(optionsPerGroup [][]groupOption, podSetsCount int) ([][]flavorFilter, bool) {
+	var candidates [][]flavorFilter
+	choice := make([]int, len(optionsPerGroup))
+	for {
+		if len(candidates) == maxFlavorGroupCandidates {
+			return candidates, true
+		}
+		filters := make([]flavorFilter, podSetsCount)
+		for g, options := range optionsPerGroup {
+			opt := options[choice[g]]
+			for _, psIdx := range opt.podSets {
+				filters[psIdx] = opt.filter
+			}
+		}
+		candidates = append(candidates, filters)
+
+		// Advance to the next combination, the last group changing the fastest.
+		g := len(choice) - 1
+		for ; g >= 0; g-- {
+			choice[g]++
+			if choice[g] < len(optionsPerGroup[g]) {
+				break
+			}
+			choice[g] = 0
+		}
+		if g < 0 {
+			return candidates, false
+		}
+	}
+}
+
+// nodeLabelOptions returns one option per value of the node label among the
+// flavors of the resource groups used by the PodSets, in order of appearance.
+func nodeLabelOptions(key string, podSetIdx []int, requests []workload.PodSetResources, resourceFlavors map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, cq *cache.ClusterQueue) []groupOption {
+	used := usedResourceGroups(podSetIdx, requests, cq)
+	options := []groupOption{}
+	seen := sets.New[string]()
+	for i := range cq.ResourceGroups {
+		rg := &cq.ResourceGroups[i]
+		if !used.Has(rg) {
+			continue
+		}
+		for _, flvQuotas := range rg.Flavors {
+			flavor, found := resourceFlavors[flvQuotas.Name]
+			if !found {
+				continue
+			}
+			value, found := flavor.Spec.NodeLabels[key]
+			if !found || seen.Has(value) {
+				continue
+			}
+			seen.Insert(value)
+			options = append(options, groupOption{
+				podSets: podSetIdx,
+				filter: func(_ *cache.ResourceGroup, f *kueue.ResourceFlavor) string {
+					if v, found := f.Spec.NodeLabels[key]; !found || v != value {
+						return fmt.Sprintf("flavor %s doesn't match %s=%s of its PodSet flavor group", f.Name, key, value)
+					}
+					return ""
+				},
+			})
+		}
+	}
+	return options
+}
+
+// sameFlavorOptions returns one option per combination of flavors of the
+// resource groups that are used by more than one of the PodSets, up to
+// maxFlavorGroupCandidates, and whether some combinations were left out.
+// It returns nil options if no resource group is shared.
+func sameFlavorOptions(podSetIdx []int, requests []workload.PodSetResources, cq *cache.ClusterQueue) ([]groupOption, bool) {
+	usage := make(map[*cache.ResourceGroup]int)
+	for _, i := range podSetIdx {
+		for rg := range usedResourceGroups([]int{i}, requests, cq) {
+			usage[rg]++
+		}
+	}
+	var shared []*cache.ResourceGroup
+	for i := range cq.ResourceGroups {
+		rg := &cq.ResourceGroups[i]
+		if usage[rg] > 1 && len(rg.Flavors) > 0 {
+			shared = append(shared, rg)
+		}
+	}
+	if len(shared) == 0 {
+		return nil, false
+	}
+
+	var options []groupOption
+	choice := make([]int, len(shared))
+	for {
+		if len(options) == maxFlavorGroupCandidates {
+			return options, true
+		}
+		pinned := make(map[*cache.ResourceGroup]kueue.ResourceFlavorReference, len(shared))
+		for i, rg := range shared {
+			pinned[rg] = rg.Flavors[choice[i]].Name
+		}
+		options = append(options, groupOption{
+			podSets: podSetIdx,
+			filter: func(rg *cache.ResourceGroup, f *kueue.ResourceFlavor) string {
+				if want, found := pinned[rg]; found && kueue.ResourceFlavorReference(f.Name) != want {
+					return fmt.Sprintf("flavor %s doesn't match flavor %s of its PodSet flavor group", f.Name, want)
+				}
+				return ""
+			},
+		})
+
+		i := len(choice) - 1
+		for ; i >= 0; i-- {
+			choice[i]++
+			if choice[i] < len(shared[i].Flavors) {
+				break
+			}
+			choice[i] = 0
+		}
+		if i < 0 {
+			return options, false
+		}
+	}
+}
+
+func usedResourceGroups(podSetIdx []int, requests []workload.PodSetResources, cq *cache.ClusterQueue) sets.Set[*cache.ResourceGroup] {
+	used := sets.New[*cache.ResourceGroup]()
+	for _, i := range podSetIdx {
+		for rName := range requests[i].Requests {
+			if rg, found := cq.RGByResource[rName]; found {
+				used.Insert(rg)
+			}
+		}
+		// The number of pods and the workload are added to the requests during
+		// the assignment.
+		if rg, found := cq.RGByResource[corev1.ResourcePods]; found {
+			used.Insert(rg)
+		}
+		if rg, found := cq.RGByResource[kueue.ResourceWorkloads]; found && i == 0 {
+			used.Insert(rg)
+		}
+	}
+	return used
+}
+
+func podSetIndexes(names []string, requests []workload.PodSetResources) []int {
+	wanted := sets.New(names...)
+	var idx []int
+	for i := range requests {
+		if wanted.Has(requests[i].Name) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// unsatisfiableGroupAssignment returns an assignment in which none of the
+// PodSets of the workload fit, because no flavor can satisfy the group.
+func unsatisfiableGroupAssignment(requests []workload.PodSetResources, groupIdx []int, group kueue.PodSetFlavorGroup) Assignment {
+	inGroup := sets.New(groupIdx...)
+	groupNames := strings.Join(group.PodSets, ", ")
+	assignment := Assignment{
+		PodSets: make([]PodSetAssignment, len(requests)),
+		Usage:   make(cache.FlavorResourceQuantities),
+	}
+	for i := range requests {
+		reason := fmt.Sprintf("no flavor has the node label %s required by the PodSet flavor group [%s]", group.NodeLabel, groupNames)
+		if !inGroup.Has(i) {
+			reason = fmt.Sprintf("the PodSet flavor group [%s] can't be satisfied", groupNames)
+		}
+		assignment.PodSets[i] = PodSetAssignment{
+			Name:     requests[i].Name,
+			Requests: requests[i].Requests.ToResourceList(),
+			Count:    requests[i].Count,
+			Status:   &Status{reasons: []string{reason}},
+		}
+	}
+	return assignment
+}
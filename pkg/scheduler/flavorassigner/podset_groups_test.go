@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flavorassigner
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+	"sigs.k8s.io/kueue/pkg/workload"
+)
+
+func TestCandidateFilters(t *testing.T) {
+	cases := map[string]struct {
+		optionsPerGroup []int
+		wantCandidates  int
+		wantTruncated   bool
+	}{
+		"single group": {
+			optionsPerGroup: []int{3},
+			wantCandidates:  3,
+		},
+		"all the combinations": {
+			optionsPerGroup: []int{8, 8},
+			wantCandidates:  64,
+		},
+		"too many combinations": {
+			optionsPerGroup: []int{8, 9},
+			wantCandidates:  maxFlavorGroupCandidates,
+			wantTruncated:   true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			optionsPerGroup := make([][]groupOption, len(tc.optionsPerGroup))
+			for g, count := range tc.optionsPerGroup {
+				optionsPerGroup[g] = make([]groupOption, count)
+				for i := range optionsPerGroup[g] {
+					optionsPerGroup[g][i] = groupOption{podSets: []int{g}}
+				}
+			}
+			candidates, truncated := candidateFilters(optionsPerGroup, len(tc.optionsPerGroup))
+			if len(candidates) != tc.wantCandidates {
+				t.Errorf("candidateFilters() returned %d candidates, want %d", len(candidates), tc.wantCandidates)
+			}
+			if truncated != tc.wantTruncated {
+				t.Errorf("candidateFilters() truncated=%t, want %t", truncated, tc.wantTruncated)
+			}
+		})
+	}
+}
+
+func TestAssignFlavorsToGroupsTruncated(t *testing.T) {
+	truncatedReason := fmt.Sprintf("only the first %d combinations of flavors of the PodSet flavor groups were evaluated", maxFlavorGroupCandidates)
+	cases := map[string]struct {
+		flavors       int
+		wantTruncated bool
+	}{
+		"all the flavors are evaluated": {
+			flavors: maxFlavorGroupCandidates,
+		},
+		"the search is truncated": {
+			flavors:       maxFlavorGroupCandidates + 1,
+			wantTruncated: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			log := testr.NewWithOptions(t, testr.Options{Verbosity: 2})
+			// The launcher and the worker don't fit together in any flavor.
+			wlInfo := workload.NewInfo(&kueue.Workload{
+				Spec: kueue.WorkloadSpec{
+					PodSets: []kueue.PodSet{
+						*utiltesting.MakePodSet("launcher", 1).Request(corev1.ResourceCPU, "1").Obj(),
+						*utiltesting.MakePodSet("worker", 1).Request(corev1.ResourceCPU, "1").Obj(),
+					},
+					PodSetFlavorGroups: []kueue.PodSetFlavorGroup{{
+						PodSets: []string{"launcher", "worker"},
+					}},
+				},
+			})
+			resourceFlavors := make(map[kueue.ResourceFlavorReference]*kueue.ResourceFlavor, tc.flavors)
+			rg := cache.ResourceGroup{CoveredResources: sets.New(corev1.ResourceCPU)}
+			for i := 0; i < tc.flavors; i++ {
+				name := kueue.ResourceFlavorReference(fmt.Sprintf("flavor-%d", i))
+				resourceFlavors[name] = utiltesting.MakeResourceFlavor(string(name)).Obj()
+				rg.Flavors = append(rg.Flavors, cache.FlavorQuotas{
+					Name: name,
+					Resources: map[corev1.ResourceName]*cache.ResourceQuota{
+						corev1.ResourceCPU: {Nominal: 1000},
+					},
+				})
+			}
+			cq := cache.ClusterQueue{
+				ResourceGroups: []cache.ResourceGroup{rg},
+				FlavorFungibility: kueue.FlavorFungibility{
+					WhenCanBorrow:  kueue.Borrow,
+					WhenCanPreempt: kueue.TryNextFlavor,
+				},
+			}
+			cq.UpdateWithFlavors(resourceFlavors)
+			cq.UpdateRGByResource()
+
+			assignment := AssignFlavors(log, wlInfo, resourceFlavors, &cq, nil)
+			if repMode := assignment.RepresentativeMode(); repMode != NoFit {
+				t.Errorf("AssignFlavors(_).RepresentativeMode()=%s, want %s", repMode, NoFit)
+			}
+			if gotTruncated := strings.Contains(assignment.Message(), truncatedReason); gotTruncated != tc.wantTruncated {
+				t.Errorf("AssignFlavors(_).Message()=%q, want truncated=%t", assignment.Message(), tc.wantTruncated)
+			}
+		})
+	}
+}
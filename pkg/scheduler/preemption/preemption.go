@@ -34,6 +34,7 @@ import (
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/metrics"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
 	"sigs.k8s.io/kueue/pkg/util/priority"
 	"sigs.k8s.io/kueue/pkg/util/routine"
@@ -75,6 +76,9 @@ func candidatesOnlyFromQueue(candidates []*workload.Info, clusterQueue string) [
 
 // GetTargets returns the list of workloads that should be evicted in order to make room for wl.
 func (p *Preemptor) GetTargets(wl workload.Info, assignment flavorassigner.Assignment, snapshot *cache.Snapshot) []*workload.Info {
+	if !priority.CanPreempt(wl.Obj) {
+		return nil
+	}
 	resPerFlv := resourcesRequiringPreemption(assignment)
 	cq := snapshot.ClusterQueues[wl.ClusterQueue]
 
@@ -112,8 +116,9 @@ func (p *Preemptor) GetTargets(wl workload.Info, assignment flavorassigner.Assig
 	return targets
 }
 
-// IssuePreemptions marks the target workloads as evicted.
-func (p *Preemptor) IssuePreemptions(ctx context.Context, targets []*workload.Info, cq *cache.ClusterQueue) (int, error) {
+// IssuePreemptions marks the target workloads as evicted, recording the
+// preemptor in their admission history.
+func (p *Preemptor) IssuePreemptions(ctx context.Context, preemptor *workload.Info, targets []*workload.Info, cq *cache.ClusterQueue) (int, error) {
 	log := ctrl.LoggerFrom(ctx)
 	errCh := routine.NewErrorChannel()
 	ctx, cancel := context.WithCancel(ctx)
@@ -122,7 +127,10 @@ func (p *Preemptor) IssuePreemptions(ctx context.Context, targets []*workload.In
 	workqueue.ParallelizeUntil(ctx, parallelPreemptions, len(targets), func(i int) {
 		target := targets[i]
 		if !meta.IsStatusConditionTrue(target.Obj.Status.Conditions, kueue.WorkloadEvicted) {
-			err := p.applyPreemption(ctx, target.Obj)
+			wl := target.Obj.DeepCopy()
+			workload.SetEvictedCondition(wl, kueue.WorkloadEvictedByPreemption, "Preempted to accommodate a higher priority Workload")
+			workload.SetPreemptor(wl, preemptor.Obj, cq.Name)
+			err := p.applyPreemption(ctx, wl)
 			if err != nil {
 				errCh.SendErrorWithCancel(err, cancel)
 				return
@@ -134,6 +142,7 @@ func (p *Preemptor) IssuePreemptions(ctx context.Context, targets []*workload.In
 			}
 			log.V(3).Info("Preempted", "targetWorkload", klog.KObj(target.Obj))
 			p.recorder.Eventf(target.Obj, corev1.EventTypeNormal, "Preempted", "Preempted by another workload in the %s", origin)
+			metrics.ReportPreemption(cq.Name, target.ClusterQueue, origin)
 		} else {
 			log.V(3).Info("Preemption ongoing", "targetWorkload", klog.KObj(target.Obj))
 		}
@@ -143,8 +152,6 @@ func (p *Preemptor) IssuePreemptions(ctx context.Context, targets []*workload.In
 }
 
 func (p *Preemptor) applyPreemptionWithSSA(ctx context.Context, w *kueue.Workload) error {
-	w = w.DeepCopy()
-	workload.SetEvictedCondition(w, kueue.WorkloadEvictedByPreemption, "Preempted to accommodate a higher priority Workload")
 	return workload.ApplyAdmissionStatus(ctx, p.client, w, false)
 }
 
@@ -221,7 +228,8 @@ func resourcesRequiringPreemption(assignment flavorassigner.Assignment) resource
 
 // findCandidates obtains candidates for preemption within the ClusterQueue and
 // cohort that respect the preemption policy and are using a resource that the
-// preempting workload needs.
+// preempting workload needs. Protected workloads with a higher priority than
+// the preempting workload are never candidates.
 func findCandidates(wl *kueue.Workload, cq *cache.ClusterQueue, resPerFlv resourcesPerFlavor) []*workload.Info {
 	var candidates []*workload.Info
 	wlPriority := priority.Priority(wl)
@@ -261,6 +269,9 @@ func findCandidates(wl *kueue.Workload, cq *cache.ClusterQueue, resPerFlv resour
 				if onlyLowerPrio && priority.Priority(candidateWl.Obj) >= priority.Priority(wl) {
 					continue
 				}
+				if priority.IsProtectedFrom(candidateWl.Obj, wl) {
+					continue
+				}
 				if !workloadUsesResources(candidateWl, resPerFlv) {
 					continue
 				}
@@ -349,6 +360,133 @@ func workloadFits(wlReq cache.FlavorResourceQuantities, cq *cache.ClusterQueue,
 	return true
 }
 
+// ReservationTargets returns the workloads to preempt so that the quota held
+// for the Reservation of the ClusterQueue is no longer borrowed by other
+// ClusterQueues in the cohort. The candidates are the workloads of the
+// ClusterQueues borrowing the reserved resources, preempted with lower
+// priority and more recent quota reservation first, until the cohort usage fits
+// its requestable resources.
+func ReservationTargets(snapshot *cache.Snapshot, cqName, reservation string) []*workload.Info {
+	cq := snapshot.ClusterQueues[cqName]
+	if cq == nil || cq.Cohort == nil {
+		return nil
+	}
+	rq := cq.Reservations[reservation]
+	if rq == nil {
+		return nil
+	}
+	resPerFlv := make(resourcesPerFlavor, len(rq.Quota))
+	for fName, resources := range rq.Quota {
+		resPerFlv[fName] = sets.KeySet(resources)
+	}
+	var candidates []*workload.Info
+	for cohortCQ := range cq.Cohort.Members {
+		if cohortCQ == cq {
+			continue
+		}
+		for _, candidateWl := range cohortCQ.Workloads {
+			if workloadUsesResources(candidateWl, resPerFlv) {
+				candidates = append(candidates, candidateWl)
+			}
+		}
+	}
+	sort.Slice(candidates, candidatesOrdering(candidates, cq.Name, time.Now()))
+
+	var targets []*workload.Info
+	for _, candWl := range candidates {
+		if cohortFits(cq.Cohort, resPerFlv) {
+			break
+		}
+		if !cqIsBorrowing(snapshot.ClusterQueues[candWl.ClusterQueue], resPerFlv) {
+			continue
+		}
+		snapshot.RemoveWorkload(candWl)
+		targets = append(targets, candWl)
+	}
+	// Reset changes to the snapshot.
+	for _, t := range targets {
+		snapshot.AddWorkload(t)
+	}
+	return targets
+}
+
+// OverQuotaTargets returns the workloads of the ClusterQueue to evict so that
+// its usage fits within its quota, after the quota was reduced. The
+// candidates are the workloads using the resources over quota, evicted with
+// lower priority and more recent quota reservation first.
+func OverQuotaTargets(snapshot *cache.Snapshot, cqName string) []*workload.Info {
+	cq := snapshot.ClusterQueues[cqName]
+	if cq == nil {
+		return nil
+	}
+	resPerFlv := overQuotaResources(cq)
+	if len(resPerFlv) == 0 {
+		return nil
+	}
+	var candidates []*workload.Info
+	for _, candidateWl := range cq.Workloads {
+		if workloadUsesResources(candidateWl, resPerFlv) {
+			candidates = append(candidates, candidateWl)
+		}
+	}
+	sort.Slice(candidates, candidatesOrdering(candidates, cq.Name, time.Now()))
+
+	var targets []*workload.Info
+	for _, candWl := range candidates {
+		if len(overQuotaResources(cq)) == 0 {
+			break
+		}
+		snapshot.RemoveWorkload(candWl)
+		targets = append(targets, candWl)
+	}
+	// Reset changes to the snapshot.
+	for _, t := range targets {
+		snapshot.AddWorkload(t)
+	}
+	return targets
+}
+
+// overQuotaResources returns the resources for which the usage of the
+// ClusterQueue is over its nominal quota and it can't borrow the excess: it
+// doesn't belong to a cohort, it exceeds its borrowing limit, or the cohort
+// usage exceeds its requestable resources.
+func overQuotaResources(cq *cache.ClusterQueue) resourcesPerFlavor {
+	resPerFlv := make(resourcesPerFlavor)
+	for _, rg := range cq.ResourceGroups {
+		for _, fQuotas := range rg.Flavors {
+			fUsage := cq.Usage[fQuotas.Name]
+			for rName, rQuota := range fQuotas.Resources {
+				used := fUsage[rName]
+				if used <= rQuota.Nominal {
+					continue
+				}
+				if cq.Cohort == nil ||
+					(rQuota.BorrowingLimit != nil && used > rQuota.Nominal+*rQuota.BorrowingLimit) ||
+					cq.Cohort.Usage[fQuotas.Name][rName] > cq.Cohort.RequestableResources[fQuotas.Name][rName] {
+					if resPerFlv[fQuotas.Name] == nil {
+						resPerFlv[fQuotas.Name] = sets.New[corev1.ResourceName]()
+					}
+					resPerFlv[fQuotas.Name].Insert(rName)
+				}
+			}
+		}
+	}
+	return resPerFlv
+}
+
+// cohortFits returns whether the usage of the resources in the cohort is
+// within its requestable resources.
+func cohortFits(cohort *cache.Cohort, resPerFlv resourcesPerFlavor) bool {
+	for fName, resources := range resPerFlv {
+		for rName := range resources {
+			if cohort.Usage[fName][rName] > cohort.RequestableResources[fName][rName] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // candidatesOrdering criteria:
 // 1. Workloads from other ClusterQueues in the cohort before the ones in the
 // same ClusterQueue as the preemptor.
@@ -26,6 +26,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -41,7 +43,7 @@ import (
 
 var snapCmpOpts = []cmp.Option{
 	cmpopts.EquateEmpty(),
-	cmpopts.IgnoreUnexported(cache.ClusterQueue{}),
+	cmpopts.IgnoreUnexported(cache.ClusterQueue{}, cache.ReservedQuota{}),
 	cmpopts.IgnoreFields(cache.Cohort{}, "AllocatableResourceGeneration"),
 	cmpopts.IgnoreFields(cache.ClusterQueue{}, "AllocatableResourceGeneration"),
 	cmp.Transformer("Cohort.Members", func(s sets.Set[*cache.ClusterQueue]) sets.Set[string] {
@@ -215,6 +217,31 @@ func TestPreemption(t *testing.T) {
 				},
 			}),
 		},
+		"no preemption for workload with preemptionPolicy=Never": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("low", "").
+					Priority(-1).
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "3000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("mid", "").
+					Request(corev1.ResourceCPU, "3").
+					ReserveQuota(utiltesting.MakeAdmission("standalone").Assignment(corev1.ResourceCPU, "default", "3000m").Obj()).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Priority(1).
+				PreemptionPolicy(corev1.PreemptNever).
+				Request(corev1.ResourceCPU, "1").
+				Obj(),
+			targetCQ: "standalone",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+		},
 		"not enough low priority workloads": {
 			admitted: []kueue.Workload{
 				*utiltesting.MakeWorkload("low", "").
@@ -606,6 +633,66 @@ func TestPreemption(t *testing.T) {
 			}),
 			wantPreempted: sets.New("/c1-1"),
 		},
+		"don't reclaim borrowed quota from protected workloads with higher priority": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("c1-1", "").
+					Priority(1).
+					Protected().
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuota(utiltesting.MakeAdmission("c1").Assignment(corev1.ResourceCPU, "default", "4000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("c1-2", "").
+					Priority(2).
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuota(utiltesting.MakeAdmission("c1").Assignment(corev1.ResourceCPU, "default", "4000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("c2", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("c2").Assignment(corev1.ResourceCPU, "default", "2000m").Obj()).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Request(corev1.ResourceCPU, "4").
+				Obj(),
+			targetCQ: "c2",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New("/c1-2"),
+		},
+		"reclaim borrowed quota from protected workloads with the same priority": {
+			admitted: []kueue.Workload{
+				*utiltesting.MakeWorkload("c1-1", "").
+					Protected().
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuota(utiltesting.MakeAdmission("c1").Assignment(corev1.ResourceCPU, "default", "4000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("c1-2", "").
+					Priority(1).
+					Protected().
+					Request(corev1.ResourceCPU, "4").
+					ReserveQuota(utiltesting.MakeAdmission("c1").Assignment(corev1.ResourceCPU, "default", "4000m").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("c2", "").
+					Request(corev1.ResourceCPU, "2").
+					ReserveQuota(utiltesting.MakeAdmission("c2").Assignment(corev1.ResourceCPU, "default", "2000m").Obj()).
+					Obj(),
+			},
+			incoming: utiltesting.MakeWorkload("in", "").
+				Request(corev1.ResourceCPU, "4").
+				Obj(),
+			targetCQ: "c2",
+			assignment: singlePodSetAssignment(flavorassigner.ResourceAssignment{
+				corev1.ResourceCPU: &flavorassigner.FlavorAssignment{
+					Name: "default",
+					Mode: flavorassigner.Preempt,
+				},
+			}),
+			wantPreempted: sets.New("/c1-1"),
+		},
 		"preempt from all ClusterQueues in cohort": {
 			admitted: []kueue.Workload{
 				*utiltesting.MakeWorkload("c1-low", "").
@@ -779,6 +866,9 @@ func TestPreemption(t *testing.T) {
 			recorder := broadcaster.NewRecorder(scheme, corev1.EventSource{Component: constants.AdmissionName})
 			preemptor := New(cl, recorder)
 			preemptor.applyPreemption = func(ctx context.Context, w *kueue.Workload) error {
+				if c := meta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadEvicted); c == nil || c.Reason != kueue.WorkloadEvictedByPreemption {
+					t.Errorf("Workload %s applied without the Evicted condition by preemption", workload.Key(w))
+				}
 				lock.Lock()
 				gotPreempted.Insert(workload.Key(w))
 				lock.Unlock()
@@ -791,7 +881,7 @@ func TestPreemption(t *testing.T) {
 			wlInfo := workload.NewInfo(tc.incoming)
 			wlInfo.ClusterQueue = tc.targetCQ
 			targets := preemptor.GetTargets(*wlInfo, tc.assignment, &snapshot)
-			preempted, err := preemptor.IssuePreemptions(ctx, targets, snapshot.ClusterQueues[wlInfo.ClusterQueue])
+			preempted, err := preemptor.IssuePreemptions(ctx, wlInfo, targets, snapshot.ClusterQueues[wlInfo.ClusterQueue])
 			if err != nil {
 				t.Fatalf("Failed doing preemption")
 			}
@@ -847,6 +937,223 @@ func TestCandidatesOrdering(t *testing.T) {
 	}
 }
 
+func TestReservationTargets(t *testing.T) {
+	now := time.Now()
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("reserved").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("borrowing").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("not-borrowing").
+			Cohort("cohort").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+			Obj(),
+	}
+	admitted := func(name, cq string, prio int32, reservedAgo time.Duration) kueue.Workload {
+		return *utiltesting.MakeWorkload(name, "").
+			Request(corev1.ResourceCPU, "4").
+			Priority(prio).
+			ReserveQuota(utiltesting.MakeAdmission(cq).Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			SetOrReplaceCondition(metav1.Condition{
+				Type:               kueue.WorkloadQuotaReserved,
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(now.Add(-reservedAgo)),
+			}).
+			Obj()
+	}
+	cases := map[string]struct {
+		admitted    []kueue.Workload
+		reservation *kueue.Reservation
+		want        []string
+	}{
+		"preempts the borrowers with lower priority and newer first": {
+			admitted: []kueue.Workload{
+				admitted("high", "borrowing", 10, time.Minute),
+				admitted("mid", "borrowing", 5, time.Minute),
+				admitted("low-old", "borrowing", 0, 2*time.Minute),
+				admitted("low-new", "borrowing", 0, time.Minute),
+				admitted("mid-2", "borrowing", 5, time.Minute),
+				admitted("lowest", "not-borrowing", -1, time.Minute),
+				admitted("lowest-2", "not-borrowing", -1, time.Minute),
+			},
+			reservation: utiltesting.MakeReservation("res", "reserved", now, time.Hour).
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")}).
+				Phase(kueue.ReservationActive).
+				Obj(),
+			want: []string{"/low-new", "/low-old"},
+		},
+		"nothing borrowed": {
+			admitted: []kueue.Workload{
+				admitted("a", "borrowing", 0, time.Minute),
+				admitted("b", "not-borrowing", 0, time.Minute),
+			},
+			reservation: utiltesting.MakeReservation("res", "reserved", now, time.Hour).
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")}).
+				Phase(kueue.ReservationActive).
+				Obj(),
+		},
+		"reservation not holding quota": {
+			admitted: []kueue.Workload{
+				admitted("a", "borrowing", 0, time.Minute),
+				admitted("b", "borrowing", 0, time.Minute),
+				admitted("c", "borrowing", 0, time.Minute),
+				admitted("d", "borrowing", 0, time.Minute),
+			},
+			reservation: utiltesting.MakeReservation("res", "reserved", now, time.Hour).
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10")}).
+				Phase(kueue.ReservationExpired).
+				Obj(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: tc.admitted}).
+				Build()
+			cqCache := cache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cq := range clusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+				}
+			}
+			cqCache.AddOrUpdateReservation(tc.reservation)
+
+			startingSnapshot := cqCache.Snapshot()
+			snapshot := cqCache.Snapshot()
+			targets := ReservationTargets(&snapshot, "reserved", "res")
+			var got []string
+			for _, target := range targets {
+				got = append(got, workload.Key(target.Obj))
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected targets (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(startingSnapshot, snapshot, snapCmpOpts...); diff != "" {
+				t.Errorf("Snapshot was modified (-initial,+end):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOverQuotaTargets(t *testing.T) {
+	now := time.Now()
+	admitted := func(name, cq string, prio int32, reservedAgo time.Duration) kueue.Workload {
+		return *utiltesting.MakeWorkload(name, "").
+			Request(corev1.ResourceCPU, "4").
+			Priority(prio).
+			ReserveQuota(utiltesting.MakeAdmission(cq).Assignment(corev1.ResourceCPU, "default", "4").Obj()).
+			SetOrReplaceCondition(metav1.Condition{
+				Type:               kueue.WorkloadQuotaReserved,
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(now.Add(-reservedAgo)),
+			}).
+			Obj()
+	}
+	shrunkWorkloads := []kueue.Workload{
+		admitted("high", "shrunk", 10, time.Minute),
+		admitted("mid", "shrunk", 5, time.Minute),
+		admitted("low-old", "shrunk", 0, 2*time.Minute),
+		admitted("low-new", "shrunk", 0, time.Minute),
+	}
+	otherCQ := utiltesting.MakeClusterQueue("other").
+		Cohort("cohort").
+		ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+		Obj()
+	cases := map[string]struct {
+		clusterQueues []*kueue.ClusterQueue
+		admitted      []kueue.Workload
+		want          []string
+	}{
+		"without cohort, evicts lower priority and newer first": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("shrunk").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "8").Obj()).
+					Obj(),
+			},
+			admitted: shrunkWorkloads,
+			want:     []string{"/low-new", "/low-old"},
+		},
+		"within quota": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("shrunk").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "16").Obj()).
+					Obj(),
+			},
+			admitted: shrunkWorkloads,
+		},
+		"borrowing within the borrowing limit": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("shrunk").
+					Cohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "8", "8").Obj()).
+					Obj(),
+				otherCQ,
+			},
+			admitted: shrunkWorkloads,
+		},
+		"borrowing over the borrowing limit": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("shrunk").
+					Cohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "8", "4").Obj()).
+					Obj(),
+				otherCQ,
+			},
+			admitted: shrunkWorkloads,
+			want:     []string{"/low-new"},
+		},
+		"borrowing over the cohort capacity": {
+			clusterQueues: []*kueue.ClusterQueue{
+				utiltesting.MakeClusterQueue("shrunk").
+					Cohort("cohort").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "8").Obj()).
+					Obj(),
+				otherCQ,
+			},
+			admitted: append([]kueue.Workload{
+				admitted("other-1", "other", -1, time.Minute),
+				admitted("other-2", "other", -1, time.Minute),
+			}, shrunkWorkloads...),
+			want: []string{"/low-new", "/low-old"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx, _ := utiltesting.ContextWithLog(t)
+			cl := utiltesting.NewClientBuilder().
+				WithLists(&kueue.WorkloadList{Items: tc.admitted}).
+				Build()
+			cqCache := cache.New(cl)
+			cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+			for _, cq := range tc.clusterQueues {
+				if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+					t.Fatalf("Couldn't add ClusterQueue to cache: %v", err)
+				}
+			}
+
+			startingSnapshot := cqCache.Snapshot()
+			snapshot := cqCache.Snapshot()
+			targets := OverQuotaTargets(&snapshot, "shrunk")
+			var got []string
+			for _, target := range targets {
+				got = append(got, workload.Key(target.Obj))
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected targets (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(startingSnapshot, snapshot, snapCmpOpts...); diff != "" {
+				t.Errorf("Snapshot was modified (-initial,+end):\n%s", diff)
+			}
+		})
+	}
+}
+
 func singlePodSetAssignment(assignments flavorassigner.ResourceAssignment) flavorassigner.Assignment {
 	return flavorassigner.Assignment{
 		PodSets: []flavorassigner.PodSetAssignment{{
@@ -25,6 +25,9 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
@@ -44,6 +47,7 @@ import (
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
 	"sigs.k8s.io/kueue/pkg/scheduler/preemption"
+	"sigs.k8s.io/kueue/pkg/tracing"
 	"sigs.k8s.io/kueue/pkg/util/api"
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
 	utilmaps "sigs.k8s.io/kueue/pkg/util/maps"
@@ -64,11 +68,13 @@ type Scheduler struct {
 	recorder                record.EventRecorder
 	admissionRoutineWrapper routine.Wrapper
 	preemptor               *preemption.Preemptor
+	localQueueMetrics       bool
 	// Stubs.
 	applyAdmission func(context.Context, *kueue.Workload) error
 }
 
 type options struct {
+	localQueueMetrics bool
 }
 
 // Option configures the reconciler.
@@ -76,6 +82,14 @@ type Option func(*options)
 
 var defaultOptions = options{}
 
+// WithLocalQueueMetrics indicates if the admissions are also reported in the
+// per LocalQueue metrics.
+func WithLocalQueueMetrics(enabled bool) Option {
+	return func(o *options) {
+		o.localQueueMetrics = enabled
+	}
+}
+
 func New(queues *queue.Manager, cache *cache.Cache, cl client.Client, recorder record.EventRecorder, opts ...Option) *Scheduler {
 	options := defaultOptions
 	for _, opt := range opts {
@@ -88,6 +102,7 @@ func New(queues *queue.Manager, cache *cache.Cache, cl client.Client, recorder r
 		recorder:                recorder,
 		preemptor:               preemption.New(cl, recorder),
 		admissionRoutineWrapper: routine.DefaultWrapper,
+		localQueueMetrics:       options.localQueueMetrics,
 	}
 	s.applyAdmission = s.applyAdmissionWithSSA
 	return s
@@ -154,6 +169,8 @@ func (cu *cohortsUsage) hasCommonFlavorResources(cohort string, assigment cache.
 
 func (s *Scheduler) schedule(ctx context.Context) {
 	log := ctrl.LoggerFrom(ctx)
+	ctx, span := tracing.Tracer().Start(ctx, "Scheduler.schedule")
+	defer span.End()
 
 	// 1. Get the heads from the queues, including their desired clusterQueue.
 	// This operation blocks while the queues are empty.
@@ -165,15 +182,101 @@ func (s *Scheduler) schedule(ctx context.Context) {
 	startTime := time.Now()
 
 	// 2. Take a snapshot of the cache.
+	_, snapshotSpan := tracing.Tracer().Start(ctx, "Cache.Snapshot")
 	snapshot := s.cache.Snapshot()
+	snapshotSpan.End()
+
+	// 3. Evaluate the heads in rounds. The first round has the first head of
+	// every ClusterQueue. The next rounds have the next head of the
+	// ClusterQueues whose previous head was assumed, evaluated against a
+	// snapshot that includes the workloads assumed in the previous rounds.
+	// A cohort stops at the first round in which one of its workloads could
+	// fit, or preempt, but was not assumed, so that the quota it was
+	// competing for is not taken by the deeper heads of other ClusterQueues.
+	// Only the heads of the first round can preempt, as the workloads assumed
+	// in the cycle would otherwise be candidates for preemption.
+	roundHeads, nextHeads := splitHeads(headWorkloads)
+	result := metrics.AdmissionResultInadmissible
+	for round := 0; len(roundHeads) > 0; round++ {
+		entries := s.scheduleRound(ctx, roundHeads, &snapshot, round == 0)
+		roundHeads = nil
+		blockedCohorts := sets.New[string]()
+		for _, e := range entries {
+			if e.status != assumed && e.assignment.RepresentativeMode() != flavorassigner.NoFit {
+				if cq := snapshot.ClusterQueues[e.ClusterQueue]; cq.Cohort != nil {
+					blockedCohorts.Insert(cq.Cohort.Name)
+				}
+			}
+		}
+		var assumedEntries []*entry
+		for i := range entries {
+			e := &entries[i]
+			if e.status != assumed {
+				continue
+			}
+			result = metrics.AdmissionResultSuccess
+			assumedEntries = append(assumedEntries, e)
+			if cq := snapshot.ClusterQueues[e.ClusterQueue]; cq.Cohort != nil && blockedCohorts.Has(cq.Cohort.Name) {
+				continue
+			}
+			if heads := nextHeads[e.ClusterQueue]; len(heads) > 0 {
+				roundHeads = append(roundHeads, heads[0])
+				nextHeads[e.ClusterQueue] = heads[1:]
+			}
+		}
+		if len(roundHeads) > 0 {
+			for _, e := range assumedEntries {
+				snapshot.AddWorkload(workload.NewInfo(e.assumedWorkload))
+			}
+		}
+	}
+
+	// 4. Return the heads that were not evaluated, keeping their order.
+	for cqName, heads := range nextHeads {
+		for i := range heads {
+			added := s.queues.RequeueWorkload(ctx, &heads[i], queue.RequeueReasonFailedAfterNomination)
+			log.V(3).Info("Workload returned to the queue without evaluation", "workload", klog.KObj(heads[i].Obj), "clusterQueue", klog.KRef("", cqName), "added", added)
+		}
+	}
+	span.SetAttributes(attribute.String("kueue.admission_result", string(result)))
+	metrics.AdmissionAttempt(result, time.Since(startTime))
+}
 
-	// 3. Calculate requirements (resource flavors, borrowing) for admitting workloads.
-	entries := s.nominate(ctx, headWorkloads, snapshot)
+// splitHeads returns the first head of each ClusterQueue and, by
+// ClusterQueue, the heads that follow it in queueing order.
+func splitHeads(heads []workload.Info) ([]workload.Info, map[string][]workload.Info) {
+	var first []workload.Info
+	next := make(map[string][]workload.Info)
+	for _, h := range heads {
+		if rest, found := next[h.ClusterQueue]; found {
+			next[h.ClusterQueue] = append(rest, h)
+			continue
+		}
+		first = append(first, h)
+		next[h.ClusterQueue] = nil
+	}
+	for cqName, rest := range next {
+		if len(rest) == 0 {
+			delete(next, cqName)
+		}
+	}
+	return first, next
+}
+
+// scheduleRound nominates the heads against the snapshot and admits the ones
+// that fit. The heads that need preemption issue it only if allowPreemption
+// is true, otherwise they are skipped. The heads that are not assumed are
+// requeued.
+func (s *Scheduler) scheduleRound(ctx context.Context, headWorkloads []workload.Info, snapshot *cache.Snapshot, allowPreemption bool) []entry {
+	log := ctrl.LoggerFrom(ctx)
 
-	// 4. Sort entries based on borrowing and timestamps.
+	// 1. Calculate requirements (resource flavors, borrowing) for admitting workloads.
+	entries := s.nominate(ctx, headWorkloads, *snapshot)
+
+	// 2. Sort entries based on borrowing and timestamps.
 	sort.Sort(entryOrdering(entries))
 
-	// 5. Admit entries, ensuring that no more than one workload gets
+	// 3. Admit entries, ensuring that no more than one workload gets
 	// admitted by a cohort (if borrowing).
 	// This is because there can be other workloads deeper in a clusterQueue whose
 	// head got admitted that should be scheduled in the cohort before the heads
@@ -207,11 +310,24 @@ func (s *Scheduler) schedule(ctx context.Context) {
 		log := log.WithValues("workload", klog.KObj(e.Obj), "clusterQueue", klog.KRef("", e.ClusterQueue))
 		ctx := ctrl.LoggerInto(ctx, log)
 		if e.assignment.RepresentativeMode() != flavorassigner.Fit {
+			if !allowPreemption {
+				// The assignment accounts for the workloads assumed in this
+				// cycle, so it is computed again in the next cycle.
+				e.status = skipped
+				e.inadmissibleMsg = "preemption is deferred to the next scheduling cycle"
+				e.LastAssignment = nil
+				continue
+			}
 			if len(e.preemptionTargets) != 0 {
-				preempted, err := s.preemptor.IssuePreemptions(ctx, e.preemptionTargets, cq)
+				pCtx, pSpan := tracing.Tracer().Start(ctx, "Preemptor.IssuePreemptions", tracing.WithWorkload(e.Obj, e.ClusterQueue)...)
+				preempted, err := s.preemptor.IssuePreemptions(pCtx, &e.Info, e.preemptionTargets, cq)
+				pSpan.SetAttributes(attribute.Int("kueue.preemption_targets", len(e.preemptionTargets)), attribute.Int("kueue.preempted", preempted))
 				if err != nil {
+					pSpan.RecordError(err)
+					pSpan.SetStatus(codes.Error, "Failed to preempt workloads")
 					log.Error(err, "Failed to preempt workloads")
 				}
+				pSpan.End()
 				if preempted != 0 {
 					e.inadmissibleMsg += fmt.Sprintf(". Pending the preemption of %d workload(s)", preempted)
 					e.requeueReason = queue.RequeueReasonPendingPreemption
@@ -221,17 +337,20 @@ func (s *Scheduler) schedule(ctx context.Context) {
 			}
 			continue
 		}
-		if !s.cache.PodsReadyForAllAdmittedWorkloads(log) {
+		if !s.cache.PodsReadyForAllAdmittedWorkloads(log, e.ClusterQueue) {
 			log.V(5).Info("Waiting for all admitted workloads to be in the PodsReady condition")
 			// If WaitForPodsReady is enabled and WaitForPodsReady.BlockAdmission is true
-			// Block admission until all currently admitted workloads are in
-			// PodsReady condition if the waitForPodsReady is enabled
-			workload.UnsetQuotaReservationWithCondition(e.Obj, "Waiting", "waiting for all admitted workloads to be in PodsReady condition")
+			// the workload is not admitted until all currently admitted workloads
+			// blocking its ClusterQueue are in PodsReady condition. The other
+			// ClusterQueues keep admitting workloads in the meantime.
+			e.status = skipped
+			e.inadmissibleMsg = "waiting for all admitted workloads to be in PodsReady condition"
+			e.requeueReason = queue.RequeueReasonWaitingForPodsReady
+			workload.UnsetQuotaReservationWithCondition(e.Obj, "Waiting", e.inadmissibleMsg)
 			if err := workload.ApplyAdmissionStatus(ctx, s.client, e.Obj, false); err != nil {
 				log.Error(err, "Could not update Workload status")
 			}
-			s.cache.WaitForPodsReady(ctx)
-			log.V(5).Info("Finished waiting for all admitted workloads to be in the PodsReady condition")
+			continue
 		}
 		e.status = nominated
 		if err := s.admit(ctx, e, cq.AdmissionChecks); err != nil {
@@ -239,8 +358,7 @@ func (s *Scheduler) schedule(ctx context.Context) {
 		}
 	}
 
-	// 6. Requeue the heads that were not scheduled.
-	result := metrics.AdmissionResultInadmissible
+	// 4. Requeue the heads that were not scheduled.
 	for _, e := range entries {
 		log.V(3).Info("Workload evaluated for admission",
 			"workload", klog.KObj(e.Obj),
@@ -249,11 +367,9 @@ func (s *Scheduler) schedule(ctx context.Context) {
 			"reason", e.inadmissibleMsg)
 		if e.status != assumed {
 			s.requeueAndUpdate(log, ctx, e)
-		} else {
-			result = metrics.AdmissionResultSuccess
 		}
 	}
-	metrics.AdmissionAttempt(result, time.Since(startTime))
+	return entries
 }
 
 type entryStatus string
@@ -279,11 +395,16 @@ type entry struct {
 	inadmissibleMsg   string
 	requeueReason     queue.RequeueReason
 	preemptionTargets []*workload.Info
+	// assumedWorkload is the workload with the quota reservation, once the
+	// entry is assumed.
+	assumedWorkload *kueue.Workload
 }
 
 // nominate returns the workloads with their requirements (resource flavors, borrowing) if
 // they were admitted by the clusterQueues in the snapshot.
 func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, snap cache.Snapshot) []entry {
+	ctx, span := tracing.Tracer().Start(ctx, "Scheduler.nominate", trace.WithAttributes(tracing.CountKey.Int(len(workloads))))
+	defer span.End()
 	log := ctrl.LoggerFrom(ctx)
 	entries := make([]entry, 0, len(workloads))
 	for _, w := range workloads {
@@ -310,9 +431,15 @@ func (s *Scheduler) nominate(ctx context.Context, workloads []workload.Info, sna
 		} else if err := s.validateLimitRange(ctx, &w); err != nil {
 			e.inadmissibleMsg = err.Error()
 		} else {
+			_, aSpan := tracing.Tracer().Start(ctx, "Scheduler.getAssignments", tracing.WithWorkload(w.Obj, w.ClusterQueue)...)
+			// The quota held for an active reservation is only available to its workloads.
+			holdReservation := snap.ReleaseReservation(&e.Info)
 			e.assignment, e.preemptionTargets = s.getAssignments(log, &e.Info, &snap)
+			holdReservation()
 			e.inadmissibleMsg = e.assignment.Message()
 			e.Info.LastAssignment = &e.assignment.LastState
+			aSpan.SetAttributes(attribute.String("kueue.assignment_mode", e.assignment.RepresentativeMode().String()))
+			aSpan.End()
 		}
 		entries = append(entries, e)
 	}
@@ -426,7 +553,9 @@ func (s *Scheduler) validateLimitRange(ctx context.Context, wi *workload.Info) e
 // admit sets the admitting clusterQueue and flavors into the workload of
 // the entry, and asynchronously updates the object in the apiserver after
 // assuming it in the cache.
-func (s *Scheduler) admit(ctx context.Context, e *entry, mustHaveChecks sets.Set[string]) error {
+func (s *Scheduler) admit(ctx context.Context, e *entry, admissionChecks map[string]sets.Set[kueue.ResourceFlavorReference]) error {
+	ctx, span := tracing.Tracer().Start(ctx, "Scheduler.admit", tracing.WithWorkload(e.Obj, e.ClusterQueue)...)
+	defer span.End()
 	log := ctrl.LoggerFrom(ctx)
 	newWorkload := e.Obj.DeepCopy()
 	admission := &kueue.Admission{
@@ -435,25 +564,35 @@ func (s *Scheduler) admit(ctx context.Context, e *entry, mustHaveChecks sets.Set
 	}
 
 	workload.SetQuotaReservation(newWorkload, admission)
-	if workload.HasAllChecks(newWorkload, mustHaveChecks) {
+	if workload.HasAllChecks(newWorkload, workload.AdmissionChecksForWorkload(newWorkload, admissionChecks)) {
 		// sync Admitted, ignore the result since an API update is always done.
 		_ = workload.SyncAdmittedCondition(newWorkload)
 	}
 	if err := s.cache.AssumeWorkload(newWorkload); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to assume the workload")
 		return err
 	}
 	e.status = assumed
+	e.assumedWorkload = newWorkload
 	log.V(2).Info("Workload assumed in the cache")
 
 	s.admissionRoutineWrapper.Run(func() {
+		ctx, applySpan := tracing.Tracer().Start(ctx, "Scheduler.applyAdmission", tracing.WithWorkload(newWorkload, e.ClusterQueue)...)
+		defer applySpan.End()
 		err := s.applyAdmission(ctx, newWorkload)
 		if err == nil {
 			waitTime := time.Since(e.Obj.CreationTimestamp.Time)
 			s.recorder.Eventf(newWorkload, corev1.EventTypeNormal, "Admitted", "Admitted by ClusterQueue %v, wait time was %.0fs", admission.ClusterQueue, waitTime.Seconds())
 			metrics.AdmittedWorkload(admission.ClusterQueue, waitTime)
+			if s.localQueueMetrics {
+				metrics.LocalQueueAdmittedWorkload(newWorkload.Spec.QueueName, newWorkload.Namespace, waitTime)
+			}
 			log.V(2).Info("Workload successfully admitted and assigned flavors", "assignments", admission.PodSetAssignments)
 			return
 		}
+		applySpan.RecordError(err)
+		applySpan.SetStatus(codes.Error, errCouldNotAdmitWL)
 		// Ignore errors because the workload or clusterQueue could have been deleted
 		// by an event.
 		_ = s.cache.ForgetWorkload(newWorkload)
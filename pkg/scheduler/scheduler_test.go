@@ -26,6 +26,11 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,9 +43,11 @@ import (
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/queue"
 	"sigs.k8s.io/kueue/pkg/scheduler/flavorassigner"
+	"sigs.k8s.io/kueue/pkg/tracing"
 	"sigs.k8s.io/kueue/pkg/util/routine"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 	"sigs.k8s.io/kueue/pkg/workload"
@@ -192,8 +199,14 @@ func TestSchedule(t *testing.T) {
 		additionalClusterQueues []kueue.ClusterQueue
 		additionalLocalQueues   []kueue.LocalQueue
 
+		reservations []*kueue.Reservation
+
 		// disable partial admission
 		disablePartialAdmission bool
+
+		// maxHeadsPerClusterQueue is the number of heads popped from each
+		// clusterQueue in the cycle, if not zero.
+		maxHeadsPerClusterQueue int
 	}{
 		"workload fits in single clusterQueue": {
 			workloads: []kueue.Workload{
@@ -1001,6 +1014,163 @@ func TestSchedule(t *testing.T) {
 					Obj(),
 			},
 		},
+		"quota held for an active reservation is only available to its workloads": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("cq1").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource("r1", "16").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("cq2").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource("r1", "16").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq1", "sales").ClusterQueue("cq1").Obj(),
+				*utiltesting.MakeLocalQueue("lq2", "sales").ClusterQueue("cq2").Obj(),
+			},
+			reservations: []*kueue.Reservation{
+				utiltesting.MakeReservation("res1", "cq1", time.Now(), time.Hour).
+					Flavor("default", corev1.ResourceList{"r1": resource.MustParse("8")}).
+					Phase(kueue.ReservationActive).
+					Obj(),
+				utiltesting.MakeReservation("res2", "cq2", time.Now(), time.Hour).
+					Flavor("default", corev1.ResourceList{"r1": resource.MustParse("8")}).
+					Phase(kueue.ReservationActive).
+					Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl1", "sales").Queue("lq1").
+					Labels(map[string]string{controllerconsts.ReservationLabel: "res1"}).
+					PodSets(*utiltesting.MakePodSet("main", 1).Request("r1", "16").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("wl2", "sales").Queue("lq2").
+					PodSets(*utiltesting.MakePodSet("main", 1).Request("r1", "10").Obj()).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/wl1"},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/wl1": *utiltesting.MakeAdmission("cq1", "main").
+					Assignment("r1", "default", "16").AssignmentPodCount(1).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[string]sets.Set[string]{
+				"cq2": sets.New("sales/wl2"),
+			},
+		},
+		"count quotas limit the admitted workloads and can be borrowed in the cohort": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("cq1").
+					Cohort("co").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource("r1", "10").
+						Resource(corev1.ResourcePods, "4").
+						Resource(kueue.ResourceWorkloads, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("cq2").
+					Cohort("co").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource("r1", "10").
+						Resource(corev1.ResourcePods, "4").
+						Resource(kueue.ResourceWorkloads, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("cq3").
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").
+						Resource("r1", "10").
+						Resource(kueue.ResourceWorkloads, "1").
+						Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq1", "sales").ClusterQueue("cq1").Obj(),
+				*utiltesting.MakeLocalQueue("lq3", "sales").ClusterQueue("cq3").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl0", "sales").Queue("lq1").PodSets(
+					*utiltesting.MakePodSet("main", 1).Request("r1", "1").Obj(),
+				).ReserveQuota(utiltesting.MakeAdmission("cq1", "main").
+					Assignment("r1", "default", "1").
+					Assignment(corev1.ResourcePods, "default", "1").
+					Assignment(kueue.ResourceWorkloads, "default", "1").
+					AssignmentPodCount(1).
+					Obj()).Obj(),
+				*utiltesting.MakeWorkload("wl1", "sales").Queue("lq1").PodSets(
+					*utiltesting.MakePodSet("main", 2).Request("r1", "1").Obj(),
+				).Obj(),
+				*utiltesting.MakeWorkload("wl2", "sales").Queue("lq3").PodSets(
+					*utiltesting.MakePodSet("main", 1).Request("r1", "1").Obj(),
+				).ReserveQuota(utiltesting.MakeAdmission("cq3", "main").
+					Assignment("r1", "default", "1").
+					Assignment(kueue.ResourceWorkloads, "default", "1").
+					AssignmentPodCount(1).
+					Obj()).Obj(),
+				*utiltesting.MakeWorkload("wl3", "sales").Queue("lq3").PodSets(
+					*utiltesting.MakePodSet("main", 1).Request("r1", "1").Obj(),
+				).Obj(),
+			},
+			wantScheduled: []string{"sales/wl1"},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/wl0": *utiltesting.MakeAdmission("cq1", "main").
+					Assignment("r1", "default", "1").
+					Assignment(corev1.ResourcePods, "default", "1").
+					Assignment(kueue.ResourceWorkloads, "default", "1").
+					AssignmentPodCount(1).
+					Obj(),
+				"sales/wl1": *utiltesting.MakeAdmission("cq1", "main").
+					Assignment("r1", "default", "2").
+					Assignment(corev1.ResourcePods, "default", "2").
+					Assignment(kueue.ResourceWorkloads, "default", "1").
+					AssignmentPodCount(2).
+					Obj(),
+				"sales/wl2": *utiltesting.MakeAdmission("cq3", "main").
+					Assignment("r1", "default", "1").
+					Assignment(kueue.ResourceWorkloads, "default", "1").
+					AssignmentPodCount(1).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[string]sets.Set[string]{
+				"cq3": sets.New("sales/wl3"),
+			},
+		},
+		"workload waiting for pods ready in its clusterQueue doesn't block other clusterQueues": {
+			additionalClusterQueues: []kueue.ClusterQueue{
+				*utiltesting.MakeClusterQueue("cq1").
+					WaitForPodsReady(true, time.Minute, true).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource("r1", "10").Obj()).
+					Obj(),
+				*utiltesting.MakeClusterQueue("cq2").
+					WaitForPodsReady(true, time.Minute, true).
+					ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource("r1", "10").Obj()).
+					Obj(),
+			},
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq1", "sales").ClusterQueue("cq1").Obj(),
+				*utiltesting.MakeLocalQueue("lq2", "sales").ClusterQueue("cq2").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("wl0", "sales").Queue("lq1").PodSets(
+					*utiltesting.MakePodSet("main", 1).Request("r1", "2").Obj(),
+				).ReserveQuota(utiltesting.MakeAdmission("cq1", "main").Assignment("r1", "default", "2").AssignmentPodCount(1).Obj()).Obj(),
+				*utiltesting.MakeWorkload("wl1", "sales").Queue("lq1").PodSets(
+					*utiltesting.MakePodSet("main", 1).Request("r1", "2").Obj(),
+				).Obj(),
+				*utiltesting.MakeWorkload("wl2", "sales").Queue("lq2").PodSets(
+					*utiltesting.MakePodSet("main", 1).Request("r1", "2").Obj(),
+				).Obj(),
+			},
+			wantScheduled: []string{"sales/wl2"},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/wl0": *utiltesting.MakeAdmission("cq1", "main").
+					Assignment("r1", "default", "2").AssignmentPodCount(1).
+					Obj(),
+				"sales/wl2": *utiltesting.MakeAdmission("cq2", "main").
+					Assignment("r1", "default", "2").AssignmentPodCount(1).
+					Obj(),
+			},
+			wantInadmissibleLeft: map[string]sets.Set[string]{
+				"cq1": sets.New("sales/wl1"),
+			},
+		},
 		"only one workload can borrow one resources from the same flavor in the same cycle if cohort quota cannot fit": {
 			additionalClusterQueues: func() []kueue.ClusterQueue {
 				preemption := kueue.ClusterQueuePreemption{
@@ -1036,6 +1206,161 @@ func TestSchedule(t *testing.T) {
 				"cq2": sets.New("sales/wl2"),
 			},
 		},
+		"batch admission admits several workloads of a clusterQueue": {
+			maxHeadsPerClusterQueue: 3,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "sales").
+					Queue("main").
+					Creation(time.Now().Add(-2 * time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("b", "sales").
+					Queue("main").
+					Creation(time.Now().Add(-time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("c", "sales").
+					Queue("main").
+					Creation(time.Now()).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/a", "sales/b"},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/a": *utiltesting.MakeAdmission("sales", "one").
+					Assignment(corev1.ResourceCPU, "default", "20").
+					AssignmentPodCount(20).
+					Obj(),
+				"sales/b": *utiltesting.MakeAdmission("sales", "one").
+					Assignment(corev1.ResourceCPU, "default", "20").
+					AssignmentPodCount(20).
+					Obj(),
+			},
+			wantLeft: map[string]sets.Set[string]{
+				"sales": sets.New("sales/c"),
+			},
+		},
+		"batch admission stops a StrictFIFO clusterQueue at the first workload that doesn't fit": {
+			maxHeadsPerClusterQueue: 3,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a", "sales").
+					Queue("main").
+					Creation(time.Now().Add(-2 * time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 20).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("b", "sales").
+					Queue("main").
+					Creation(time.Now().Add(-time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 40).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("c", "sales").
+					Queue("main").
+					Creation(time.Now()).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/a"},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/a": *utiltesting.MakeAdmission("sales", "one").
+					Assignment(corev1.ResourceCPU, "default", "20").
+					AssignmentPodCount(20).
+					Obj(),
+			},
+			wantLeft: map[string]sets.Set[string]{
+				"sales": sets.New("sales/b", "sales/c"),
+			},
+		},
+		"batch admission doesn't preempt the workloads assumed in the cycle": {
+			maxHeadsPerClusterQueue: 2,
+			additionalClusterQueues: func() []kueue.ClusterQueue {
+				preemption := kueue.ClusterQueuePreemption{
+					ReclaimWithinCohort: kueue.PreemptionPolicyAny,
+				}
+				rg := *utiltesting.MakeFlavorQuotas("default").Resource("r1", "10", "10").Obj()
+				cq1 := *utiltesting.MakeClusterQueue("cq1").Cohort("co").Preemption(preemption).ResourceGroup(rg).Obj()
+				cq2 := *utiltesting.MakeClusterQueue("cq2").Cohort("co").Preemption(preemption).ResourceGroup(rg).Obj()
+				return []kueue.ClusterQueue{cq1, cq2}
+			}(),
+			additionalLocalQueues: []kueue.LocalQueue{
+				*utiltesting.MakeLocalQueue("lq1", "sales").ClusterQueue("cq1").Obj(),
+				*utiltesting.MakeLocalQueue("lq2", "sales").ClusterQueue("cq2").Obj(),
+			},
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a1", "sales").Queue("lq1").
+					Creation(time.Now().Add(-2 * time.Second)).
+					PodSets(*utiltesting.MakePodSet("main", 1).Request("r1", "15").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("b1", "sales").Queue("lq2").
+					Creation(time.Now().Add(-time.Second)).
+					PodSets(*utiltesting.MakePodSet("main", 1).Request("r1", "1").Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("b2", "sales").Queue("lq2").
+					Creation(time.Now()).
+					PodSets(*utiltesting.MakePodSet("main", 1).Request("r1", "8").Obj()).
+					Obj(),
+			},
+			wantScheduled: []string{"sales/a1", "sales/b1"},
+			wantAssignments: map[string]kueue.Admission{
+				"sales/a1": *utiltesting.MakeAdmission("cq1", "main").
+					Assignment("r1", "default", "15").AssignmentPodCount(1).
+					Obj(),
+				"sales/b1": *utiltesting.MakeAdmission("cq2", "main").
+					Assignment("r1", "default", "1").AssignmentPodCount(1).
+					Obj(),
+			},
+			wantLeft: map[string]sets.Set[string]{
+				"cq2": sets.New("sales/b2"),
+			},
+		},
+		"batch admission stops a cohort when a workload was skipped": {
+			maxHeadsPerClusterQueue: 3,
+			workloads: []kueue.Workload{
+				*utiltesting.MakeWorkload("a1", "eng-alpha").
+					Queue("main").
+					Creation(time.Now().Add(-2 * time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 50).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("a2", "eng-alpha").
+					Queue("main").
+					Creation(time.Now().Add(-time.Second)).
+					PodSets(*utiltesting.MakePodSet("one", 10).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+				*utiltesting.MakeWorkload("b1", "eng-beta").
+					Queue("main").
+					Creation(time.Now()).
+					PodSets(*utiltesting.MakePodSet("one", 60 /* Will borrow */).
+						Request(corev1.ResourceCPU, "1").
+						Obj()).
+					Obj(),
+			},
+			wantScheduled: []string{"eng-alpha/a1"},
+			wantAssignments: map[string]kueue.Admission{
+				"eng-alpha/a1": *utiltesting.MakeAdmission("eng-alpha", "one").
+					Assignment(corev1.ResourceCPU, "on-demand", "50").
+					AssignmentPodCount(50).
+					Obj(),
+			},
+			wantLeft: map[string]sets.Set[string]{
+				"eng-alpha": sets.New("eng-alpha/a2"),
+				"eng-beta":  sets.New("eng-beta/b1"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -1062,7 +1387,11 @@ func TestSchedule(t *testing.T) {
 			recorder := broadcaster.NewRecorder(scheme,
 				corev1.EventSource{Component: constants.AdmissionName})
 			cqCache := cache.New(cl)
-			qManager := queue.NewManager(cl, cqCache)
+			var qOpts []queue.Option
+			if tc.maxHeadsPerClusterQueue > 0 {
+				qOpts = append(qOpts, queue.WithMaxHeadsPerClusterQueue(tc.maxHeadsPerClusterQueue))
+			}
+			qManager := queue.NewManager(cl, cqCache, qOpts...)
 			// Workloads are loaded into queues or clusterQueues as we add them.
 			for _, q := range allQueues {
 				if err := qManager.AddLocalQueue(ctx, &q); err != nil {
@@ -1080,6 +1409,9 @@ func TestSchedule(t *testing.T) {
 					t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
 				}
 			}
+			for _, res := range tc.reservations {
+				cqCache.AddOrUpdateReservation(res)
+			}
 			scheduler := New(qManager, cqCache, cl, recorder)
 			gotScheduled := make(map[string]kueue.Admission)
 			var mu sync.Mutex
@@ -1785,3 +2117,116 @@ func TestRequeueAndUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestScheduleTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	prevProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	t.Cleanup(func() { otel.SetTracerProvider(prevProvider) })
+
+	ctx, _ := utiltesting.ContextWithLog(t)
+	clusterQueues := []*kueue.ClusterQueue{
+		utiltesting.MakeClusterQueue("cq").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+			Obj(),
+		utiltesting.MakeClusterQueue("small-cq").
+			ResourceGroup(*utiltesting.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "1").Obj()).
+			Obj(),
+	}
+	localQueues := []*kueue.LocalQueue{
+		utiltesting.MakeLocalQueue("lq", "ns").ClusterQueue("cq").Obj(),
+		utiltesting.MakeLocalQueue("small-lq", "ns").ClusterQueue("small-cq").Obj(),
+	}
+	fits := utiltesting.MakeWorkload("fits", "ns").Queue("lq").Request(corev1.ResourceCPU, "1").Obj()
+	tooBig := utiltesting.MakeWorkload("too-big", "ns").Queue("small-lq").Request(corev1.ResourceCPU, "2").Obj()
+
+	cl := utiltesting.NewClientBuilder().
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ns"}}, localQueues[0], localQueues[1], fits, tooBig).
+		WithStatusSubresource(&kueue.Workload{}).
+		Build()
+	recorder := record.NewBroadcaster().NewRecorder(runtime.NewScheme(), corev1.EventSource{Component: constants.AdmissionName})
+	cqCache := cache.New(cl)
+	qManager := queue.NewManager(cl, cqCache)
+	cqCache.AddOrUpdateResourceFlavor(utiltesting.MakeResourceFlavor("default").Obj())
+	for _, cq := range clusterQueues {
+		if err := cqCache.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in cache: %v", cq.Name, err)
+		}
+		if err := qManager.AddClusterQueue(ctx, cq); err != nil {
+			t.Fatalf("Inserting clusterQueue %s in manager: %v", cq.Name, err)
+		}
+	}
+	for _, lq := range localQueues {
+		if err := qManager.AddLocalQueue(ctx, lq); err != nil {
+			t.Fatalf("Inserting queue %s in manager: %v", lq.Name, err)
+		}
+	}
+
+	scheduler := New(qManager, cqCache, cl, recorder)
+	scheduler.applyAdmission = func(context.Context, *kueue.Workload) error { return nil }
+	wg := sync.WaitGroup{}
+	scheduler.setAdmissionRoutineWrapper(routine.NewWrapper(
+		func() { wg.Add(1) },
+		func() { wg.Done() },
+	))
+
+	ctx, cancel := context.WithTimeout(ctx, queueingTimeout)
+	defer cancel()
+	go qManager.CleanUpOnContext(ctx)
+	scheduler.schedule(ctx)
+	wg.Wait()
+
+	spans := make(map[string][]tracetest.SpanStub)
+	for _, s := range exporter.GetSpans() {
+		spans[s.Name] = append(spans[s.Name], s)
+	}
+	gotCounts := make(map[string]int, len(spans))
+	for name, ss := range spans {
+		gotCounts[name] = len(ss)
+	}
+	wantCounts := map[string]int{
+		"Scheduler.schedule":       1,
+		"Manager.Heads":            1,
+		"Cache.Snapshot":           1,
+		"Scheduler.nominate":       1,
+		"Scheduler.getAssignments": 2,
+		"Scheduler.admit":          1,
+		"Scheduler.applyAdmission": 1,
+		"Manager.RequeueWorkload":  1,
+	}
+	if diff := cmp.Diff(wantCounts, gotCounts); diff != "" {
+		t.Fatalf("Unexpected spans (-want,+got):\n%s", diff)
+	}
+
+	cycle := spans["Scheduler.schedule"][0]
+	for _, name := range []string{"Manager.Heads", "Cache.Snapshot", "Scheduler.nominate", "Scheduler.admit", "Manager.RequeueWorkload"} {
+		s := spans[name][0]
+		if s.Parent.SpanID() != cycle.SpanContext.SpanID() {
+			t.Errorf("Span %s is not a child of the scheduling cycle", name)
+		}
+	}
+	admit := spans["Scheduler.admit"][0]
+	if apply := spans["Scheduler.applyAdmission"][0]; apply.Parent.SpanID() != admit.SpanContext.SpanID() {
+		t.Errorf("Span Scheduler.applyAdmission is not a child of Scheduler.admit")
+	}
+
+	wantAdmitAttrs := []attribute.KeyValue{
+		tracing.WorkloadNameKey.String("fits"),
+		tracing.WorkloadNamespaceKey.String("ns"),
+		tracing.LocalQueueKey.String("lq"),
+		tracing.ClusterQueueKey.String("cq"),
+	}
+	if diff := cmp.Diff(wantAdmitAttrs, admit.Attributes, cmp.AllowUnexported(attribute.Value{})); diff != "" {
+		t.Errorf("Unexpected attributes of Scheduler.admit (-want,+got):\n%s", diff)
+	}
+	wantLinks := map[string]trace.SpanContext{
+		"Scheduler.admit":         tracing.WorkloadSpanContext("ns", "fits", ""),
+		"Manager.RequeueWorkload": tracing.WorkloadSpanContext("ns", "too-big", ""),
+	}
+	for name, want := range wantLinks {
+		links := spans[name][0].Links
+		if len(links) != 1 || !links[0].SpanContext.Equal(want) {
+			t.Errorf("Span %s is not linked to the lifecycle of its workload, got links %v", name, links)
+		}
+	}
+}
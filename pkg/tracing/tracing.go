@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/types"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/constants"
+	"sigs.k8s.io/kueue/pkg/version"
+)
+
+const instrumentationName = "sigs.k8s.io/kueue"
+
+const (
+	WorkloadNameKey      = attribute.Key("kueue.workload.name")
+	WorkloadNamespaceKey = attribute.Key("kueue.workload.namespace")
+	LocalQueueKey        = attribute.Key("kueue.local_queue")
+	ClusterQueueKey      = attribute.Key("kueue.cluster_queue")
+	JobKindKey           = attribute.Key("kueue.job.kind")
+	JobNameKey           = attribute.Key("kueue.job.name")
+	CountKey             = attribute.Key("kueue.count")
+)
+
+// Tracer returns the tracer used by all the Kueue components.
+// Until Setup is called, the spans are discarded.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Setup registers the global TracerProvider that samples
+// samplingRatePerMillion out of every million traces and sends them to the
+// exporter. The returned function flushes the pending spans and stops the
+// exporter.
+func Setup(exporter sdktrace.SpanExporter, samplingRatePerMillion int32) (func(context.Context) error, error) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(constants.KueueName),
+		semconv.ServiceVersion(version.GitVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("building the tracing resource: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(samplingRatePerMillion)/1000000))),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// NewStdoutExporter returns an exporter that writes the spans to the
+// standard output.
+func NewStdoutExporter() (sdktrace.SpanExporter, error) {
+	return stdouttrace.New()
+}
+
+// WorkloadSpanContext returns the span context that stands for the whole
+// lifecycle of the workload with the given namespace, name and UID.
+// It's derived from those alone, so that the job reconciler, the queue
+// manager and the scheduler can link their spans to the same lifecycle
+// without sharing any state. The UID keeps apart the workloads that reuse
+// the name of a deleted one.
+func WorkloadSpanContext(namespace, name string, uid types.UID) trace.SpanContext {
+	sum := sha256.Sum256([]byte(namespace + "/" + name + "/" + string(uid)))
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	copy(traceID[:], sum[:len(traceID)])
+	copy(spanID[:], sum[len(traceID):len(traceID)+len(spanID)])
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
+
+// WorkloadLink links a span to the lifecycle of the workload.
+func WorkloadLink(wl *kueue.Workload) trace.Link {
+	return trace.Link{SpanContext: WorkloadSpanContext(wl.Namespace, wl.Name, wl.UID)}
+}
+
+// WithWorkload returns the options to start a span about the workload: its
+// identifying attributes and the link to its lifecycle.
+// cqName is the ClusterQueue the workload is processed for; if empty, the one
+// in the admission of the workload is used, if any.
+func WithWorkload(wl *kueue.Workload, cqName string) []trace.SpanStartOption {
+	attrs := []attribute.KeyValue{
+		WorkloadNameKey.String(wl.Name),
+		WorkloadNamespaceKey.String(wl.Namespace),
+		LocalQueueKey.String(wl.Spec.QueueName),
+	}
+	if cqName == "" && wl.Status.Admission != nil {
+		cqName = string(wl.Status.Admission.ClusterQueue)
+	}
+	if cqName != "" {
+		attrs = append(attrs, ClusterQueueKey.String(cqName))
+	}
+	return []trace.SpanStartOption{
+		trace.WithAttributes(attrs...),
+		trace.WithLinks(WorkloadLink(wl)),
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/types"
+
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestWorkloadSpanContext(t *testing.T) {
+	sc := WorkloadSpanContext("ns", "wl", "uid")
+	if !sc.IsValid() || !sc.IsSampled() {
+		t.Errorf("Span context %v is not valid and sampled", sc)
+	}
+	if !sc.Equal(WorkloadSpanContext("ns", "wl", "uid")) {
+		t.Errorf("Span context is not stable for the same workload")
+	}
+	for _, other := range [][3]string{{"ns", "wl2", "uid"}, {"ns2", "wl", "uid"}, {"ns/wl", "", "uid"}, {"ns", "wl", "uid2"}} {
+		if sc.TraceID() == WorkloadSpanContext(other[0], other[1], types.UID(other[2])).TraceID() {
+			t.Errorf("Workload %s/%s with UID %s shares the lifecycle of ns/wl", other[0], other[1], other[2])
+		}
+	}
+}
+
+func TestWithWorkload(t *testing.T) {
+	admitted := utiltesting.MakeWorkload("wl", "ns").Queue("lq").ReserveQuota(utiltesting.MakeAdmission("cq").Obj()).Obj()
+	admitted.UID = "uid"
+	cases := map[string]struct {
+		cqName    string
+		wantAttrs []attribute.KeyValue
+	}{
+		"cluster queue from the admission": {
+			wantAttrs: []attribute.KeyValue{
+				WorkloadNameKey.String("wl"),
+				WorkloadNamespaceKey.String("ns"),
+				LocalQueueKey.String("lq"),
+				ClusterQueueKey.String("cq"),
+			},
+		},
+		"explicit cluster queue": {
+			cqName: "other-cq",
+			wantAttrs: []attribute.KeyValue{
+				WorkloadNameKey.String("wl"),
+				WorkloadNamespaceKey.String("ns"),
+				LocalQueueKey.String("lq"),
+				ClusterQueueKey.String("other-cq"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tp := sdktrace.NewTracerProvider()
+			_, span := tp.Tracer("test").Start(context.Background(), "span", WithWorkload(admitted, tc.cqName)...)
+			span.End()
+			ro := span.(sdktrace.ReadOnlySpan)
+			if diff := cmp.Diff(tc.wantAttrs, ro.Attributes(), cmp.AllowUnexported(attribute.Value{})); diff != "" {
+				t.Errorf("Unexpected attributes (-want,+got):\n%s", diff)
+			}
+			if links := ro.Links(); len(links) != 1 || !links[0].SpanContext.Equal(WorkloadSpanContext("ns", "wl", "uid")) {
+				t.Errorf("Unexpected links %v", links)
+			}
+		})
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var gotPath string
+	var got coltracepb.ExportTraceServiceRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Reading request: %v", err)
+		}
+		if err := proto.Unmarshal(body, &got); err != nil {
+			t.Errorf("Decoding request: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	exporter, err := NewOTLPExporter(ctx, strings.TrimPrefix(srv.URL, "http://"), true)
+	if err != nil {
+		t.Fatalf("Creating the exporter: %v", err)
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	wl := utiltesting.MakeWorkload("wl", "ns").Obj()
+	wl.UID = "uid"
+	_, span := tp.Tracer(instrumentationName).Start(ctx, "span", WithWorkload(wl, "cq")...)
+	span.End()
+	if err := tp.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutting down the tracer provider: %v", err)
+	}
+
+	if gotPath != "/v1/traces" {
+		t.Errorf("Unexpected path %q", gotPath)
+	}
+	if len(got.ResourceSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans) != 1 || len(got.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+		t.Fatalf("Unexpected spans %v", got.ResourceSpans)
+	}
+	gotSpan := got.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if gotSpan.Name != "span" {
+		t.Errorf("Unexpected span name %q", gotSpan.Name)
+	}
+	wantLink := WorkloadSpanContext("ns", "wl", "uid")
+	if len(gotSpan.Links) != 1 || trace.TraceID(gotSpan.Links[0].TraceId) != wantLink.TraceID() || trace.SpanID(gotSpan.Links[0].SpanId) != wantLink.SpanID() {
+		t.Errorf("Unexpected links %v", gotSpan.Links)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissioncheck
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+// FlavorsByCheck returns the AdmissionChecks required by the ClusterQueue,
+// with the ResourceFlavors that each of them applies to. An empty set means
+// that the check applies to all the flavors.
+func FlavorsByCheck(cq *kueue.ClusterQueue) map[string]sets.Set[kueue.ResourceFlavorReference] {
+	if cq.Spec.AdmissionChecksStrategy == nil {
+		checks := make(map[string]sets.Set[kueue.ResourceFlavorReference], len(cq.Spec.AdmissionChecks))
+		for _, name := range cq.Spec.AdmissionChecks {
+			checks[name] = sets.New[kueue.ResourceFlavorReference]()
+		}
+		return checks
+	}
+	rules := cq.Spec.AdmissionChecksStrategy.AdmissionChecks
+	checks := make(map[string]sets.Set[kueue.ResourceFlavorReference], len(rules))
+	for _, rule := range rules {
+		checks[rule.Name] = sets.New(rule.OnFlavors...)
+	}
+	return checks
+}
+
+// CheckNames returns the names of all the AdmissionChecks required by the
+// ClusterQueue, regardless of the flavors.
+func CheckNames(cq *kueue.ClusterQueue) sets.Set[string] {
+	return sets.KeySet(FlavorsByCheck(cq))
+}
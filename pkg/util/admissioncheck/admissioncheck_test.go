@@ -0,0 +1,65 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admissioncheck
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestFlavorsByCheck(t *testing.T) {
+	cases := map[string]struct {
+		cq   *kueue.ClusterQueue
+		want map[string]sets.Set[kueue.ResourceFlavorReference]
+	}{
+		"no checks": {
+			cq:   utiltesting.MakeClusterQueue("cq").Obj(),
+			want: map[string]sets.Set[kueue.ResourceFlavorReference]{},
+		},
+		"admission checks": {
+			cq: utiltesting.MakeClusterQueue("cq").AdmissionChecks("check1", "check2").Obj(),
+			want: map[string]sets.Set[kueue.ResourceFlavorReference]{
+				"check1": sets.New[kueue.ResourceFlavorReference](),
+				"check2": sets.New[kueue.ResourceFlavorReference](),
+			},
+		},
+		"admission checks strategy": {
+			cq: utiltesting.MakeClusterQueue("cq").
+				AdmissionCheckStrategy(
+					kueue.AdmissionCheckStrategyRule{Name: "check1", OnFlavors: []kueue.ResourceFlavorReference{"gpu", "tpu"}},
+					kueue.AdmissionCheckStrategyRule{Name: "check2"},
+				).
+				Obj(),
+			want: map[string]sets.Set[kueue.ResourceFlavorReference]{
+				"check1": sets.New[kueue.ResourceFlavorReference]("gpu", "tpu"),
+				"check2": sets.New[kueue.ResourceFlavorReference](),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, FlavorsByCheck(tc.cq)); diff != "" {
+				t.Errorf("Unexpected checks (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
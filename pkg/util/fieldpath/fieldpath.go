@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fieldpath reads and writes fields of unstructured objects
+// addressed by the subset of JSONPath made of field names and list
+// indexes, for example ".spec.replicaSpecs[0].template".
+package fieldpath
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidPath = errors.New("invalid path")
+	ErrWrongType   = errors.New("wrong type")
+)
+
+type element struct {
+	field string
+	// index is the list index of the element, or -1 for a field.
+	index int
+}
+
+// Path is a parsed field path.
+type Path []element
+
+// Parse parses a path in the dot notation, optionally wrapped in braces,
+// like "{.spec.suspend}" or ".spec.template".
+func Parse(s string) (Path, error) {
+	in := s
+	if strings.HasPrefix(in, "{") && strings.HasSuffix(in, "}") {
+		in = in[1 : len(in)-1]
+	}
+	if !strings.HasPrefix(in, ".") {
+		return nil, fmt.Errorf("%w %q: must start with \".\"", ErrInvalidPath, s)
+	}
+	var p Path
+	for len(in) > 0 {
+		if in[0] != '.' {
+			return nil, fmt.Errorf("%w %q: expected \".\" at %q", ErrInvalidPath, s, in)
+		}
+		in = in[1:]
+		end := strings.IndexAny(in, ".[]")
+		if end == -1 {
+			end = len(in)
+		}
+		if end == 0 {
+			return nil, fmt.Errorf("%w %q: empty field name", ErrInvalidPath, s)
+		}
+		p = append(p, element{field: in[:end], index: -1})
+		in = in[end:]
+		for len(in) > 0 && in[0] == '[' {
+			closing := strings.IndexByte(in, ']')
+			if closing == -1 {
+				return nil, fmt.Errorf("%w %q: unterminated index", ErrInvalidPath, s)
+			}
+			idx, err := strconv.Atoi(in[1:closing])
+			if err != nil || idx < 0 {
+				return nil, fmt.Errorf("%w %q: index %q is not a non-negative integer", ErrInvalidPath, s, in[1:closing])
+			}
+			p = append(p, element{index: idx})
+			in = in[closing+1:]
+		}
+	}
+	return p, nil
+}
+
+// MustParse is like Parse but panics if the path is invalid.
+func MustParse(s string) Path {
+	p, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p Path) String() string {
+	var b strings.Builder
+	for _, e := range p {
+		if e.index < 0 {
+			b.WriteByte('.')
+			b.WriteString(e.field)
+		} else {
+			fmt.Fprintf(&b, "[%d]", e.index)
+		}
+	}
+	return b.String()
+}
+
+// Get returns the value at the path in obj and whether it was found. It
+// returns an error if an intermediate value isn't a map or a list as
+// expected by the path.
+func (p Path) Get(obj map[string]interface{}) (interface{}, bool, error) {
+	var cur interface{} = obj
+	for i, e := range p {
+		next, found, err := p[:i].step(cur, e)
+		if err != nil || !found {
+			return nil, false, err
+		}
+		cur = next
+	}
+	return cur, true, nil
+}
+
+// Set sets the value at the path in obj, creating the missing maps on the
+// way. Missing lists or list items aren't created and cause an error.
+func (p Path) Set(obj map[string]interface{}, value interface{}) error {
+	if len(p) == 0 {
+		return fmt.Errorf("%w: empty path", ErrInvalidPath)
+	}
+	var cur interface{} = obj
+	for i, e := range p[:len(p)-1] {
+		next, found, err := p[:i].step(cur, e)
+		if err != nil {
+			return err
+		}
+		if !found {
+			if e.index >= 0 || p[i+1].index >= 0 {
+				return fmt.Errorf("%s not found", p[:i+1])
+			}
+			next = map[string]interface{}{}
+			cur.(map[string]interface{})[e.field] = next
+		}
+		cur = next
+	}
+	last := p[len(p)-1]
+	if last.index < 0 {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%w: %s is %T, not a map", ErrWrongType, p[:len(p)-1], cur)
+		}
+		m[last.field] = value
+		return nil
+	}
+	l, ok := cur.([]interface{})
+	if !ok {
+		return fmt.Errorf("%w: %s is %T, not a list", ErrWrongType, p[:len(p)-1], cur)
+	}
+	if last.index >= len(l) {
+		return fmt.Errorf("%s not found", p)
+	}
+	l[last.index] = value
+	return nil
+}
+
+// step returns the child e of cur, where prefix is the path of cur.
+func (prefix Path) step(cur interface{}, e element) (interface{}, bool, error) {
+	if e.index < 0 {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("%w: %s is %T, not a map", ErrWrongType, prefix, cur)
+		}
+		v, found := m[e.field]
+		return v, found, nil
+	}
+	l, ok := cur.([]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("%w: %s is %T, not a list", ErrWrongType, prefix, cur)
+	}
+	if e.index >= len(l) {
+		return nil, false, nil
+	}
+	return l[e.index], true, nil
+}
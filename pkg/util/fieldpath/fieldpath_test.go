@@ -0,0 +1,215 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fieldpath
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	cases := map[string]struct {
+		path     string
+		wantErr  bool
+		wantPath string
+	}{
+		"single field": {
+			path:     ".spec",
+			wantPath: ".spec",
+		},
+		"nested fields with braces": {
+			path:     "{.spec.suspend}",
+			wantPath: ".spec.suspend",
+		},
+		"list indexes": {
+			path:     ".spec.groups[1].lists[0][2].template",
+			wantPath: ".spec.groups[1].lists[0][2].template",
+		},
+		"empty": {
+			path:    "",
+			wantErr: true,
+		},
+		"missing leading dot": {
+			path:    "spec.suspend",
+			wantErr: true,
+		},
+		"empty field name": {
+			path:    ".spec..suspend",
+			wantErr: true,
+		},
+		"trailing dot": {
+			path:    ".spec.",
+			wantErr: true,
+		},
+		"negative index": {
+			path:    ".spec.groups[-1]",
+			wantErr: true,
+		},
+		"wildcard index": {
+			path:    ".spec.groups[*]",
+			wantErr: true,
+		},
+		"unterminated index": {
+			path:    ".spec.groups[1",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Parse(tc.path)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Parse(%q) returned error %v, want error: %t", tc.path, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				if !errors.Is(err, ErrInvalidPath) {
+					t.Errorf("Parse(%q) returned error %v, want %v", tc.path, err, ErrInvalidPath)
+				}
+				return
+			}
+			if got.String() != tc.wantPath {
+				t.Errorf("Parse(%q).String() = %q, want %q", tc.path, got.String(), tc.wantPath)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+	obj := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"suspend": true,
+			"groups": []interface{}{
+				map[string]interface{}{"replicas": int64(2)},
+			},
+		},
+	}
+	cases := map[string]struct {
+		path      string
+		want      interface{}
+		wantFound bool
+		wantErr   error
+	}{
+		"field": {
+			path:      ".spec.suspend",
+			want:      true,
+			wantFound: true,
+		},
+		"list item field": {
+			path:      ".spec.groups[0].replicas",
+			want:      int64(2),
+			wantFound: true,
+		},
+		"missing field": {
+			path: ".status.active",
+		},
+		"index out of range": {
+			path: ".spec.groups[1].replicas",
+		},
+		"field of a list": {
+			path:    ".spec.groups.replicas",
+			wantErr: ErrWrongType,
+		},
+		"index of a map": {
+			path:    ".spec[0]",
+			wantErr: ErrWrongType,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, found, err := MustParse(tc.path).Get(obj)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Get returned error %v, want %v", err, tc.wantErr)
+			}
+			if found != tc.wantFound {
+				t.Errorf("Get returned found=%t, want %t", found, tc.wantFound)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Unexpected value (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	cases := map[string]struct {
+		obj     map[string]interface{}
+		path    string
+		value   interface{}
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		"existing field": {
+			obj:   map[string]interface{}{"spec": map[string]interface{}{"suspend": true}},
+			path:  ".spec.suspend",
+			value: false,
+			want:  map[string]interface{}{"spec": map[string]interface{}{"suspend": false}},
+		},
+		"missing maps are created": {
+			obj:   map[string]interface{}{},
+			path:  ".spec.runPolicy.suspend",
+			value: true,
+			want: map[string]interface{}{
+				"spec": map[string]interface{}{"runPolicy": map[string]interface{}{"suspend": true}},
+			},
+		},
+		"list item": {
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{"groups": []interface{}{"a", "b"}},
+			},
+			path:  ".spec.groups[1]",
+			value: "c",
+			want: map[string]interface{}{
+				"spec": map[string]interface{}{"groups": []interface{}{"a", "c"}},
+			},
+		},
+		"missing list": {
+			obj:     map[string]interface{}{"spec": map[string]interface{}{}},
+			path:    ".spec.groups[0].template",
+			value:   map[string]interface{}{},
+			wantErr: true,
+		},
+		"list item out of range": {
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{"groups": []interface{}{"a"}},
+			},
+			path:    ".spec.groups[1]",
+			value:   "b",
+			wantErr: true,
+		},
+		"field of a scalar": {
+			obj:     map[string]interface{}{"spec": "value"},
+			path:    ".spec.suspend",
+			value:   true,
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := MustParse(tc.path).Set(tc.obj, tc.value)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Set returned error %v, want error: %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, tc.obj); diff != "" {
+				t.Errorf("Unexpected object (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
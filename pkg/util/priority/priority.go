@@ -19,6 +19,7 @@ package priority
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
@@ -36,6 +37,18 @@ func Priority(w *kueue.Workload) int32 {
 	return ptr.Deref(w.Spec.Priority, constants.DefaultPriority)
 }
 
+// CanPreempt returns whether the given workload is allowed to preempt
+// workloads with lower priority.
+func CanPreempt(w *kueue.Workload) bool {
+	return ptr.Deref(w.Spec.PreemptionPolicy, corev1.PreemptLowerPriority) != corev1.PreemptNever
+}
+
+// IsProtectedFrom returns whether the given workload is protected from being
+// preempted by the preemptor because of its lower priority.
+func IsProtectedFrom(w, preemptor *kueue.Workload) bool {
+	return w.Spec.Protected && Priority(w) > Priority(preemptor)
+}
+
 // GetPriorityFromPriorityClass returns the priority populated from
 // priority class. If not specified, priority will be default or
 // zero if there is no default.
@@ -66,6 +79,34 @@ func GetPriorityFromWorkloadPriorityClass(ctx context.Context, client client.Cli
 	return wpc.Name, constants.WorkloadPriorityClassSource, wpc.Value, nil
 }
 
+// GetPreemptionFromWorkloadPriorityClass returns the preemption policy and
+// whether the workloads are protected from preemption, populated from the
+// workload priority class.
+func GetPreemptionFromWorkloadPriorityClass(ctx context.Context, client client.Client,
+	workloadPriorityClass string) (*corev1.PreemptionPolicy, bool, error) {
+	wpc := &kueue.WorkloadPriorityClass{}
+	if err := client.Get(ctx, types.NamespacedName{Name: workloadPriorityClass}, wpc); err != nil {
+		return nil, false, err
+	}
+	return wpc.PreemptionPolicy, wpc.Protected, nil
+}
+
+// SetFromWorkloadPriorityClass sets the priority class of the workload and the
+// fields populated from it, returning whether the workload changed.
+func SetFromWorkloadPriorityClass(w *kueue.Workload, wpc *kueue.WorkloadPriorityClass) bool {
+	changed := w.Spec.PriorityClassName != wpc.Name ||
+		w.Spec.PriorityClassSource != constants.WorkloadPriorityClassSource ||
+		Priority(w) != wpc.Value || w.Spec.Priority == nil ||
+		!ptr.Equal(w.Spec.PreemptionPolicy, wpc.PreemptionPolicy) ||
+		w.Spec.Protected != wpc.Protected
+	w.Spec.PriorityClassName = wpc.Name
+	w.Spec.PriorityClassSource = constants.WorkloadPriorityClassSource
+	w.Spec.Priority = ptr.To(wpc.Value)
+	w.Spec.PreemptionPolicy = wpc.PreemptionPolicy
+	w.Spec.Protected = wpc.Protected
+	return changed
+}
+
 func getDefaultPriority(ctx context.Context, client client.Client) (string, string, int32, error) {
 	dpc, err := getDefaultPriorityClass(ctx, client)
 	if err != nil {
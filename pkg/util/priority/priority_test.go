@@ -21,10 +21,12 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
 	schedulingv1 "k8s.io/api/scheduling/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
@@ -226,3 +228,92 @@ func TestGetPriorityFromWorkloadPriorityClass(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPreemptionFromWorkloadPriorityClass(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kueue.AddToScheme(scheme); err != nil {
+		t.Fatalf("Failed adding kueue scheme: %v", err)
+	}
+
+	tests := map[string]struct {
+		workloadPriorityClassList *kueue.WorkloadPriorityClassList
+		workloadPriorityClassName string
+		wantPreemptionPolicy      *corev1.PreemptionPolicy
+		wantProtected             bool
+		wantErr                   error
+	}{
+		"workloadPriorityClass without preemption settings": {
+			workloadPriorityClassList: &kueue.WorkloadPriorityClassList{
+				Items: []kueue.WorkloadPriorityClass{
+					*utiltesting.MakeWorkloadPriorityClass("test").PriorityValue(50).Obj(),
+				},
+			},
+			workloadPriorityClassName: "test",
+		},
+		"workloadPriorityClass with preemption settings": {
+			workloadPriorityClassList: &kueue.WorkloadPriorityClassList{
+				Items: []kueue.WorkloadPriorityClass{
+					*utiltesting.MakeWorkloadPriorityClass("test").
+						PriorityValue(50).
+						PreemptionPolicy(corev1.PreemptNever).
+						Protected().
+						Obj(),
+				},
+			},
+			workloadPriorityClassName: "test",
+			wantPreemptionPolicy:      ptr.To(corev1.PreemptNever),
+			wantProtected:             true,
+		},
+		"workloadPriorityClass does not exist": {
+			workloadPriorityClassList: &kueue.WorkloadPriorityClassList{},
+			workloadPriorityClassName: "test",
+			wantErr:                   apierrors.NewNotFound(kueue.Resource("workloadpriorityclasses"), "test"),
+		},
+	}
+
+	for desc, tt := range tests {
+		tt := tt
+		t.Run(desc, func(t *testing.T) {
+			t.Parallel()
+
+			client := fake.NewClientBuilder().WithScheme(scheme).WithLists(tt.workloadPriorityClassList).Build()
+
+			preemptionPolicy, protected, err := GetPreemptionFromWorkloadPriorityClass(context.Background(), client, tt.workloadPriorityClassName)
+			if diff := cmp.Diff(tt.wantErr, err); diff != "" {
+				t.Errorf("unexpected error (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantPreemptionPolicy, preemptionPolicy); diff != "" {
+				t.Errorf("unexpected preemptionPolicy (-want,+got):\n%s", diff)
+			}
+			if protected != tt.wantProtected {
+				t.Errorf("unexpected protected: got: %t, expected: %t", protected, tt.wantProtected)
+			}
+		})
+	}
+}
+
+func TestIsProtectedFrom(t *testing.T) {
+	preemptor := utiltesting.MakeWorkload("preemptor", "").Priority(10).Obj()
+	tests := map[string]struct {
+		workload *kueue.Workload
+		want     bool
+	}{
+		"not protected": {
+			workload: utiltesting.MakeWorkload("wl", "").Priority(20).Obj(),
+		},
+		"protected with higher priority": {
+			workload: utiltesting.MakeWorkload("wl", "").Priority(20).Protected().Obj(),
+			want:     true,
+		},
+		"protected with the same priority": {
+			workload: utiltesting.MakeWorkload("wl", "").Priority(10).Protected().Obj(),
+		},
+	}
+	for desc, tt := range tests {
+		t.Run(desc, func(t *testing.T) {
+			if got := IsProtectedFrom(tt.workload, preemptor); got != tt.want {
+				t.Errorf("IsProtectedFrom() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
@@ -75,6 +75,11 @@ func (w *WorkloadWrapper) Obj() *kueue.Workload {
 	return &w.Workload
 }
 
+// Clone returns deep copy of the Workload.
+func (w *WorkloadWrapper) Clone() *WorkloadWrapper {
+	return &WorkloadWrapper{Workload: *w.DeepCopy()}
+}
+
 func (w *WorkloadWrapper) Finalizers(fin ...string) *WorkloadWrapper {
 	w.ObjectMeta.Finalizers = fin
 	return w
@@ -156,6 +161,21 @@ func (w *WorkloadWrapper) PriorityClassSource(source string) *WorkloadWrapper {
 	return w
 }
 
+func (w *WorkloadWrapper) PreemptionPolicy(policy corev1.PreemptionPolicy) *WorkloadWrapper {
+	w.Spec.PreemptionPolicy = &policy
+	return w
+}
+
+func (w *WorkloadWrapper) Protected() *WorkloadWrapper {
+	w.Spec.Protected = true
+	return w
+}
+
+func (w *WorkloadWrapper) PodSetFlavorGroups(groups ...kueue.PodSetFlavorGroup) *WorkloadWrapper {
+	w.Spec.PodSetFlavorGroups = groups
+	return w
+}
+
 func (w *WorkloadWrapper) PodSets(podSets ...kueue.PodSet) *WorkloadWrapper {
 	w.Spec.PodSets = podSets
 	return w
@@ -306,6 +326,24 @@ func (p *PodSetWrapper) PodOverHead(resources corev1.ResourceList) *PodSetWrappe
 	return p
 }
 
+// ResourceClaimTemplate adds a pod resource claim created from the template.
+func (p *PodSetWrapper) ResourceClaimTemplate(name, template string) *PodSetWrapper {
+	p.Template.Spec.ResourceClaims = append(p.Template.Spec.ResourceClaims, corev1.PodResourceClaim{
+		Name:   name,
+		Source: corev1.ClaimSource{ResourceClaimTemplateName: ptr.To(template)},
+	})
+	return p
+}
+
+// ResourceClaim adds a pod resource claim referencing an existing ResourceClaim.
+func (p *PodSetWrapper) ResourceClaim(name, claim string) *PodSetWrapper {
+	p.Template.Spec.ResourceClaims = append(p.Template.Spec.ResourceClaims, corev1.PodResourceClaim{
+		Name:   name,
+		Source: corev1.ClaimSource{ResourceClaimName: ptr.To(claim)},
+	})
+	return p
+}
+
 // AdmissionWrapper wraps an Admission
 type AdmissionWrapper struct{ kueue.Admission }
 
@@ -453,6 +491,12 @@ func (c *ClusterQueueWrapper) AdmissionChecks(checks ...string) *ClusterQueueWra
 	return c
 }
 
+// AdmissionCheckStrategy replaces the queue admission checks strategy
+func (c *ClusterQueueWrapper) AdmissionCheckStrategy(rules ...kueue.AdmissionCheckStrategyRule) *ClusterQueueWrapper {
+	c.Spec.AdmissionChecksStrategy = &kueue.AdmissionChecksStrategy{AdmissionChecks: rules}
+	return c
+}
+
 // QueueingStrategy sets the queueing strategy in this ClusterQueue.
 func (c *ClusterQueueWrapper) QueueingStrategy(strategy kueue.QueueingStrategy) *ClusterQueueWrapper {
 	c.Spec.QueueingStrategy = strategy
@@ -471,12 +515,28 @@ func (c *ClusterQueueWrapper) Preemption(p kueue.ClusterQueuePreemption) *Cluste
 	return c
 }
 
+// WaitForPodsReady overrides the waitForPodsReady configuration.
+func (c *ClusterQueueWrapper) WaitForPodsReady(enable bool, timeout time.Duration, blockAdmission bool) *ClusterQueueWrapper {
+	c.Spec.WaitForPodsReady = &kueue.ClusterQueueWaitForPodsReady{
+		Enable:         enable,
+		Timeout:        &metav1.Duration{Duration: timeout},
+		BlockAdmission: &blockAdmission,
+	}
+	return c
+}
+
 // Preemption sets the preeemption policies.
 func (c *ClusterQueueWrapper) FlavorFungibility(p kueue.FlavorFungibility) *ClusterQueueWrapper {
 	c.Spec.FlavorFungibility = &p
 	return c
 }
 
+// QuotaShrinkPolicy sets the policy applied when the quota is reduced.
+func (c *ClusterQueueWrapper) QuotaShrinkPolicy(p kueue.QuotaShrinkPolicy) *ClusterQueueWrapper {
+	c.Spec.QuotaShrinkPolicy = p
+	return c
+}
+
 // FlavorQuotasWrapper wraps a FlavorQuotas object.
 type FlavorQuotasWrapper struct{ kueue.FlavorQuotas }
 
@@ -509,6 +569,66 @@ func (f *FlavorQuotasWrapper) Resource(name corev1.ResourceName, qs ...string) *
 	return f
 }
 
+// ResourcePercentage adds a resource whose nominal quota is a percentage of
+// the capacity of the flavor.
+func (f *FlavorQuotasWrapper) ResourcePercentage(name corev1.ResourceName, percentage int32) *FlavorQuotasWrapper {
+	f.Resources = append(f.Resources, kueue.ResourceQuota{
+		Name:                   name,
+		NominalQuotaPercentage: ptr.To(percentage),
+	})
+	return f
+}
+
+// Schedule adds a quota schedule.
+func (f *FlavorQuotasWrapper) Schedule(schedule kueue.QuotaSchedule) *FlavorQuotasWrapper {
+	f.Schedules = append(f.Schedules, schedule)
+	return f
+}
+
+// QuotaScheduleWrapper wraps a QuotaSchedule.
+type QuotaScheduleWrapper struct{ kueue.QuotaSchedule }
+
+// MakeQuotaSchedule creates a wrapper for a quota schedule with a daily
+// window between the given times of the day.
+func MakeQuotaSchedule(name, startTime, endTime string) *QuotaScheduleWrapper {
+	return &QuotaScheduleWrapper{kueue.QuotaSchedule{
+		Name:      name,
+		StartTime: startTime,
+		EndTime:   endTime,
+	}}
+}
+
+// Obj returns the inner QuotaSchedule.
+func (s *QuotaScheduleWrapper) Obj() *kueue.QuotaSchedule {
+	return &s.QuotaSchedule
+}
+
+// DaysOfWeek sets the days on which the window starts.
+func (s *QuotaScheduleWrapper) DaysOfWeek(days ...kueue.DayOfWeek) *QuotaScheduleWrapper {
+	s.QuotaSchedule.DaysOfWeek = days
+	return s
+}
+
+// TimeZone sets the time zone of the window.
+func (s *QuotaScheduleWrapper) TimeZone(tz string) *QuotaScheduleWrapper {
+	s.QuotaSchedule.TimeZone = &tz
+	return s
+}
+
+// Resource adds the nominal quota and, optionally, the borrowing limit of a
+// resource.
+func (s *QuotaScheduleWrapper) Resource(name corev1.ResourceName, nominal string, borrowingLimit ...string) *QuotaScheduleWrapper {
+	rq := kueue.ScheduledResourceQuota{
+		Name:         name,
+		NominalQuota: resource.MustParse(nominal),
+	}
+	if len(borrowingLimit) > 0 {
+		rq.BorrowingLimit = ptr.To(resource.MustParse(borrowingLimit[0]))
+	}
+	s.Resources = append(s.Resources, rq)
+	return s
+}
+
 // ResourceFlavorWrapper wraps a ResourceFlavor.
 type ResourceFlavorWrapper struct{ kueue.ResourceFlavor }
 
@@ -547,6 +667,27 @@ func (rf *ResourceFlavorWrapper) Toleration(t corev1.Toleration) *ResourceFlavor
 	return rf
 }
 
+// Capacity sets the capacity in the status of the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) Capacity(r corev1.ResourceName, v string) *ResourceFlavorWrapper {
+	if rf.Status.Capacity == nil {
+		rf.Status.Capacity = make(corev1.ResourceList)
+	}
+	rf.Status.Capacity[r] = resource.MustParse(v)
+	return rf
+}
+
+// NodeCount sets the number of nodes in the status of the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) NodeCount(n int32) *ResourceFlavorWrapper {
+	rf.Status.NodeCount = n
+	return rf
+}
+
+// PodTemplateOverrides sets the pod template overrides of the ResourceFlavor.
+func (rf *ResourceFlavorWrapper) PodTemplateOverrides(o *kueue.PodTemplateOverrides) *ResourceFlavorWrapper {
+	rf.Spec.PodTemplateOverrides = o
+	return rf
+}
+
 // RuntimeClassWrapper wraps a RuntimeClass.
 type RuntimeClassWrapper struct{ nodev1.RuntimeClass }
 
@@ -685,7 +826,65 @@ func (p *WorkloadPriorityClassWrapper) PriorityValue(v int32) *WorkloadPriorityC
 	return p
 }
 
+// PreemptionPolicy updates preemptionPolicy of WorkloadPriorityClass.
+func (p *WorkloadPriorityClassWrapper) PreemptionPolicy(policy corev1.PreemptionPolicy) *WorkloadPriorityClassWrapper {
+	p.WorkloadPriorityClass.PreemptionPolicy = &policy
+	return p
+}
+
+// Protected marks the WorkloadPriorityClass as protected from preemption.
+func (p *WorkloadPriorityClassWrapper) Protected() *WorkloadPriorityClassWrapper {
+	p.WorkloadPriorityClass.Protected = true
+	return p
+}
+
 // Obj returns the inner WorkloadPriorityClass.
 func (p *WorkloadPriorityClassWrapper) Obj() *kueue.WorkloadPriorityClass {
 	return &p.WorkloadPriorityClass
 }
+
+// ReservationWrapper wraps a Reservation.
+type ReservationWrapper struct {
+	kueue.Reservation
+}
+
+// MakeReservation creates a wrapper for a Reservation of the ClusterQueue,
+// starting at the given time.
+func MakeReservation(name, cq string, start time.Time, duration time.Duration) *ReservationWrapper {
+	return &ReservationWrapper{kueue.Reservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: kueue.ReservationSpec{
+			ClusterQueue: kueue.ClusterQueueReference(cq),
+			StartTime:    metav1.NewTime(start),
+			Duration:     metav1.Duration{Duration: duration},
+		},
+	}}
+}
+
+// Flavor adds the reserved resources of a flavor to the Reservation.
+func (r *ReservationWrapper) Flavor(name string, resources corev1.ResourceList) *ReservationWrapper {
+	r.Spec.Flavors = append(r.Spec.Flavors, kueue.ReservedFlavor{
+		Name:      kueue.ResourceFlavorReference(name),
+		Resources: resources,
+	})
+	return r
+}
+
+// HoldPeriod sets the hold period of the Reservation.
+func (r *ReservationWrapper) HoldPeriod(d time.Duration) *ReservationWrapper {
+	r.Spec.HoldPeriod = &metav1.Duration{Duration: d}
+	return r
+}
+
+// Phase sets the phase in the status of the Reservation.
+func (r *ReservationWrapper) Phase(phase kueue.ReservationPhase) *ReservationWrapper {
+	r.Status.Phase = phase
+	return r
+}
+
+// Obj returns the inner Reservation.
+func (r *ReservationWrapper) Obj() *kueue.Reservation {
+	return &r.Reservation
+}
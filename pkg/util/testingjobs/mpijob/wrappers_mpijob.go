@@ -101,6 +101,15 @@ func (j *MPIJobWrapper) WorkloadPriorityClass(wpc string) *MPIJobWrapper {
 	return j
 }
 
+// Annotation sets an annotation on the job.
+func (j *MPIJobWrapper) Annotation(k, v string) *MPIJobWrapper {
+	if j.Annotations == nil {
+		j.Annotations = make(map[string]string)
+	}
+	j.Annotations[k] = v
+	return j
+}
+
 // Obj returns the inner Job.
 func (j *MPIJobWrapper) Obj() *kubeflow.MPIJob {
 	return &j.MPIJob
@@ -156,3 +165,21 @@ func (j *MPIJobWrapper) PodLabel(replicaType kubeflow.MPIReplicaType, k, v strin
 	j.Spec.MPIReplicaSpecs[replicaType].Template.Labels[k] = v
 	return j
 }
+
+// MinAvailable sets the minimum number of pods, launcher included, of the job.
+func (j *MPIJobWrapper) MinAvailable(m int32) *MPIJobWrapper {
+	if j.Spec.RunPolicy.SchedulingPolicy == nil {
+		j.Spec.RunPolicy.SchedulingPolicy = &kubeflow.SchedulingPolicy{}
+	}
+	j.Spec.RunPolicy.SchedulingPolicy.MinAvailable = ptr.To(m)
+	return j
+}
+
+// StatusSucceeded sets the number of succeeded pods of the replicaType.
+func (j *MPIJobWrapper) StatusSucceeded(replicaType kubeflow.MPIReplicaType, succeeded int32) *MPIJobWrapper {
+	if j.Status.ReplicaStatuses == nil {
+		j.Status.ReplicaStatuses = make(map[kubeflow.MPIReplicaType]*kubeflow.ReplicaStatus)
+	}
+	j.Status.ReplicaStatuses[replicaType] = &kubeflow.ReplicaStatus{Succeeded: succeeded}
+	return j
+}
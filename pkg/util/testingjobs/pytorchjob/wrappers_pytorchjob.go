@@ -155,3 +155,21 @@ func (j *PyTorchJobWrapper) PodLabel(replicaType kftraining.ReplicaType, k, v st
 	j.Spec.PyTorchReplicaSpecs[replicaType].Template.Labels[k] = v
 	return j
 }
+
+// MinReplicas sets the minReplicas of the elastic policy of the job.
+func (j *PyTorchJobWrapper) MinReplicas(m int32) *PyTorchJobWrapper {
+	if j.Spec.ElasticPolicy == nil {
+		j.Spec.ElasticPolicy = &kftraining.ElasticPolicy{}
+	}
+	j.Spec.ElasticPolicy.MinReplicas = ptr.To(m)
+	return j
+}
+
+// StatusSucceeded sets the number of succeeded pods of the replicaType.
+func (j *PyTorchJobWrapper) StatusSucceeded(replicaType kftraining.ReplicaType, succeeded int32) *PyTorchJobWrapper {
+	if j.Status.ReplicaStatuses == nil {
+		j.Status.ReplicaStatuses = make(map[kftraining.ReplicaType]*kftraining.ReplicaStatus)
+	}
+	j.Status.ReplicaStatuses[replicaType] = &kftraining.ReplicaStatus{Succeeded: succeeded}
+	return j
+}
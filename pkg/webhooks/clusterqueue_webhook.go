@@ -18,6 +18,8 @@ package webhooks
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -27,25 +29,36 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/workload"
 )
 
 const (
 	isNegativeErrorMsg string = `must be greater than or equal to 0`
+	timeOfDayErrorMsg  string = `must be a time of the day in the 24-hour HH:MM format`
+
+	timeOfDayLayout = "15:04"
 )
 
-type ClusterQueueWebhook struct{}
+type ClusterQueueWebhook struct {
+	resources *configapi.Resources
+}
 
-func setupWebhookForClusterQueue(mgr ctrl.Manager) error {
+func setupWebhookForClusterQueue(mgr ctrl.Manager, opts options) error {
+	wh := &ClusterQueueWebhook{
+		resources: opts.resources,
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kueue.ClusterQueue{}).
-		WithDefaulter(&ClusterQueueWebhook{}).
-		WithValidator(&ClusterQueueWebhook{}).
+		WithDefaulter(wh).
+		WithValidator(wh).
 		Complete()
 }
 
@@ -86,7 +99,7 @@ func (w *ClusterQueueWebhook) ValidateCreate(ctx context.Context, obj runtime.Ob
 	log := ctrl.LoggerFrom(ctx).WithName("clusterqueue-webhook")
 	log.V(5).Info("Validating create", "clusterQueue", klog.KObj(cq))
 	allErrs := ValidateClusterQueue(cq)
-	return nil, allErrs.ToAggregate()
+	return w.resourcesWarnings(cq), allErrs.ToAggregate()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -97,7 +110,7 @@ func (w *ClusterQueueWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj
 	log := ctrl.LoggerFrom(ctx).WithName("clusterqueue-webhook")
 	log.V(5).Info("Validating update", "clusterQueue", klog.KObj(newCQ))
 	allErrs := ValidateClusterQueueUpdate(newCQ, oldCQ)
-	return nil, allErrs.ToAggregate()
+	return w.resourcesWarnings(newCQ), allErrs.ToAggregate()
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
@@ -105,6 +118,33 @@ func (w *ClusterQueueWebhook) ValidateDelete(ctx context.Context, obj runtime.Ob
 	return nil, nil
 }
 
+// resourcesWarnings returns a warning for each covered resource that the
+// workloads never request, because it's excluded or replaced by a
+// transformation in the configuration.
+func (w *ClusterQueueWebhook) resourcesWarnings(cq *kueue.ClusterQueue) admission.Warnings {
+	if w.resources == nil {
+		return nil
+	}
+	replaced := sets.New[corev1.ResourceName]()
+	for _, t := range w.resources.Transformations {
+		if ptr.Deref(t.Strategy, configapi.RetainResourceTransformation) == configapi.ReplaceResourceTransformation {
+			replaced.Insert(t.Input)
+		}
+	}
+	var warnings admission.Warnings
+	for i, rg := range cq.Spec.ResourceGroups {
+		for _, name := range rg.CoveredResources {
+			switch {
+			case workload.IsExcludedResource(w.resources.ExcludeResourcePrefixes, name):
+				warnings = append(warnings, fmt.Sprintf("resource %s covered by resourceGroups[%d] is excluded from the requests of the workloads", name, i))
+			case replaced.Has(name):
+				warnings = append(warnings, fmt.Sprintf("resource %s covered by resourceGroups[%d] is replaced by other resources in the requests of the workloads", name, i))
+			}
+		}
+	}
+	return warnings
+}
+
 func ValidateClusterQueue(cq *kueue.ClusterQueue) field.ErrorList {
 	path := field.NewPath("spec")
 
@@ -115,7 +155,33 @@ func ValidateClusterQueue(cq *kueue.ClusterQueue) field.ErrorList {
 	allErrs = append(allErrs, validateResourceGroups(cq.Spec.ResourceGroups, path.Child("resourceGroups"))...)
 	allErrs = append(allErrs,
 		validation.ValidateLabelSelector(cq.Spec.NamespaceSelector, validation.LabelSelectorValidationOptions{}, path.Child("namespaceSelector"))...)
+	allErrs = append(allErrs, validateAdmissionChecksStrategy(&cq.Spec, path)...)
+	allErrs = append(allErrs, validateWaitForPodsReady(cq.Spec.WaitForPodsReady, path.Child("waitForPodsReady"))...)
+
+	return allErrs
+}
 
+func validateWaitForPodsReady(wfpr *kueue.ClusterQueueWaitForPodsReady, path *field.Path) field.ErrorList {
+	if wfpr == nil || wfpr.Timeout == nil || wfpr.Timeout.Duration >= 0 {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(path.Child("timeout"), wfpr.Timeout.String(), isNegativeErrorMsg)}
+}
+
+func validateAdmissionChecksStrategy(spec *kueue.ClusterQueueSpec, path *field.Path) field.ErrorList {
+	if spec.AdmissionChecksStrategy == nil {
+		return nil
+	}
+	strategyPath := path.Child("admissionChecksStrategy")
+	var allErrs field.ErrorList
+	if len(spec.AdmissionChecks) > 0 {
+		allErrs = append(allErrs, field.Invalid(strategyPath, spec.AdmissionChecksStrategy, "must not be used along with admissionChecks"))
+	}
+	for i, rule := range spec.AdmissionChecksStrategy.AdmissionChecks {
+		for j, flavor := range rule.OnFlavors {
+			allErrs = append(allErrs, validateNameReference(string(flavor), strategyPath.Child("admissionChecks").Index(i).Child("onFlavors").Index(j))...)
+		}
+	}
 	return allErrs
 }
 
@@ -178,6 +244,41 @@ func validateFlavorQuotas(flavorQuotas kueue.FlavorQuotas, coveredResources []co
 			allErrs = append(allErrs, validateResourceQuantity(*rq.BorrowingLimit, path.Child("borrowingLimit"))...)
 		}
 	}
+	allErrs = append(allErrs, validateQuotaSchedules(&flavorQuotas, path.Child("schedules"))...)
+	return allErrs
+}
+
+func validateQuotaSchedules(flavorQuotas *kueue.FlavorQuotas, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	flavorResources := sets.New[corev1.ResourceName]()
+	for _, rq := range flavorQuotas.Resources {
+		flavorResources.Insert(rq.Name)
+	}
+	for i, schedule := range flavorQuotas.Schedules {
+		path := path.Index(i)
+		allErrs = append(allErrs, validateNameReference(schedule.Name, path.Child("name"))...)
+		if _, err := time.Parse(timeOfDayLayout, schedule.StartTime); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("startTime"), schedule.StartTime, timeOfDayErrorMsg))
+		}
+		if _, err := time.Parse(timeOfDayLayout, schedule.EndTime); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("endTime"), schedule.EndTime, timeOfDayErrorMsg))
+		}
+		if schedule.TimeZone != nil {
+			if _, err := time.LoadLocation(*schedule.TimeZone); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), *schedule.TimeZone, err.Error()))
+			}
+		}
+		for j, rq := range schedule.Resources {
+			path := path.Child("resources").Index(j)
+			if !flavorResources.Has(rq.Name) {
+				allErrs = append(allErrs, field.Invalid(path.Child("name"), rq.Name, "must be one of the resources of the flavor"))
+			}
+			allErrs = append(allErrs, validateResourceQuantity(rq.NominalQuota, path.Child("nominalQuota"))...)
+			if rq.BorrowingLimit != nil {
+				allErrs = append(allErrs, validateResourceQuantity(*rq.BorrowingLimit, path.Child("borrowingLimit"))...)
+			}
+		}
+	}
 	return allErrs
 }
 
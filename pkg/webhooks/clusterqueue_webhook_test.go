@@ -17,14 +17,20 @@ limitations under the License.
 package webhooks
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	testingutil "sigs.k8s.io/kueue/pkg/util/testing"
 )
@@ -53,6 +59,49 @@ func TestValidateClusterQueue(t *testing.T) {
 				field.Invalid(resourceGroupsPath.Index(0).Child("coveredResources").Index(0), "@cpu", ""),
 			},
 		},
+		{
+			name: "waitForPodsReady with timeout",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				WaitForPodsReady(true, time.Minute, true).
+				Obj(),
+		},
+		{
+			name: "waitForPodsReady with negative timeout",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				WaitForPodsReady(true, -time.Minute, true).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("waitForPodsReady", "timeout"), nil, ""),
+			},
+		},
+		{
+			name: "admission checks strategy",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				AdmissionCheckStrategy(
+					kueue.AdmissionCheckStrategyRule{Name: "check1", OnFlavors: []kueue.ResourceFlavorReference{"gpu"}},
+					kueue.AdmissionCheckStrategyRule{Name: "check2"},
+				).
+				Obj(),
+		},
+		{
+			name: "admission checks and admission checks strategy",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				AdmissionChecks("check1").
+				AdmissionCheckStrategy(kueue.AdmissionCheckStrategyRule{Name: "check2"}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("admissionChecksStrategy"), nil, ""),
+			},
+		},
+		{
+			name: "invalid flavor in admission checks strategy",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				AdmissionCheckStrategy(kueue.AdmissionCheckStrategyRule{Name: "check1", OnFlavors: []kueue.ResourceFlavorReference{"@gpu"}}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(specPath.Child("admissionChecksStrategy", "admissionChecks").Index(0).Child("onFlavors").Index(0), "@gpu", ""),
+			},
+		},
 		{
 			name:         "in cohort",
 			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").Cohort("prod").Obj(),
@@ -291,6 +340,39 @@ func TestValidateClusterQueue(t *testing.T) {
 				field.Duplicate(resourceGroupsPath.Index(1).Child("coveredResources").Index(0), nil),
 			},
 		},
+		{
+			name: "valid quota schedule",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*testingutil.MakeFlavorQuotas("default").
+					Resource("cpu", "10").
+					Schedule(*testingutil.MakeQuotaSchedule("night", "20:00", "08:00").
+						DaysOfWeek("Monday", "Friday").
+						TimeZone("Europe/Madrid").
+						Resource("cpu", "2", "0").
+						Obj()).
+					Obj()).
+				Obj(),
+		},
+		{
+			name: "invalid quota schedule",
+			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
+				ResourceGroup(*testingutil.MakeFlavorQuotas("default").
+					Resource("cpu", "10").
+					Schedule(*testingutil.MakeQuotaSchedule("invalid_name", "08:60", "24:00").
+						TimeZone("Nowhere/Nothing").
+						Resource("memory", "-1").
+						Obj()).
+					Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("schedules").Index(0).Child("name"), nil, ""),
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("schedules").Index(0).Child("startTime"), nil, ""),
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("schedules").Index(0).Child("endTime"), nil, ""),
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("schedules").Index(0).Child("timeZone"), nil, ""),
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("schedules").Index(0).Child("resources").Index(0).Child("name"), nil, ""),
+				field.Invalid(resourceGroupsPath.Index(0).Child("flavors").Index(0).Child("schedules").Index(0).Child("resources").Index(0).Child("nominalQuota"), nil, ""),
+			},
+		},
 		{
 			name: "flavor in more than one resource group",
 			clusterQueue: testingutil.MakeClusterQueue("cluster-queue").
@@ -350,3 +432,67 @@ func TestValidateClusterQueueUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterQueueResourcesWarnings(t *testing.T) {
+	resources := &configapi.Resources{
+		ExcludeResourcePrefixes: []string{"networking.example.com/"},
+		Transformations: []configapi.ResourceTransformation{
+			{
+				Input:    "example.com/mig",
+				Strategy: ptr.To(configapi.ReplaceResourceTransformation),
+				Outputs:  corev1.ResourceList{"example.com/gpu-memory": resource.MustParse("5Gi")},
+			},
+			{
+				Input:    "example.com/gpu",
+				Strategy: ptr.To(configapi.RetainResourceTransformation),
+				Outputs:  corev1.ResourceList{"example.com/gpu-memory": resource.MustParse("16Gi")},
+			},
+		},
+	}
+	testcases := map[string]struct {
+		resources    *configapi.Resources
+		clusterQueue *kueue.ClusterQueue
+		wantWarnings admission.Warnings
+	}{
+		"no resources configuration": {
+			clusterQueue: testingutil.MakeClusterQueue("cq").
+				ResourceGroup(*testingutil.MakeFlavorQuotas("default").Resource("example.com/mig", "4").Obj()).
+				Obj(),
+		},
+		"requested resources": {
+			resources: resources,
+			clusterQueue: testingutil.MakeClusterQueue("cq").
+				ResourceGroup(*testingutil.MakeFlavorQuotas("default").
+					Resource("example.com/gpu", "4").
+					Resource("example.com/gpu-memory", "64Gi").
+					Obj()).
+				Obj(),
+		},
+		"excluded and replaced resources": {
+			resources: resources,
+			clusterQueue: testingutil.MakeClusterQueue("cq").
+				ResourceGroup(*testingutil.MakeFlavorQuotas("default").Resource(corev1.ResourceCPU, "10").Obj()).
+				ResourceGroup(*testingutil.MakeFlavorQuotas("gpu").
+					Resource("example.com/mig", "4").
+					Resource("networking.example.com/vpc", "10").
+					Obj()).
+				Obj(),
+			wantWarnings: admission.Warnings{
+				"resource example.com/mig covered by resourceGroups[1] is replaced by other resources in the requests of the workloads",
+				"resource networking.example.com/vpc covered by resourceGroups[1] is excluded from the requests of the workloads",
+			},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			wh := &ClusterQueueWebhook{resources: tc.resources}
+			gotWarnings, err := wh.ValidateCreate(context.Background(), tc.clusterQueue)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantWarnings, gotWarnings); diff != "" {
+				t.Errorf("Unexpected warnings (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+)
+
+type ReservationWebhook struct{}
+
+func setupWebhookForReservation(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kueue.Reservation{}).
+		WithValidator(&ReservationWebhook{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-kueue-x-k8s-io-v1beta1-reservation,mutating=false,failurePolicy=fail,sideEffects=None,groups=kueue.x-k8s.io,resources=reservations,verbs=create;update,versions=v1beta1,name=vreservation.kb.io,admissionReviewVersions=v1
+
+var _ webhook.CustomValidator = &ReservationWebhook{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *ReservationWebhook) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	res := obj.(*kueue.Reservation)
+	log := ctrl.LoggerFrom(ctx).WithName("reservation-webhook")
+	log.V(5).Info("Validating create", "reservation", klog.KObj(res))
+	return nil, ValidateReservation(res).ToAggregate()
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *ReservationWebhook) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldRes := oldObj.(*kueue.Reservation)
+	newRes := newObj.(*kueue.Reservation)
+	log := ctrl.LoggerFrom(ctx).WithName("reservation-webhook")
+	log.V(5).Info("Validating update", "reservation", klog.KObj(newRes))
+	return nil, ValidateReservationUpdate(newRes, oldRes).ToAggregate()
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (w *ReservationWebhook) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func ValidateReservation(res *kueue.Reservation) field.ErrorList {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+	allErrs = append(allErrs, validateNameReference(string(res.Spec.ClusterQueue), specPath.Child("clusterQueue"))...)
+	flavorsPath := specPath.Child("flavors")
+	for i, flv := range res.Spec.Flavors {
+		flvPath := flavorsPath.Index(i)
+		allErrs = append(allErrs, validateNameReference(string(flv.Name), flvPath.Child("name"))...)
+		for rName, q := range flv.Resources {
+			rPath := flvPath.Child("resources").Key(string(rName))
+			allErrs = append(allErrs, validateResourceName(rName, rPath)...)
+			allErrs = append(allErrs, validateResourceQuantity(q, rPath)...)
+		}
+	}
+	if res.Spec.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("duration"), res.Spec.Duration.Duration.String(), "must be greater than 0"))
+	}
+	if res.Spec.HoldPeriod != nil && res.Spec.HoldPeriod.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("holdPeriod"), res.Spec.HoldPeriod.Duration.String(), isNegativeErrorMsg))
+	}
+	return allErrs
+}
+
+func ValidateReservationUpdate(newObj, oldObj *kueue.Reservation) field.ErrorList {
+	allErrs := ValidateReservation(newObj)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.ClusterQueue, oldObj.Spec.ClusterQueue, field.NewPath("spec", "clusterQueue"))...)
+	return allErrs
+}
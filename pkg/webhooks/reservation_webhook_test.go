@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	testingutil "sigs.k8s.io/kueue/pkg/util/testing"
+)
+
+func TestValidateReservation(t *testing.T) {
+	start := time.Now()
+	specPath := field.NewPath("spec")
+	testcases := map[string]struct {
+		reservation *kueue.Reservation
+		wantErr     field.ErrorList
+	}{
+		"valid": {
+			reservation: testingutil.MakeReservation("res", "cq", start, time.Hour).
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("32")}).
+				HoldPeriod(time.Hour).
+				Obj(),
+		},
+		"invalid clusterQueue name": {
+			reservation: testingutil.MakeReservation("res", "invalid_name", start, time.Hour).
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("32")}).
+				Obj(),
+			wantErr: field.ErrorList{field.Invalid(specPath.Child("clusterQueue"), "invalid_name", "")},
+		},
+		"invalid flavor name": {
+			reservation: testingutil.MakeReservation("res", "cq", start, time.Hour).
+				Flavor("invalid_name", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("32")}).
+				Obj(),
+			wantErr: field.ErrorList{field.Invalid(specPath.Child("flavors").Index(0).Child("name"), "invalid_name", "")},
+		},
+		"negative quantity": {
+			reservation: testingutil.MakeReservation("res", "cq", start, time.Hour).
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("-1")}).
+				Obj(),
+			wantErr: field.ErrorList{field.Invalid(specPath.Child("flavors").Index(0).Child("resources").Key("cpu"), "-1", "")},
+		},
+		"zero duration": {
+			reservation: testingutil.MakeReservation("res", "cq", start, 0).
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("32")}).
+				Obj(),
+			wantErr: field.ErrorList{field.Invalid(specPath.Child("duration"), "0s", "")},
+		},
+		"negative hold period": {
+			reservation: testingutil.MakeReservation("res", "cq", start, time.Hour).
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("32")}).
+				HoldPeriod(-time.Minute).
+				Obj(),
+			wantErr: field.ErrorList{field.Invalid(specPath.Child("holdPeriod"), "-1m0s", "")},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := ValidateReservation(tc.reservation)
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("ValidateReservation() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateReservationUpdate(t *testing.T) {
+	start := time.Now()
+	oldRes := testingutil.MakeReservation("res", "cq", start, time.Hour).
+		Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("32")}).
+		Obj()
+	testcases := map[string]struct {
+		newRes  *kueue.Reservation
+		wantErr field.ErrorList
+	}{
+		"can change the time window and resources": {
+			newRes: testingutil.MakeReservation("res", "cq", start.Add(time.Hour), 2*time.Hour).
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("16")}).
+				Obj(),
+		},
+		"cannot change the clusterQueue": {
+			newRes: testingutil.MakeReservation("res", "other-cq", start, time.Hour).
+				Flavor("default", corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("32")}).
+				Obj(),
+			wantErr: field.ErrorList{field.Invalid(field.NewPath("spec", "clusterQueue"), kueue.ClusterQueueReference("other-cq"), "")},
+		},
+	}
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			gotErr := ValidateReservationUpdate(tc.newRes, oldRes)
+			if diff := cmp.Diff(tc.wantErr, gotErr, cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("ValidateReservationUpdate() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metavalidation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -33,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	controllerconsts "sigs.k8s.io/kueue/pkg/controller/constants"
 )
 
 type ResourceFlavorWebhook struct{}
@@ -94,6 +96,34 @@ func ValidateResourceFlavor(rf *kueue.ResourceFlavor) field.ErrorList {
 
 	allErrs = append(allErrs, validateNodeTaints(rf.Spec.NodeTaints, specPath.Child("nodeTaints"))...)
 	allErrs = append(allErrs, validateTolerations(rf.Spec.Tolerations, specPath.Child("tolerations"))...)
+	if rf.Spec.PodTemplateOverrides != nil {
+		allErrs = append(allErrs, validatePodTemplateOverrides(rf.Spec.PodTemplateOverrides, specPath.Child("podTemplateOverrides"))...)
+	}
+	return allErrs
+}
+
+func validatePodTemplateOverrides(overrides *kueue.PodTemplateOverrides, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	annotationsPath := fldPath.Child("annotations")
+	allErrs = append(allErrs, apivalidation.ValidateAnnotations(overrides.Annotations, annotationsPath)...)
+	if _, found := overrides.Annotations[controllerconsts.FlavorEnvAnnotation]; found {
+		allErrs = append(allErrs, field.Invalid(annotationsPath.Key(controllerconsts.FlavorEnvAnnotation), controllerconsts.FlavorEnvAnnotation, "the key is reserved for internal kueue use"))
+	}
+	for i, env := range overrides.Env {
+		for _, msg := range validation.IsEnvVarName(env.Name) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("env").Index(i).Child("name"), env.Name, msg))
+		}
+	}
+	if overrides.RuntimeClassName != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(*overrides.RuntimeClassName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("runtimeClassName"), *overrides.RuntimeClassName, msg))
+		}
+	}
+	if len(overrides.SchedulerName) > 0 {
+		for _, msg := range validation.IsDNS1123Subdomain(overrides.SchedulerName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("schedulerName"), overrides.SchedulerName, msg))
+		}
+	}
 	return allErrs
 }
 
@@ -23,6 +23,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
@@ -112,6 +113,34 @@ func TestValidateResourceFlavor(t *testing.T) {
 				field.NotSupported(field.NewPath("spec", "tolerations").Index(2).Child("effect"), corev1.TaintEffect("not-valid"), nil),
 			},
 		},
+		{
+			name: "valid podTemplateOverrides",
+			rf: utiltesting.MakeResourceFlavor("resource-flavor").
+				PodTemplateOverrides(&kueue.PodTemplateOverrides{
+					Annotations:      map[string]string{"example.com/spot": "true"},
+					Env:              []corev1.EnvVar{{Name: "KUEUE_FLAVOR", Value: "resource-flavor"}},
+					RuntimeClassName: ptr.To("nvidia"),
+					SchedulerName:    "gpu-scheduler",
+				}).
+				Obj(),
+		},
+		{
+			name: "invalid podTemplateOverrides",
+			rf: utiltesting.MakeResourceFlavor("resource-flavor").
+				PodTemplateOverrides(&kueue.PodTemplateOverrides{
+					Annotations:      map[string]string{"kueue.x-k8s.io/flavor-env": "FOO"},
+					Env:              []corev1.EnvVar{{Name: "1FOO"}},
+					RuntimeClassName: ptr.To("Nvidia"),
+					SchedulerName:    "gpu_scheduler",
+				}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "podTemplateOverrides", "annotations").Key("kueue.x-k8s.io/flavor-env"), "kueue.x-k8s.io/flavor-env", ""),
+				field.Invalid(field.NewPath("spec", "podTemplateOverrides", "env").Index(0).Child("name"), "1FOO", ""),
+				field.Invalid(field.NewPath("spec", "podTemplateOverrides", "runtimeClassName"), "Nvidia", ""),
+				field.Invalid(field.NewPath("spec", "podTemplateOverrides", "schedulerName"), "gpu_scheduler", ""),
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -16,12 +16,50 @@ limitations under the License.
 
 package webhooks
 
-import ctrl "sigs.k8s.io/controller-runtime"
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+)
+
+type options struct {
+	cache               *cache.Cache
+	capacityCheckAction configapi.CapacityCheckAction
+	resources           *configapi.Resources
+}
+
+// Option configures the webhooks.
+type Option func(*options)
+
+// WithCapacityCheck makes the Workload webhook look up in the cache whether
+// the created workloads can never fit in their ClusterQueue, and reject them
+// or warn about them depending on the action.
+func WithCapacityCheck(c *cache.Cache, action configapi.CapacityCheckAction) Option {
+	return func(o *options) {
+		o.cache = c
+		o.capacityCheckAction = action
+	}
+}
+
+// WithResources makes the ClusterQueue webhook warn about the covered
+// resources that the workloads never request, according to the resource
+// exclusions and transformations.
+func WithResources(r *configapi.Resources) Option {
+	return func(o *options) {
+		o.resources = r
+	}
+}
 
 // Setup sets up the webhooks for core controllers. It returns the name of the
 // webhook that failed to create and an error, if any.
-func Setup(mgr ctrl.Manager) (string, error) {
-	if err := setupWebhookForWorkload(mgr); err != nil {
+func Setup(mgr ctrl.Manager, opts ...Option) (string, error) {
+	var options options
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if err := setupWebhookForWorkload(mgr, options); err != nil {
 		return "Workload", err
 	}
 
@@ -29,7 +67,7 @@ func Setup(mgr ctrl.Manager) (string, error) {
 		return "ResourceFlavor", err
 	}
 
-	if err := setupWebhookForClusterQueue(mgr); err != nil {
+	if err := setupWebhookForClusterQueue(mgr, options); err != nil {
 		return "ClusterQueue", err
 	}
 
@@ -41,5 +79,9 @@ func Setup(mgr ctrl.Manager) (string, error) {
 		return "AdmissionCheck", err
 	}
 
+	if err := setupWebhookForReservation(mgr); err != nil {
+		return "Reservation", err
+	}
+
 	return "", nil
 }
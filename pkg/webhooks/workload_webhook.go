@@ -23,6 +23,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -34,19 +35,29 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
+	"sigs.k8s.io/kueue/pkg/constants"
 	"sigs.k8s.io/kueue/pkg/features"
 	"sigs.k8s.io/kueue/pkg/util/slices"
 	"sigs.k8s.io/kueue/pkg/workload"
 )
 
-type WorkloadWebhook struct{}
+type WorkloadWebhook struct {
+	cache               *cache.Cache
+	capacityCheckAction configapi.CapacityCheckAction
+}
 
-func setupWebhookForWorkload(mgr ctrl.Manager) error {
+func setupWebhookForWorkload(mgr ctrl.Manager, opts options) error {
+	wh := &WorkloadWebhook{
+		cache:               opts.cache,
+		capacityCheckAction: opts.capacityCheckAction,
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&kueue.Workload{}).
-		WithDefaulter(&WorkloadWebhook{}).
-		WithValidator(&WorkloadWebhook{}).
+		WithDefaulter(wh).
+		WithValidator(wh).
 		Complete()
 }
 
@@ -87,7 +98,41 @@ func (w *WorkloadWebhook) ValidateCreate(ctx context.Context, obj runtime.Object
 	wl := obj.(*kueue.Workload)
 	log := ctrl.LoggerFrom(ctx).WithName("workload-webhook")
 	log.V(5).Info("Validating create", "workload", klog.KObj(wl))
-	return nil, ValidateWorkload(wl).ToAggregate()
+	allErrs := ValidateWorkload(wl)
+	if len(allErrs) > 0 {
+		return nil, allErrs.ToAggregate()
+	}
+	return w.validateCapacity(wl)
+}
+
+// validateCapacity rejects or warns about a workload that can never fit in
+// its ClusterQueue, depending on the configured action. The workloads owned
+// by a job are checked by the webhook of the job instead, which reports to
+// the user that creates the job.
+func (w *WorkloadWebhook) validateCapacity(wl *kueue.Workload) (admission.Warnings, error) {
+	if w.cache == nil || workload.HasQuotaReservation(wl) || metav1.GetControllerOf(wl) != nil {
+		return nil, nil
+	}
+	if w.capacityCheckAction != configapi.CapacityCheckReject && w.capacityCheckAction != configapi.CapacityCheckWarn {
+		return nil, nil
+	}
+	reasons := w.cache.MaxCapacityViolations(wl)
+	if len(reasons) == 0 {
+		return nil, nil
+	}
+	if w.capacityCheckAction == configapi.CapacityCheckWarn {
+		warnings := make(admission.Warnings, 0, len(reasons))
+		for _, r := range reasons {
+			warnings = append(warnings, "the workload can never be admitted: "+r)
+		}
+		return warnings, nil
+	}
+	podSetsPath := field.NewPath("spec", "podSets")
+	allErrs := make(field.ErrorList, 0, len(reasons))
+	for _, r := range reasons {
+		allErrs = append(allErrs, field.Forbidden(podSetsPath, "the workload can never be admitted: "+r))
+	}
+	return nil, allErrs.ToAggregate()
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
@@ -121,6 +166,8 @@ func ValidateWorkload(obj *kueue.Workload) field.ErrorList {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("podSets"), variableCountPosets, "at most one podSet can use minCount"))
 	}
 
+	allErrs = append(allErrs, validatePodSetFlavorGroups(obj, specPath.Child("podSetFlavorGroups"))...)
+
 	if len(obj.Spec.PriorityClassName) > 0 {
 		msgs := validation.IsDNS1123Subdomain(obj.Spec.PriorityClassName)
 		if len(msgs) > 0 {
@@ -174,12 +221,42 @@ func validatePodSet(ps *kueue.PodSet, path *field.Path) field.ErrorList {
 	return allErrs
 }
 
+func validatePodSetFlavorGroups(obj *kueue.Workload, basePath *field.Path) field.ErrorList {
+	if len(obj.Spec.PodSetFlavorGroups) == 0 {
+		return nil
+	}
+	knowPodSets := sets.New[string]()
+	for i := range obj.Spec.PodSets {
+		knowPodSets.Insert(obj.Spec.PodSets[i].Name)
+	}
+
+	var allErrs field.ErrorList
+	grouped := sets.New[string]()
+	for i := range obj.Spec.PodSetFlavorGroups {
+		group := &obj.Spec.PodSetFlavorGroups[i]
+		groupPath := basePath.Index(i)
+		for j, name := range group.PodSets {
+			psPath := groupPath.Child("podSets").Index(j)
+			if !knowPodSets.Has(name) {
+				allErrs = append(allErrs, field.NotSupported(psPath, name, sets.List(knowPodSets)))
+			} else if grouped.Has(name) {
+				allErrs = append(allErrs, field.Duplicate(psPath, name))
+			}
+			grouped.Insert(name)
+		}
+		if len(group.NodeLabel) > 0 {
+			allErrs = append(allErrs, metav1validation.ValidateLabelName(group.NodeLabel, groupPath.Child("nodeLabel"))...)
+		}
+	}
+	return allErrs
+}
+
 func validateContainer(c *corev1.Container, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	rPath := path.Child("resources", "requests")
 	for name := range c.Resources.Requests {
-		if name == corev1.ResourcePods {
-			allErrs = append(allErrs, field.Invalid(rPath.Key(string(name)), corev1.ResourcePods, "the key is reserved for internal kueue use"))
+		if name == corev1.ResourcePods || name == kueue.ResourceWorkloads {
+			allErrs = append(allErrs, field.Invalid(rPath.Key(string(name)), name, "the key is reserved for internal kueue use"))
 		}
 	}
 	return allErrs
@@ -348,7 +425,12 @@ func ValidateWorkloadUpdate(newObj, oldObj *kueue.Workload) field.ErrorList {
 	if workload.HasQuotaReservation(oldObj) {
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.PodSets, oldObj.Spec.PodSets, specPath.Child("podSets"))...)
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.PriorityClassSource, oldObj.Spec.PriorityClassSource, specPath.Child("priorityClassSource"))...)
-		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.PriorityClassName, oldObj.Spec.PriorityClassName, specPath.Child("priorityClassName"))...)
+		// The workloadPriorityClass can be replaced to change the priority of
+		// the workload, but not the pod priorityClass.
+		if oldObj.Spec.PriorityClassSource != constants.WorkloadPriorityClassSource {
+			allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.PriorityClassName, oldObj.Spec.PriorityClassName, specPath.Child("priorityClassName"))...)
+		}
+		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.PodSetFlavorGroups, oldObj.Spec.PodSetFlavorGroups, specPath.Child("podSetFlavorGroups"))...)
 	}
 	if workload.HasQuotaReservation(newObj) && workload.HasQuotaReservation(oldObj) {
 		allErrs = append(allErrs, apivalidation.ValidateImmutableField(newObj.Spec.QueueName, oldObj.Spec.QueueName, specPath.Child("queueName"))...)
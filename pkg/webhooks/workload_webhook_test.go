@@ -28,8 +28,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	configapi "sigs.k8s.io/kueue/apis/config/v1beta1"
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	"sigs.k8s.io/kueue/pkg/cache"
 	"sigs.k8s.io/kueue/pkg/constants"
 	testingutil "sigs.k8s.io/kueue/pkg/util/testing"
 )
@@ -237,6 +240,16 @@ func TestValidateWorkload(t *testing.T) {
 				field.Invalid(firstPodSetSpecPath.Child("containers").Index(0).Child("resources", "requests").Key(string(corev1.ResourcePods)), nil, ""),
 			},
 		},
+		"should not request the workloads resource": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(*testingutil.MakePodSet("bad", 1).
+					Request(kueue.ResourceWorkloads, "1").
+					Obj()).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(firstPodSetSpecPath.Child("containers").Index(0).Child("resources", "requests").Key(string(kueue.ResourceWorkloads)), nil, ""),
+			},
+		},
 		"empty podSetUpdates": {
 			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).AdmissionChecks(kueue.AdmissionCheckState{}).Obj(),
 			wantErr:  nil,
@@ -380,6 +393,40 @@ func TestValidateWorkload(t *testing.T) {
 				field.Invalid(podSetsPath, nil, ""),
 			},
 		},
+		"valid podSetFlavorGroups": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(
+					*testingutil.MakePodSet("launcher", 1).Obj(),
+					*testingutil.MakePodSet("workers", 3).Obj(),
+				).
+				PodSetFlavorGroups(kueue.PodSetFlavorGroup{
+					PodSets:   []string{"launcher", "workers"},
+					NodeLabel: "topology.kubernetes.io/zone",
+				}).
+				Obj(),
+		},
+		"invalid podSetFlavorGroups": {
+			workload: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(
+					*testingutil.MakePodSet("launcher", 1).Obj(),
+					*testingutil.MakePodSet("workers", 3).Obj(),
+				).
+				PodSetFlavorGroups(
+					kueue.PodSetFlavorGroup{
+						PodSets: []string{"launcher", "workers"},
+					},
+					kueue.PodSetFlavorGroup{
+						PodSets:   []string{"workers", "driver"},
+						NodeLabel: "invalid label",
+					},
+				).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Duplicate(specPath.Child("podSetFlavorGroups").Index(1).Child("podSets").Index(0), nil),
+				field.NotSupported(specPath.Child("podSetFlavorGroups").Index(1).Child("podSets").Index(1), nil, []string{}),
+				field.Invalid(specPath.Child("podSetFlavorGroups").Index(1).Child("nodeLabel"), nil, ""),
+			},
+		},
 	}
 	for name, tc := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -600,6 +647,29 @@ func TestValidateWorkloadUpdate(t *testing.T) {
 				field.Invalid(field.NewPath("spec").Child("priorityClassName"), nil, ""),
 			},
 		},
+		"workloadPriorityClass can be updated when has quota reservation": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).Queue("q").
+				PriorityClass("test-class-1").PriorityClassSource(constants.WorkloadPriorityClassSource).
+				Priority(10).ReserveQuota(testingutil.MakeAdmission("cq").Obj()).Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).Queue("q").
+				PriorityClass("test-class-2").PriorityClassSource(constants.WorkloadPriorityClassSource).
+				Priority(20).PreemptionPolicy(corev1.PreemptNever).Protected().
+				ReserveQuota(testingutil.MakeAdmission("cq").Obj()).Obj(),
+		},
+		"podSetFlavorGroups should not be updated when has quota reservation": {
+			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).ReserveQuota(testingutil.MakeAdmission("cq").Obj()).Obj(),
+			after: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				PodSets(
+					*testingutil.MakePodSet("launcher", 1).Obj(),
+					*testingutil.MakePodSet("workers", 3).Obj(),
+				).
+				PodSetFlavorGroups(kueue.PodSetFlavorGroup{PodSets: []string{"launcher", "workers"}}).
+				Obj(),
+			wantErr: field.ErrorList{
+				field.Invalid(field.NewPath("spec").Child("podSets"), nil, ""),
+				field.Invalid(field.NewPath("spec").Child("podSetFlavorGroups"), nil, ""),
+			},
+		},
 		"podSetUpdates should be immutable when state is ready": {
 			before: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).PodSets(
 				*testingutil.MakePodSet("first", 1).Obj(),
@@ -690,3 +760,79 @@ func TestValidateWorkloadUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkloadWebhookValidateCreateCapacity(t *testing.T) {
+	cq := testingutil.MakeClusterQueue("cq").
+		ResourceGroup(*testingutil.MakeFlavorQuotas("default").Resource("example.com/gpu", "16").Obj()).
+		Obj()
+	lq := testingutil.MakeLocalQueue("lq", testWorkloadNamespace).ClusterQueue("cq").Obj()
+	tooBig := testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+		Queue("lq").
+		Request("example.com/gpu", "64").
+		Obj()
+	reason := "the workload can never be admitted: podSet main requests example.com/gpu=64, which exceed the maximum quota of every flavor in ClusterQueue cq"
+
+	cases := map[string]struct {
+		action       configapi.CapacityCheckAction
+		wl           *kueue.Workload
+		wantWarnings admission.Warnings
+		wantErr      field.ErrorList
+	}{
+		"reject": {
+			action: configapi.CapacityCheckReject,
+			wl:     tooBig,
+			wantErr: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "podSets"), reason),
+			},
+		},
+		"warn": {
+			action:       configapi.CapacityCheckWarn,
+			wl:           tooBig,
+			wantWarnings: admission.Warnings{reason},
+		},
+		"mark inadmissible is left to the controller": {
+			action: configapi.CapacityCheckMarkInadmissible,
+			wl:     tooBig,
+		},
+		"fits": {
+			action: configapi.CapacityCheckReject,
+			wl: testingutil.MakeWorkload(testWorkloadName, testWorkloadNamespace).
+				Queue("lq").
+				Request("example.com/gpu", "16").
+				Obj(),
+		},
+		"owned by a job is left to the job webhook": {
+			action: configapi.CapacityCheckReject,
+			wl: func() *kueue.Workload {
+				wl := tooBig.DeepCopy()
+				wl.OwnerReferences = []metav1.OwnerReference{{
+					APIVersion: "batch/v1",
+					Kind:       "Job",
+					Name:       "job",
+					UID:        "job-uid",
+					Controller: ptr.To(true),
+				}}
+				return wl
+			}(),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cqCache := cache.New(testingutil.NewFakeClient())
+			if err := cqCache.AddClusterQueue(context.Background(), cq); err != nil {
+				t.Fatalf("Adding ClusterQueue: %v", err)
+			}
+			if err := cqCache.AddLocalQueue(lq); err != nil {
+				t.Fatalf("Adding LocalQueue: %v", err)
+			}
+			wh := &WorkloadWebhook{cache: cqCache, capacityCheckAction: tc.action}
+			warnings, err := wh.ValidateCreate(context.Background(), tc.wl)
+			if diff := cmp.Diff(tc.wantWarnings, warnings); diff != "" {
+				t.Errorf("Unexpected warnings (-want,+got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantErr.ToAggregate(), err); diff != "" {
+				t.Errorf("Unexpected error (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -59,6 +59,11 @@ func SyncAdmittedCondition(w *kueue.Workload) bool {
 	}
 
 	apimeta.SetStatusCondition(&w.Status.Conditions, newCondition)
+	if newCondition.Status == metav1.ConditionTrue {
+		if record := openAdmissionRecord(w); record != nil && record.AdmissionTime == nil {
+			record.AdmissionTime = &apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadAdmitted).LastTransitionTime
+		}
+	}
 	return true
 }
 
@@ -148,3 +153,25 @@ func HasRetryOrRejectedChecks(wl *kueue.Workload) bool {
 	}
 	return false
 }
+
+// AdmissionChecksForWorkload returns the AdmissionChecks that apply to the
+// workload, out of the checks of its ClusterQueue and the flavors they apply
+// to: the checks without flavors and the checks with a flavor assigned to any
+// of the podSets. Without admission, only the checks without flavors apply.
+func AdmissionChecksForWorkload(wl *kueue.Workload, checks map[string]sets.Set[kueue.ResourceFlavorReference]) sets.Set[string] {
+	assigned := sets.New[kueue.ResourceFlavorReference]()
+	if wl.Status.Admission != nil {
+		for i := range wl.Status.Admission.PodSetAssignments {
+			for _, flavor := range wl.Status.Admission.PodSetAssignments[i].Flavors {
+				assigned.Insert(flavor)
+			}
+		}
+	}
+	ret := sets.New[string]()
+	for name, flavors := range checks {
+		if flavors.Len() == 0 || flavors.HasAny(assigned.UnsortedList()...) {
+			ret.Insert(name)
+		}
+	}
+	return ret
+}
@@ -24,9 +24,11 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
 
 	kueue "sigs.k8s.io/kueue/apis/kueue/v1beta1"
+	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
 func TestSyncAdmittedCondition(t *testing.T) {
@@ -394,3 +396,48 @@ func TestSetCheckState(t *testing.T) {
 		})
 	}
 }
+
+func TestAdmissionChecksForWorkload(t *testing.T) {
+	checks := map[string]sets.Set[kueue.ResourceFlavorReference]{
+		"all":  sets.New[kueue.ResourceFlavorReference](),
+		"gpu":  sets.New[kueue.ResourceFlavorReference]("gpu"),
+		"spot": sets.New[kueue.ResourceFlavorReference]("spot-cpu", "spot-gpu"),
+	}
+	cases := map[string]struct {
+		wl   *kueue.Workload
+		want sets.Set[string]
+	}{
+		"without admission": {
+			wl:   utiltesting.MakeWorkload("wl", "ns").Obj(),
+			want: sets.New("all"),
+		},
+		"with a flavor of a check": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment("example.com/gpu", "gpu", "1").Obj()).
+				Obj(),
+			want: sets.New("all", "gpu"),
+		},
+		"with flavors of several checks": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").
+					Assignment(corev1.ResourceCPU, "spot-cpu", "1").
+					Assignment("example.com/gpu", "gpu", "1").
+					Obj()).
+				Obj(),
+			want: sets.New("all", "gpu", "spot"),
+		},
+		"with other flavors": {
+			wl: utiltesting.MakeWorkload("wl", "ns").
+				ReserveQuota(utiltesting.MakeAdmission("cq").Assignment(corev1.ResourceCPU, "on-demand", "1").Obj()).
+				Obj(),
+			want: sets.New("all"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, AdmissionChecksForWorkload(tc.wl, checks)); diff != "" {
+				t.Errorf("Unexpected checks (-want,+got):\n%s", diff)
+			}
+		})
+	}
+}
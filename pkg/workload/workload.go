@@ -35,6 +35,10 @@ import (
 	"sigs.k8s.io/kueue/pkg/util/limitrange"
 )
 
+// MaxAdmissionHistoryLength is the number of records kept in the admission
+// history of a workload, matching the limit in the API.
+const MaxAdmissionHistoryLength = 10
+
 var (
 	admissionManagedConditions = []string{kueue.WorkloadQuotaReserved, kueue.WorkloadEvicted, kueue.WorkloadAdmitted}
 )
@@ -66,6 +70,9 @@ type Info struct {
 	// already admitted.
 	ClusterQueue   string
 	LastAssignment *AssigmentClusterQueueState
+	// ResourceClaimsErr is set when the ResourceClaimTemplates of a pod set
+	// couldn't be resolved. The requests of the pod set are incomplete.
+	ResourceClaimsErr *ResourceClaimError
 }
 
 type PodSetResources struct {
@@ -88,7 +95,82 @@ func (psr *PodSetResources) ScaledTo(newCount int32) *PodSetResources {
 	return ret
 }
 
-func NewInfo(w *kueue.Workload) *Info {
+// ResourceTransformation converts the requests of the pods for a resource
+// into the requests of the workload.
+type ResourceTransformation struct {
+	// Outputs are the resources that a unit of the input resource maps to.
+	Outputs corev1.ResourceList
+	// Replace removes the input resource from the requests.
+	Replace bool
+}
+
+// ResourceClaimTemplateResolver returns the resource that accounts for the
+// devices requested by the ResourceClaimTemplate in the namespace, or an empty
+// name if the devices aren't accounted.
+type ResourceClaimTemplateResolver func(namespace, name string) (corev1.ResourceName, error)
+
+// ResourceClaimError is the error resolving a ResourceClaimTemplate referenced
+// by a pod set.
+type ResourceClaimError struct {
+	PodSet   string
+	Template string
+	Err      error
+}
+
+func (e *ResourceClaimError) Error() string {
+	return fmt.Sprintf("resolving ResourceClaimTemplate %q: %v", e.Template, e.Err)
+}
+
+func (e *ResourceClaimError) Unwrap() error {
+	return e.Err
+}
+
+type infoOptions struct {
+	excludedResourcePrefixes []string
+	resourceTransformations  map[corev1.ResourceName]ResourceTransformation
+	resourceClaimResolver    ResourceClaimTemplateResolver
+}
+
+// InfoOption configures how the requests of a workload are computed.
+type InfoOption func(*infoOptions)
+
+// WithExcludedResourcePrefixes drops the resources with any of the prefixes
+// from the requests of the pods.
+func WithExcludedResourcePrefixes(prefixes []string) InfoOption {
+	return func(o *infoOptions) {
+		o.excludedResourcePrefixes = prefixes
+	}
+}
+
+// WithResourceTransformations applies the transformations, keyed by their
+// input resource, to the requests of the pods.
+func WithResourceTransformations(transformations map[corev1.ResourceName]ResourceTransformation) InfoOption {
+	return func(o *infoOptions) {
+		o.resourceTransformations = transformations
+	}
+}
+
+// WithResourceClaimTemplateResolver counts one unit of the resolved resource
+// for each ResourceClaimTemplate referenced by the pods, regardless of the
+// number of devices that the claim allocates. ResourceClaims referenced by
+// name are shared by the pods and aren't counted.
+//
+// The resolver is called while the cache and the queues hold their locks, so
+// it must not block.
+func WithResourceClaimTemplateResolver(resolver ResourceClaimTemplateResolver) InfoOption {
+	return func(o *infoOptions) {
+		o.resourceClaimResolver = resolver
+	}
+}
+
+// NewInfo returns the Info of the workload. The options only apply to the
+// requests of the workloads without quota reservation; the usage of the
+// others is read from their admission.
+func NewInfo(w *kueue.Workload, opts ...InfoOption) *Info {
+	var options infoOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
 	info := &Info{
 		Obj: w,
 	}
@@ -96,7 +178,7 @@ func NewInfo(w *kueue.Workload) *Info {
 		info.ClusterQueue = string(w.Status.Admission.ClusterQueue)
 		info.TotalRequests = totalRequestsFromAdmission(w)
 	} else {
-		info.TotalRequests = totalRequestsFromPodSets(w)
+		info.TotalRequests, info.ResourceClaimsErr = totalRequestsFromPodSets(w, &options)
 	}
 	return info
 }
@@ -157,10 +239,11 @@ func podSetsCountsAfterReclaim(wl *kueue.Workload) map[string]int32 {
 	return totalCounts
 }
 
-func totalRequestsFromPodSets(wl *kueue.Workload) []PodSetResources {
+func totalRequestsFromPodSets(wl *kueue.Workload, options *infoOptions) ([]PodSetResources, *ResourceClaimError) {
 	if len(wl.Spec.PodSets) == 0 {
-		return nil
+		return nil, nil
 	}
+	var claimsErr *ResourceClaimError
 	res := make([]PodSetResources, 0, len(wl.Spec.PodSets))
 	currentCounts := podSetsCountsAfterReclaim(wl)
 	for _, ps := range wl.Spec.PodSets {
@@ -169,11 +252,93 @@ func totalRequestsFromPodSets(wl *kueue.Workload) []PodSetResources {
 			Name:  ps.Name,
 			Count: count,
 		}
-		setRes.Requests = newRequests(limitrange.TotalRequests(&ps.Template.Spec))
+		podRequests := limitrange.TotalRequests(&ps.Template.Spec)
+		if options.resourceClaimResolver != nil {
+			if err := addResourceClaims(podRequests, wl.Namespace, &ps.Template.Spec, options.resourceClaimResolver); err != nil && claimsErr == nil {
+				err.PodSet = ps.Name
+				claimsErr = err
+			}
+		}
+		podRequests = applyResourceTransformations(podRequests, options.resourceTransformations)
+		podRequests = dropExcludedResources(podRequests, options.excludedResourcePrefixes)
+		setRes.Requests = newRequests(podRequests)
 		setRes.Requests.scaleUp(int64(count))
 		res = append(res, setRes)
 	}
-	return res
+	return res, claimsErr
+}
+
+// addResourceClaims adds a unit of the resolved resource to the requests for
+// each ResourceClaimTemplate of the pod.
+func addResourceClaims(requests corev1.ResourceList, namespace string, spec *corev1.PodSpec, resolver ResourceClaimTemplateResolver) *ResourceClaimError {
+	for _, claim := range spec.ResourceClaims {
+		template := ptr.Deref(claim.Source.ResourceClaimTemplateName, "")
+		if template == "" {
+			continue
+		}
+		name, err := resolver(namespace, template)
+		if err != nil {
+			return &ResourceClaimError{Template: template, Err: err}
+		}
+		if name == "" {
+			continue
+		}
+		total := requests[name]
+		total.Add(*resource.NewQuantity(1, resource.DecimalSI))
+		requests[name] = total
+	}
+	return nil
+}
+
+// applyResourceTransformations adds the outputs of the transformations,
+// scaled by the integer value of their input, to the requests.
+func applyResourceTransformations(requests corev1.ResourceList, transformations map[corev1.ResourceName]ResourceTransformation) corev1.ResourceList {
+	if len(transformations) == 0 {
+		return requests
+	}
+	ret := make(corev1.ResourceList, len(requests))
+	outputs := make(corev1.ResourceList)
+	for name, q := range requests {
+		t, found := transformations[name]
+		if !found {
+			ret[name] = q
+			continue
+		}
+		if !t.Replace {
+			ret[name] = q
+		}
+		for outName, weight := range t.Outputs {
+			total := outputs[outName]
+			total.Add(*resource.NewMilliQuantity(weight.MilliValue()*q.Value(), weight.Format))
+			outputs[outName] = total
+		}
+	}
+	for name, q := range outputs {
+		total := ret[name]
+		total.Add(q)
+		ret[name] = total
+	}
+	return ret
+}
+
+func dropExcludedResources(requests corev1.ResourceList, prefixes []string) corev1.ResourceList {
+	for name := range requests {
+		if IsExcludedResource(prefixes, name) {
+			delete(requests, name)
+		}
+	}
+	return requests
+}
+
+// IsExcludedResource returns whether the name of the resource has any of the
+// prefixes.
+func IsExcludedResource(prefixes []string, name corev1.ResourceName) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(string(name), prefix) {
+			return true
+		}
+	}
+	return false
 }
 
 func totalRequestsFromAdmission(wl *kueue.Workload) []PodSetResources {
@@ -247,15 +412,21 @@ func ResourceQuantity(name corev1.ResourceName, v int64) resource.Quantity {
 	}
 }
 
+// scaleUp multiplies the requests by the number of pods, except for the
+// workloads resource, which is requested once per workload.
 func (r Requests) scaleUp(f int64) {
 	for name := range r {
-		r[name] *= f
+		if name != kueue.ResourceWorkloads {
+			r[name] *= f
+		}
 	}
 }
 
 func (r Requests) scaleDown(f int64) {
 	for name := range r {
-		r[name] /= f
+		if name != kueue.ResourceWorkloads {
+			r[name] /= f
+		}
 	}
 }
 
@@ -282,6 +453,9 @@ func UpdateStatus(ctx context.Context,
 	return c.Status().Patch(ctx, newWl, client.Apply, client.FieldOwner(managerPrefix+"-"+condition.Type))
 }
 
+// UnsetQuotaReservationWithCondition clears the admission of the workload,
+// sets the QuotaReserved condition to false and closes the current record of
+// the admission history, if it wasn't closed by an eviction.
 func UnsetQuotaReservationWithCondition(wl *kueue.Workload, reason, message string) {
 	condition := metav1.Condition{
 		Type:               kueue.WorkloadQuotaReserved,
@@ -291,6 +465,9 @@ func UnsetQuotaReservationWithCondition(wl *kueue.Workload, reason, message stri
 		Message:            api.TruncateConditionMessage(message),
 	}
 	apimeta.SetStatusCondition(&wl.Status.Conditions, condition)
+	if wl.Status.Admission != nil {
+		closeAdmissionRecord(wl, condition.LastTransitionTime, reason)
+	}
 	wl.Status.Admission = nil
 }
 
@@ -317,17 +494,20 @@ func BaseSSAWorkload(w *kueue.Workload) *kueue.Workload {
 }
 
 // SetQuotaReservation applies the provided admission to the workload.
-// The WorkloadAdmitted and WorkloadEvicted are added or updated if necessary.
+// The WorkloadAdmitted and WorkloadEvicted are added or updated if necessary,
+// and a new record is added to the admission history.
 func SetQuotaReservation(w *kueue.Workload, admission *kueue.Admission) {
 	w.Status.Admission = admission
+	now := metav1.Now()
 	admittedCond := metav1.Condition{
 		Type:               kueue.WorkloadQuotaReserved,
 		Status:             metav1.ConditionTrue,
-		LastTransitionTime: metav1.Now(),
+		LastTransitionTime: now,
 		Reason:             "QuotaReserved",
 		Message:            fmt.Sprintf("Quota reserved in ClusterQueue %s", w.Status.Admission.ClusterQueue),
 	}
 	apimeta.SetStatusCondition(&w.Status.Conditions, admittedCond)
+	appendAdmissionRecord(w, now)
 
 	//reset Evicted condition if present.
 	if evictedCond := apimeta.FindStatusCondition(w.Status.Conditions, kueue.WorkloadEvicted); evictedCond != nil {
@@ -336,6 +516,8 @@ func SetQuotaReservation(w *kueue.Workload, admission *kueue.Admission) {
 	}
 }
 
+// SetEvictedCondition sets the Evicted condition and records the eviction in
+// the current record of the admission history, if any.
 func SetEvictedCondition(w *kueue.Workload, reason string, message string) {
 	condition := metav1.Condition{
 		Type:               kueue.WorkloadEvicted,
@@ -345,6 +527,73 @@ func SetEvictedCondition(w *kueue.Workload, reason string, message string) {
 		Message:            message,
 	}
 	apimeta.SetStatusCondition(&w.Status.Conditions, condition)
+	closeAdmissionRecord(w, condition.LastTransitionTime, reason)
+}
+
+// CloseAdmissionRecord records that the quota reservation ended for the given
+// reason in the current record of the admission history. It returns whether
+// there was a record to close.
+func CloseAdmissionRecord(w *kueue.Workload, reason string) bool {
+	return closeAdmissionRecord(w, metav1.Now(), reason)
+}
+
+func closeAdmissionRecord(w *kueue.Workload, now metav1.Time, reason string) bool {
+	record := openAdmissionRecord(w)
+	if record == nil {
+		return false
+	}
+	record.EvictionTime = &now
+	record.EvictionReason = reason
+	return true
+}
+
+// SetPreemptor records the preemptor in the last record of the admission
+// history, which is expected to be closed by a preemption.
+func SetPreemptor(w *kueue.Workload, preemptor *kueue.Workload, cqName string) {
+	if len(w.Status.AdmissionHistory) == 0 {
+		return
+	}
+	record := &w.Status.AdmissionHistory[len(w.Status.AdmissionHistory)-1]
+	if record.EvictionReason != kueue.WorkloadEvictedByPreemption {
+		return
+	}
+	record.Preemptor = &kueue.PreemptorReference{
+		Name:         preemptor.Name,
+		Namespace:    preemptor.Namespace,
+		UID:          preemptor.UID,
+		ClusterQueue: kueue.ClusterQueueReference(cqName),
+	}
+}
+
+func appendAdmissionRecord(w *kueue.Workload, now metav1.Time) {
+	record := kueue.AdmissionRecord{
+		ClusterQueue:         w.Status.Admission.ClusterQueue,
+		QuotaReservationTime: now,
+	}
+	for _, psa := range w.Status.Admission.PodSetAssignments {
+		record.PodSetFlavors = append(record.PodSetFlavors, kueue.PodSetFlavors{
+			Name:    psa.Name,
+			Flavors: maps.Clone(psa.Flavors),
+		})
+	}
+	history := append(w.Status.AdmissionHistory, record)
+	if len(history) > MaxAdmissionHistoryLength {
+		history = history[len(history)-MaxAdmissionHistoryLength:]
+	}
+	w.Status.AdmissionHistory = history
+}
+
+// openAdmissionRecord returns the last record of the admission history if it
+// wasn't evicted yet.
+func openAdmissionRecord(w *kueue.Workload) *kueue.AdmissionRecord {
+	if len(w.Status.AdmissionHistory) == 0 {
+		return nil
+	}
+	record := &w.Status.AdmissionHistory[len(w.Status.AdmissionHistory)-1]
+	if record.EvictionTime != nil {
+		return nil
+	}
+	return record
 }
 
 // admissionPatch creates a new object based on the input workload that contains
@@ -353,6 +602,9 @@ func admissionPatch(w *kueue.Workload) *kueue.Workload {
 	wlCopy := BaseSSAWorkload(w)
 
 	wlCopy.Status.Admission = w.Status.Admission.DeepCopy()
+	for i := range w.Status.AdmissionHistory {
+		wlCopy.Status.AdmissionHistory = append(wlCopy.Status.AdmissionHistory, *w.Status.AdmissionHistory[i].DeepCopy())
+	}
 	for _, conditionName := range admissionManagedConditions {
 		if existing := apimeta.FindStatusCondition(w.Status.Conditions, conditionName); existing != nil {
 			wlCopy.Status.Conditions = append(wlCopy.Status.Conditions, *existing.DeepCopy())
@@ -18,12 +18,14 @@ package workload
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -33,10 +35,19 @@ import (
 	utiltesting "sigs.k8s.io/kueue/pkg/util/testing"
 )
 
+type testError string
+
+func (e testError) Error() string {
+	return string(e)
+}
+
+const errTemplateNotFound = testError("template not found")
+
 func TestNewInfo(t *testing.T) {
 	cases := map[string]struct {
-		workload kueue.Workload
-		wantInfo Info
+		workload    kueue.Workload
+		infoOptions []InfoOption
+		wantInfo    Info
 	}{
 		"pending": {
 			workload: *utiltesting.MakeWorkload("", "").
@@ -187,10 +198,176 @@ func TestNewInfo(t *testing.T) {
 				},
 			},
 		},
+		"admitted with reclaim and counted resources": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(
+					*utiltesting.MakePodSet("main", 5).
+						Request(corev1.ResourceCPU, "10m").
+						Obj(),
+				).
+				ReserveQuota(utiltesting.MakeAdmission("foo").
+					Assignment(corev1.ResourceCPU, "f1", "50m").
+					Assignment(corev1.ResourcePods, "f1", "5").
+					Assignment(kueue.ResourceWorkloads, "f1", "1").
+					AssignmentPodCount(5).
+					Obj()).
+				ReclaimablePods(
+					kueue.ReclaimablePod{
+						Name:  "main",
+						Count: 2,
+					},
+				).
+				Obj(),
+			wantInfo: Info{
+				ClusterQueue: "foo",
+				TotalRequests: []PodSetResources{
+					{
+						Name: "main",
+						Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{
+							corev1.ResourceCPU:      "f1",
+							corev1.ResourcePods:     "f1",
+							kueue.ResourceWorkloads: "f1",
+						},
+						Requests: Requests{
+							corev1.ResourceCPU:      3 * 10,
+							corev1.ResourcePods:     3,
+							kueue.ResourceWorkloads: 1,
+						},
+						Count: 3,
+					},
+				},
+			},
+		},
+		"pending with resource transformations and exclusions": {
+			workload: *utiltesting.MakeWorkload("", "").
+				PodSets(
+					*utiltesting.MakePodSet("main", 2).
+						Request(corev1.ResourceCPU, "10m").
+						Request("example.com/mig-1g", "2").
+						Request("example.com/mig-2g", "1").
+						Request("example.com/accelerator-memory", "1Gi").
+						Request("networking.example.com/vpc", "1").
+						Obj(),
+				).
+				Obj(),
+			infoOptions: []InfoOption{
+				WithResourceTransformations(map[corev1.ResourceName]ResourceTransformation{
+					"example.com/mig-1g": {
+						Outputs: corev1.ResourceList{
+							"example.com/accelerator-memory": resource.MustParse("5Gi"),
+							"example.com/credits":            resource.MustParse("1"),
+						},
+						Replace: true,
+					},
+					"example.com/mig-2g": {
+						Outputs: corev1.ResourceList{
+							"example.com/accelerator-memory": resource.MustParse("10Gi"),
+						},
+					},
+				}),
+				WithExcludedResourcePrefixes([]string{"networking.example.com/"}),
+			},
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: "main",
+						Requests: Requests{
+							corev1.ResourceCPU:               2 * 10,
+							"example.com/mig-2g":             2 * 1,
+							"example.com/accelerator-memory": 2 * (1 + 2*5 + 10) * 1024 * 1024 * 1024,
+							"example.com/credits":            2 * 2,
+						},
+						Count: 2,
+					},
+				},
+			},
+		},
+		"pending with resource claims": {
+			workload: *utiltesting.MakeWorkload("", "ns").
+				PodSets(
+					*utiltesting.MakePodSet("main", 2).
+						Request(corev1.ResourceCPU, "10m").
+						ResourceClaimTemplate("gpu-0", "single-gpu").
+						ResourceClaimTemplate("gpu-1", "single-gpu").
+						ResourceClaimTemplate("nic", "fast-nic").
+						ResourceClaim("shared", "shared-gpu").
+						Obj(),
+				).
+				Obj(),
+			infoOptions: []InfoOption{
+				WithResourceClaimTemplateResolver(func(namespace, name string) (corev1.ResourceName, error) {
+					if namespace == "ns" && name == "single-gpu" {
+						return "example.com/gpu", nil
+					}
+					return "", nil
+				}),
+				WithResourceTransformations(map[corev1.ResourceName]ResourceTransformation{
+					"example.com/gpu": {
+						Outputs: corev1.ResourceList{
+							"example.com/accelerator-memory": resource.MustParse("16Gi"),
+						},
+					},
+				}),
+			},
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: "main",
+						Requests: Requests{
+							corev1.ResourceCPU:               2 * 10,
+							"example.com/gpu":                2 * 2,
+							"example.com/accelerator-memory": 2 * 2 * 16 * 1024 * 1024 * 1024,
+						},
+						Count: 2,
+					},
+				},
+			},
+		},
+		"pending with unresolved resource claims": {
+			workload: *utiltesting.MakeWorkload("", "ns").
+				PodSets(
+					*utiltesting.MakePodSet("driver", 1).
+						Request(corev1.ResourceCPU, "10m").
+						Obj(),
+					*utiltesting.MakePodSet("workers", 2).
+						Request(corev1.ResourceCPU, "10m").
+						ResourceClaimTemplate("gpu", "missing").
+						Obj(),
+				).
+				Obj(),
+			infoOptions: []InfoOption{
+				WithResourceClaimTemplateResolver(func(namespace, name string) (corev1.ResourceName, error) {
+					return "", errTemplateNotFound
+				}),
+			},
+			wantInfo: Info{
+				TotalRequests: []PodSetResources{
+					{
+						Name: "driver",
+						Requests: Requests{
+							corev1.ResourceCPU: 10,
+						},
+						Count: 1,
+					},
+					{
+						Name: "workers",
+						Requests: Requests{
+							corev1.ResourceCPU: 2 * 10,
+						},
+						Count: 2,
+					},
+				},
+				ResourceClaimsErr: &ResourceClaimError{
+					PodSet:   "workers",
+					Template: "missing",
+					Err:      errTemplateNotFound,
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			info := NewInfo(&tc.workload)
+			info := NewInfo(&tc.workload, tc.infoOptions...)
 			if diff := cmp.Diff(info, &tc.wantInfo, cmpopts.IgnoreFields(Info{}, "Obj")); diff != "" {
 				t.Errorf("NewInfo(_) = (-want,+got):\n%s", diff)
 			}
@@ -374,3 +551,88 @@ func TestReclaimablePodsAreEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestAdmissionHistory(t *testing.T) {
+	admission := func(cq, flavor string) *kueue.Admission {
+		return utiltesting.MakeAdmission(cq).Assignment(corev1.ResourceCPU, kueue.ResourceFlavorReference(flavor), "1").Obj()
+	}
+	preemptor := utiltesting.MakeWorkload("preemptor", "ns").Obj()
+	preemptor.UID = "preemptor-uid"
+	ignoreTimes := cmpopts.IgnoreTypes(metav1.Time{}, &metav1.Time{})
+
+	wl := utiltesting.MakeWorkload("wl", "ns").Obj()
+	SetQuotaReservation(wl, admission("cq-a", "on-demand"))
+	wl.Status.AdmissionChecks = nil
+	SyncAdmittedCondition(wl)
+	SetEvictedCondition(wl, kueue.WorkloadEvictedByPreemption, "Preempted")
+	SetPreemptor(wl, preemptor, "cq-b")
+	UnsetQuotaReservationWithCondition(wl, "Pending", "Evicted")
+	SetQuotaReservation(wl, admission("cq-a", "spot"))
+
+	wantHistory := []kueue.AdmissionRecord{
+		{
+			ClusterQueue: "cq-a",
+			PodSetFlavors: []kueue.PodSetFlavors{{
+				Name:    kueue.DefaultPodSetName,
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "on-demand"},
+			}},
+			EvictionReason: kueue.WorkloadEvictedByPreemption,
+			Preemptor: &kueue.PreemptorReference{
+				Name:         "preemptor",
+				Namespace:    "ns",
+				UID:          "preemptor-uid",
+				ClusterQueue: "cq-b",
+			},
+		},
+		{
+			ClusterQueue: "cq-a",
+			PodSetFlavors: []kueue.PodSetFlavors{{
+				Name:    kueue.DefaultPodSetName,
+				Flavors: map[corev1.ResourceName]kueue.ResourceFlavorReference{corev1.ResourceCPU: "spot"},
+			}},
+		},
+	}
+	if diff := cmp.Diff(wantHistory, wl.Status.AdmissionHistory, ignoreTimes); diff != "" {
+		t.Errorf("Unexpected admission history (-want,+got):\n%s", diff)
+	}
+	if wl.Status.AdmissionHistory[0].AdmissionTime == nil || wl.Status.AdmissionHistory[0].EvictionTime == nil {
+		t.Errorf("Timestamps of the closed record are not set: %+v", wl.Status.AdmissionHistory[0])
+	}
+	if wl.Status.AdmissionHistory[1].AdmissionTime != nil || wl.Status.AdmissionHistory[1].EvictionTime != nil {
+		t.Errorf("Timestamps of the open record are set: %+v", wl.Status.AdmissionHistory[1])
+	}
+
+	// A preemptor is only recorded for evictions by preemption.
+	SetEvictedCondition(wl, kueue.WorkloadEvictedByPodsReadyTimeout, "Timeout")
+	SetPreemptor(wl, preemptor, "cq-b")
+	if last := wl.Status.AdmissionHistory[1]; last.EvictionReason != kueue.WorkloadEvictedByPodsReadyTimeout || last.Preemptor != nil {
+		t.Errorf("Unexpected record for the eviction by timeout: %+v", last)
+	}
+
+	// Clearing the quota reservation without an eviction closes the record too.
+	SetQuotaReservation(wl, admission("cq-a", "spot"))
+	UnsetQuotaReservationWithCondition(wl, "Inadmissible", "ClusterQueue cq-a is inactive")
+	if last := wl.Status.AdmissionHistory[2]; last.EvictionReason != "Inadmissible" || last.EvictionTime == nil {
+		t.Errorf("Unexpected record for the cleared quota reservation: %+v", last)
+	}
+	SetQuotaReservation(wl, admission("cq-a", "spot"))
+	if !CloseAdmissionRecord(wl, "AdmissionChecksRejected") || CloseAdmissionRecord(wl, "AdmissionChecksRejected") {
+		t.Errorf("Only the open record can be closed")
+	}
+	if last := wl.Status.AdmissionHistory[3]; last.EvictionReason != "AdmissionChecksRejected" || last.EvictionTime == nil {
+		t.Errorf("Unexpected record for the rejected admission checks: %+v", last)
+	}
+
+	for i := 0; i < MaxAdmissionHistoryLength; i++ {
+		SetQuotaReservation(wl, admission(fmt.Sprintf("cq-%d", i), "spot"))
+	}
+	if len(wl.Status.AdmissionHistory) != MaxAdmissionHistoryLength {
+		t.Fatalf("Got %d records in the admission history, want %d", len(wl.Status.AdmissionHistory), MaxAdmissionHistoryLength)
+	}
+	if first := wl.Status.AdmissionHistory[0].ClusterQueue; first != "cq-0" {
+		t.Errorf("The oldest records were not dropped, the first one is for %s", first)
+	}
+	if patch := admissionPatch(wl); !equality.Semantic.DeepEqual(patch.Status.AdmissionHistory, wl.Status.AdmissionHistory) {
+		t.Errorf("The admission patch doesn't include the admission history")
+	}
+}
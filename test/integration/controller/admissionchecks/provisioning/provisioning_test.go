@@ -165,7 +165,7 @@ var _ = ginkgo.Describe("Provisioning", func() {
 			ginkgo.By("Checking no provision request is created", func() {
 				provReqKey := types.NamespacedName{
 					Namespace: wlKey.Namespace,
-					Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name),
+					Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name, 1),
 				}
 				gomega.Consistently(func() error {
 					request := &autoscaling.ProvisioningRequest{}
@@ -203,7 +203,7 @@ var _ = ginkgo.Describe("Provisioning", func() {
 			ginkgo.By("Checking that the provision request is created", func() {
 				provReqKey := types.NamespacedName{
 					Namespace: wlKey.Namespace,
-					Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name),
+					Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name, 1),
 				}
 				gomega.Eventually(func() error {
 					return k8sClient.Get(ctx, provReqKey, createdRequest)
@@ -264,7 +264,7 @@ var _ = ginkgo.Describe("Provisioning", func() {
 			ginkgo.By("Checking provision request is deleted", func() {
 				provReqKey := types.NamespacedName{
 					Namespace: wlKey.Namespace,
-					Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name),
+					Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name, 1),
 				}
 				gomega.Eventually(func() error {
 					request := &autoscaling.ProvisioningRequest{}
@@ -300,7 +300,7 @@ var _ = ginkgo.Describe("Provisioning", func() {
 
 			provReqKey := types.NamespacedName{
 				Namespace: wlKey.Namespace,
-				Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name),
+				Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name, 1),
 			}
 			ginkgo.By("Setting the provision request as Provisioned", func() {
 				createdRequest := &autoscaling.ProvisioningRequest{}
@@ -372,7 +372,7 @@ var _ = ginkgo.Describe("Provisioning", func() {
 				createdRequest := &autoscaling.ProvisioningRequest{}
 				provReqKey := types.NamespacedName{
 					Namespace: wlKey.Namespace,
-					Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name),
+					Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name, 1),
 				}
 				gomega.Eventually(func() error {
 					err := k8sClient.Get(ctx, provReqKey, createdRequest)
@@ -427,7 +427,7 @@ var _ = ginkgo.Describe("Provisioning", func() {
 			createdRequest := &autoscaling.ProvisioningRequest{}
 			provReqKey := types.NamespacedName{
 				Namespace: wlKey.Namespace,
-				Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name),
+				Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name, 1),
 			}
 			ginkgo.By("Checking that the provision request is created", func() {
 				gomega.Eventually(func() error {
@@ -515,7 +515,7 @@ var _ = ginkgo.Describe("Provisioning", func() {
 			ginkgo.By("Checking no provision request is deleted", func() {
 				provReqKey := types.NamespacedName{
 					Namespace: wlKey.Namespace,
-					Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name),
+					Name:      provisioning.GetProvisioningRequestName(wlKey.Name, ac.Name, 1),
 				}
 				gomega.Eventually(func() error {
 					request := &autoscaling.ProvisioningRequest{}